@@ -0,0 +1,95 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ESAliasIndex is index alias points to, see https://www.elastic.co/guide/en/elasticsearch/reference/7.x/indices-aliases.html
+type ESAliasIndex struct {
+	// (Required, string) Name of index alias points to.
+	// +kubebuilder:validation:MinLength=1
+	Index string `json:"index"`
+	// (Optional, query object in string) Filter query used to limit the index alias. If specified, the index alias only applies to documents returned by the filter.
+	// +optional
+	// +kubebuilder:validation:Pattern=`[{\[]{1}([,:{}\[\]0-9.\-+Eaeflnr-u \n\r\t]|".*?")+[}\]]{1}`
+	Filter string `json:"filter,omitempty"`
+	// (Optional, boolean) If true, assigns the index as an alias’s write index. Defaults to false.
+	// +optional
+	IsWriteIndex bool `json:"is_write_index,omitempty"`
+	// (Optional, string) Custom routing value used to route operations to a specific shard.
+	// +optional
+	Routing string `json:"routing,omitempty"`
+	// (Optional, string) Custom routing value used for the alias’s indexing operations.
+	// +optional
+	IndexRouting string `json:"index_routing,omitempty"`
+	// (Optional, string) Custom routing value used for the alias’s search operations.
+	// +optional
+	SearchRouting string `json:"search_routing,omitempty"`
+}
+
+// ElasticSearchAliasSpec defines the desired state of ElasticSearchAlias
+type ElasticSearchAliasSpec struct {
+	// See more at https://www.elastic.co/guide/en/elasticsearch/reference/7.x/indices-aliases.html
+	// Name of ES alias
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:Pattern=`^[^-_+A-Z][^A-Z\\\/\*\?"\<\> ,|#]{0,254}$`
+	Name string `json:"name"`
+	// Should we drop alias if K8S object is deleted, default false. Indices are never dropped.
+	// +optional
+	DropOnDelete bool `json:"drop_on_delete,omitempty"`
+
+	// (Required, array of index objects) Indices alias points to. Alias is removed from indices which are not listed.
+	// +kubebuilder:validation:MinItems=1
+	Indices []ESAliasIndex `json:"indices"`
+}
+
+// ElasticSearchAliasStatus defines the observed state of ElasticSearchAlias
+type ElasticSearchAliasStatus struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// Indices alias points to, it is set once alias is managed by operator
+	// +optional
+	Indices []string `json:"indices,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticSearchAlias is the Schema for the elasticsearchaliases API
+type ElasticSearchAlias struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticSearchAliasSpec   `json:"spec,omitempty"`
+	Status ElasticSearchAliasStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticSearchAliasList contains a list of ElasticSearchAlias
+type ElasticSearchAliasList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticSearchAlias `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticSearchAlias{}, &ElasticSearchAliasList{})
+}
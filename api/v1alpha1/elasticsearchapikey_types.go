@@ -0,0 +1,107 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ESAPIKeyRoleDescriptor is role API key is limited to
+type ESAPIKeyRoleDescriptor struct {
+	// (Optional, array of strings) Cluster privileges, e.g. monitor.
+	// +optional
+	Cluster []string `json:"cluster,omitempty"`
+	// (Optional) Index privileges.
+	// +optional
+	Indices []ESRoleIndexPrivileges `json:"indices,omitempty"`
+}
+
+// ElasticSearchAPIKeySpec defines the desired state of ElasticSearchAPIKey
+type ElasticSearchAPIKeySpec struct {
+	// See more at https://www.elastic.co/guide/en/elasticsearch/reference/7.x/security-api-create-api-key.html
+	// Name of API key
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	Name string `json:"name"`
+	// (Optional) Roles API key is limited to, keys are role names. Without them API key has all privileges of
+	// user operator connects with.
+	// +optional
+	RoleDescriptors map[string]ESAPIKeyRoleDescriptor `json:"role_descriptors,omitempty"`
+	// (Optional, time units) Expiration of API key, e.g. 30d. API key never expires if not set.
+	// +optional
+	Expiration string `json:"expiration,omitempty"`
+	// (Optional, duration) How long before expiration new API key is created, e.g. 72h. Defaults to third of
+	// API key lifetime.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renew_before,omitempty"`
+	// (Optional, duration) How long old API key stays valid after it is replaced, e.g. 30m. Defaults to 1h.
+	// +optional
+	GracePeriod *metav1.Duration `json:"grace_period,omitempty"`
+	// (Optional) Name of Secret API key is stored in with keys id, api_key and encoded. Defaults to
+	// <metadata.name>-elasticsearch-api-key. Secret is owned by object.
+	// +optional
+	SecretName string `json:"secret_name,omitempty"`
+}
+
+// ElasticSearchAPIKeyStatus defines the observed state of ElasticSearchAPIKey
+type ElasticSearchAPIKeyStatus struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// Id of current API key
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Time current API key was created
+	// +optional
+	CreationTime *metav1.Time `json:"creation_time,omitempty"`
+	// Time current API key expires
+	// +optional
+	ExpirationTime *metav1.Time `json:"expiration_time,omitempty"`
+	// Generation of object current API key was created for
+	// +optional
+	ObservedGeneration int64 `json:"observed_generation,omitempty"`
+	// Id of replaced API key which is still valid during grace period
+	// +optional
+	PreviousID string `json:"previous_id,omitempty"`
+	// Time replaced API key is invalidated
+	// +optional
+	PreviousInvalidationTime *metav1.Time `json:"previous_invalidation_time,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticSearchAPIKey is the Schema for the elasticsearchapikeys API
+type ElasticSearchAPIKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticSearchAPIKeySpec   `json:"spec,omitempty"`
+	Status ElasticSearchAPIKeyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticSearchAPIKeyList contains a list of ElasticSearchAPIKey
+type ElasticSearchAPIKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticSearchAPIKey `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticSearchAPIKey{}, &ElasticSearchAPIKeyList{})
+}
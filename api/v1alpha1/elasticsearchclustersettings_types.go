@@ -0,0 +1,68 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ElasticSearchClusterSettingsSpec defines the desired state of ElasticSearchClusterSettings
+type ElasticSearchClusterSettingsSpec struct {
+	// See more at https://www.elastic.co/guide/en/elasticsearch/reference/7.x/cluster-update-settings.html
+	// Should we reset settings to their defaults if K8S object is deleted, default false
+	// +optional
+	DropOnDelete bool `json:"drop_on_delete,omitempty"`
+
+	// (Optional, map of strings) Persistent cluster settings in flat form, for example search.max_buckets: "20000".
+	// List values are comma separated. Settings not declared here are never changed.
+	// +optional
+	Persistent map[string]string `json:"persistent,omitempty"`
+}
+
+// ElasticSearchClusterSettingsStatus defines the observed state of ElasticSearchClusterSettings
+type ElasticSearchClusterSettingsStatus struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// Persistent settings which were last applied, settings removed from spec are reset to their defaults
+	// +optional
+	AppliedKeys []string `json:"applied_keys,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticSearchClusterSettings is the Schema for the elasticsearchclustersettings API
+type ElasticSearchClusterSettings struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticSearchClusterSettingsSpec   `json:"spec,omitempty"`
+	Status ElasticSearchClusterSettingsStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticSearchClusterSettingsList contains a list of ElasticSearchClusterSettings
+type ElasticSearchClusterSettingsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticSearchClusterSettings `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticSearchClusterSettings{}, &ElasticSearchClusterSettingsList{})
+}
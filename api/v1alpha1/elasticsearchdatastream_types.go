@@ -0,0 +1,83 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ElasticSearchDataStreamSpec defines the desired state of ElasticSearchDataStream
+type ElasticSearchDataStreamSpec struct {
+	// See more at https://www.elastic.co/guide/en/elasticsearch/reference/current/data-streams.html
+	// Name of ES data stream, it must match composable index template with data_stream enabled
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:Pattern=`^[^-_+.A-Z][^A-Z\\\/\*\?"\<\> ,|#:]{0,254}$`
+	Name string `json:"name"`
+	// Should we drop data stream with all its backing indices if K8S object is deleted, default false
+	// +optional
+	DropOnDelete bool `json:"drop_on_delete,omitempty"`
+}
+
+// ElasticSearchDataStreamStatus defines the observed state of ElasticSearchDataStream
+type ElasticSearchDataStreamStatus struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// Current generation of data stream, it is incremented by every rollover
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+	// Backing indices of data stream, the last one is write index
+	// +optional
+	BackingIndices []string `json:"backing_indices,omitempty"`
+	// Index template data stream was created from
+	// +optional
+	Template string `json:"template,omitempty"`
+	// ILM policy which manages backing indices
+	// +optional
+	ILMPolicy string `json:"ilm_policy,omitempty"`
+	// Health of data stream backing indices
+	// +optional
+	Health string `json:"health,omitempty"`
+	// Value of rollover annotation which was last handled
+	// +optional
+	LastRollover string `json:"last_rollover,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticSearchDataStream is the Schema for the elasticsearchdatastreams API
+type ElasticSearchDataStream struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticSearchDataStreamSpec   `json:"spec,omitempty"`
+	Status ElasticSearchDataStreamStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticSearchDataStreamList contains a list of ElasticSearchDataStream
+type ElasticSearchDataStreamList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticSearchDataStream `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticSearchDataStream{}, &ElasticSearchDataStreamList{})
+}
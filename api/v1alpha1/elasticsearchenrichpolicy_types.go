@@ -0,0 +1,120 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ElasticSearchEnrichPolicySpec defines the desired state of ElasticSearchEnrichPolicy
+type ElasticSearchEnrichPolicySpec struct {
+	// See more at https://www.elastic.co/guide/en/elasticsearch/reference/7.x/put-enrich-policy-api.html
+	// Name of enrich policy
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:Pattern=`^[^\\\/\*\?"\<\> ,|#]+$`
+	Name string `json:"name"`
+	// Should we drop enrich policy if K8S object is deleted, default false
+	// +optional
+	DropOnDelete bool `json:"drop_on_delete,omitempty"`
+
+	// (Optional, string) Type of enrich policy, one of match, geo_match or range. Defaults to match.
+	// +optional
+	// +kubebuilder:validation:Enum=match;geo_match;range
+	// +kubebuilder:default=match
+	Type string `json:"type,omitempty"`
+	// (Required, array of strings) Source indices used to create enrich index.
+	// +kubebuilder:validation:MinItems=1
+	Indices []string `json:"indices"`
+	// (Required, string) Field in source indices used to match incoming documents.
+	// +kubebuilder:validation:MinLength=1
+	MatchField string `json:"match_field"`
+	// (Required, array of strings) Fields added to matching incoming documents from enrich index.
+	// +kubebuilder:validation:MinItems=1
+	EnrichFields []string `json:"enrich_fields"`
+	// (Optional, object in string) Query used to filter documents in enrich index, must be valid JSON.
+	// +optional
+	// +kubebuilder:validation:Pattern=`[{\[]{1}([,:{}\[\]0-9.\-+Eaeflnr-u \n\r\t]|".*?")+[}\]]{1}`
+	Query string `json:"query,omitempty"`
+	// (Optional, string) Cron schedule to re-execute enrich policy with, in UTC.
+	// Standard 5 fields are supported, e.g. "0 3 * * *", as well as macros like @daily.
+	// Policy is always executed when it is created or recreated.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+}
+
+// ESEnrichPolicyExecution is state of enrich policy execution
+type ESEnrichPolicyExecution struct {
+	// Id of execute enrich policy task
+	// +optional
+	Task string `json:"task,omitempty"`
+	// Phase of execution, one of Running, Succeeded or Failed
+	// +optional
+	Phase ESJobPhase `json:"phase,omitempty"`
+	// Time execution was started
+	// +optional
+	StartTime *metav1.Time `json:"start_time,omitempty"`
+	// Time execution was finished
+	// +optional
+	CompletionTime *metav1.Time `json:"completion_time,omitempty"`
+	// Reason of failed execution
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// ElasticSearchEnrichPolicyStatus defines the observed state of ElasticSearchEnrichPolicy
+type ElasticSearchEnrichPolicyStatus struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// Name of enrich policy managed by object. Enrich policies have no metadata, so ownership is recorded here.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Generation of object enrich policy was last executed for
+	// +optional
+	ObservedGeneration int64 `json:"observed_generation,omitempty"`
+	// Last execution of enrich policy
+	// +optional
+	LastExecution *ESEnrichPolicyExecution `json:"last_execution,omitempty"`
+	// Time of next scheduled execution of enrich policy
+	// +optional
+	NextExecutionTime *metav1.Time `json:"next_execution_time,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticSearchEnrichPolicy is the Schema for the elasticsearchenrichpolicies API
+type ElasticSearchEnrichPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticSearchEnrichPolicySpec   `json:"spec,omitempty"`
+	Status ElasticSearchEnrichPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticSearchEnrichPolicyList contains a list of ElasticSearchEnrichPolicy
+type ElasticSearchEnrichPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticSearchEnrichPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticSearchEnrichPolicy{}, &ElasticSearchEnrichPolicyList{})
+}
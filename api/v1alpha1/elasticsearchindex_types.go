@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -154,6 +155,36 @@ type ESIndexSettings struct {
 	// The final ingest node pipeline for this index. Index requests will fail if the final pipeline is set and the pipeline does not exist. The final pipeline always runs after the request pipeline (if specified) and the default pipeline (if it exists). The special pipeline name _none indicates no ingest pipeline will run.
 	// +optional
 	FinalPipeline string `json:"final_pipeline,omitempty"`
+	// Index lifecycle management (ILM) settings. Supported only by Elasticsearch clusters.
+	// +optional
+	Lifecycle *ESIndexLifecycle `json:"lifecycle,omitempty"`
+	// Plugin settings. Supported only by OpenSearch clusters.
+	// +optional
+	Plugins *ESIndexPlugins `json:"plugins,omitempty"`
+}
+
+// ESIndexLifecycle defines Elasticsearch ILM settings of index
+type ESIndexLifecycle struct {
+	// The name of the ILM policy used to manage the index.
+	// +optional
+	Name string `json:"name,omitempty"`
+	// The index alias to update when the index rolls over.
+	// +optional
+	RolloverAlias string `json:"rollover_alias,omitempty"`
+}
+
+// ESIndexPlugins defines OpenSearch plugin settings of index
+type ESIndexPlugins struct {
+	// Index State Management (ISM) settings.
+	// +optional
+	IndexStateManagement ESIndexStateManagement `json:"index_state_management,omitempty"`
+}
+
+// ESIndexStateManagement defines OpenSearch ISM settings of index
+type ESIndexStateManagement struct {
+	// The index alias to update when the index rolls over.
+	// +optional
+	RolloverAlias string `json:"rollover_alias,omitempty"`
 }
 
 // ESShard would hold shard structure
@@ -216,6 +247,95 @@ type ESIndexRouting struct {
 	Rebalance  ESRoutingRebalanceEnable  `json:"rebalance,omitempty"`
 }
 
+// ESIndexRollover is rollover pattern of time-series indices, see https://www.elastic.co/guide/en/elasticsearch/reference/7.x/indices-rollover-index.html
+type ESIndexRollover struct {
+	// (Optional, time units) Write index is rolled over after this amount of time has elapsed from index creation, e.g. 7d.
+	// +optional
+	MaxAge string `json:"max_age,omitempty"`
+	// (Optional, integer) Write index is rolled over after the specified number of documents is reached.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxDocs int64 `json:"max_docs,omitempty"`
+	// (Optional, byte units) Write index is rolled over when the largest primary shard reaches a certain size, e.g. 50gb.
+	// +optional
+	MaxPrimaryShardSize string `json:"max_primary_shard_size,omitempty"`
+	// (Optional, integer) Number of index generations to keep, write index included. Older generations managed by operator are deleted. All generations are kept if not set.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Retention int64 `json:"retention,omitempty"`
+}
+
+// ESIndexBackup is snapshot taken before destructive operations on index: drop on delete, deletion of
+// old rollover generations and closing index to update inline synonyms
+type ESIndexBackup struct {
+	// Snapshot repository to store backup snapshots in, see ElasticSearchSnapshotRepository
+	// +kubebuilder:validation:MinLength=1
+	Repository string `json:"repository"`
+}
+
+// ESIndexBackupStatus is state of last backup snapshot of index
+type ESIndexBackupStatus struct {
+	// Name of backup snapshot
+	// +optional
+	Snapshot string `json:"snapshot,omitempty"`
+	// Indices stored in backup snapshot, they are about to be deleted or closed
+	// +optional
+	Indices []string `json:"indices,omitempty"`
+	// Destructive operation backup snapshot is taken for, every new operation gets new snapshot
+	// +optional
+	Operation string `json:"operation,omitempty"`
+	// Phase of backup snapshot, one of Running, Succeeded or Failed
+	// +optional
+	Phase ESJobPhase `json:"phase,omitempty"`
+	// Time backup snapshot was started
+	// +optional
+	StartTime *metav1.Time `json:"start_time,omitempty"`
+	// Time backup snapshot was finished
+	// +optional
+	CompletionTime *metav1.Time `json:"completion_time,omitempty"`
+}
+
+// ESIndexSynonyms is synonym filter of index analysis with synonym rules taken from ConfigMap,
+// see https://www.elastic.co/guide/en/elasticsearch/reference/7.x/analysis-synonym-graph-tokenfilter.html
+type ESIndexSynonyms struct {
+	// (Required, string) Name of synonym filter, analyzers refer to filter by this name.
+	// +kubebuilder:validation:MinLength=1
+	Filter string `json:"filter"`
+	// (Optional, string) Type of filter, synonym or synonym_graph. Defaults to synonym_graph.
+	// +optional
+	// +kubebuilder:validation:Enum=synonym;synonym_graph
+	// +kubebuilder:default=synonym_graph
+	Type string `json:"type,omitempty"`
+	// (Optional, boolean) If true, filter can only be used in search analyzers, which are reloaded once synonyms change.
+	// Defaults to false.
+	// +optional
+	Updateable bool `json:"updateable,omitempty"`
+	// (Optional, boolean) If true, index is closed to update inline synonyms, so it is unavailable for a moment.
+	// Otherwise changed inline synonyms are not pushed and are reported in status. Synonyms sets are updated
+	// without closing index. Defaults to false.
+	// +optional
+	CloseIndex bool `json:"close_index,omitempty"`
+	// (Required) ConfigMap key with synonym rules in Solr format, one rule per line. Empty lines and lines starting with # are ignored.
+	ConfigMapRef corev1.ConfigMapKeySelector `json:"config_map_ref"`
+}
+
+// ESIndexSynonymsStatus is version of synonyms pushed to synonym filter
+type ESIndexSynonymsStatus struct {
+	// Name of synonym filter
+	Filter string `json:"filter"`
+	// Synonyms set filter refers to, it is only used with ES synonyms API
+	// +optional
+	SynonymsSet string `json:"synonyms_set,omitempty"`
+	// Version of synonyms, hash of synonym rules
+	Version string `json:"version"`
+	// Version of synonyms which are not pushed, as index must be closed for it and close_index is not set
+	// +optional
+	PendingVersion string `json:"pending_version,omitempty"`
+	// Time synonyms were last changed
+	// +optional
+	UpdateTime *metav1.Time `json:"update_time,omitempty"`
+}
+
 // ElasticSearchIndexSpec defines the desired state of ElasticSearchIndex
 // +k8s:openapi-gen=true
 type ElasticSearchIndexSpec struct {
@@ -235,6 +355,22 @@ type ElasticSearchIndexSpec struct {
 	// Mappings of ES Index
 	// +kubebuilder:validation:Pattern=`[{\[]{1}([,:{}\[\]0-9.\-+Eaeflnr-u \n\r\t]|".*?")+[}\]]{1}`
 	Mappings string `json:"mappings"`
+	// (Optional) Manage time-series indices with rollover pattern. Name is used as write alias and prefix of
+	// indices <name>-000001, <name>-000002 and so on. Settings and mappings are applied to current write index.
+	// +optional
+	Rollover *ESIndexRollover `json:"rollover,omitempty"`
+	// (Optional) Take snapshot of indices and wait for it before they are deleted or closed. Indices are never
+	// deleted or closed if snapshot fails.
+	// +optional
+	Backup *ESIndexBackup `json:"backup,omitempty"`
+	// (Optional) Analysis settings of index, must be valid JSON. Analysis is applied when index is created.
+	// +optional
+	// +kubebuilder:validation:Pattern=`[{\[]{1}([,:{}\[\]0-9.\-+Eaeflnr-u \n\r\t]|".*?")+[}\]]{1}`
+	Analysis string `json:"analysis,omitempty"`
+	// (Optional) Synonym filters of index analysis with synonym rules taken from ConfigMaps. Synonyms are pushed
+	// to ES cluster every time ConfigMap changes.
+	// +optional
+	Synonyms []ESIndexSynonyms `json:"synonyms,omitempty"`
 
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "export GOROOT=/usr/local/go; operator-sdk generate k8s" to regenerate code after modifying this file
@@ -246,6 +382,12 @@ type ElasticSearchIndexSpec struct {
 type ElasticSearchIndexStatus struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=status
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// Last backup snapshot taken before destructive operation
+	// +optional
+	Backup *ESIndexBackupStatus `json:"backup,omitempty"`
+	// Synonyms pushed to synonym filters
+	// +optional
+	Synonyms []ESIndexSynonymsStatus `json:"synonyms,omitempty"`
 }
 
 //+kubebuilder:object:root=true
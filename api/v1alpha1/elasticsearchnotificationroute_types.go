@@ -0,0 +1,67 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ElasticSearchNotificationRouteSpec defines which operator notifications are routed to which notifier target
+type ElasticSearchNotificationRouteSpec struct {
+	// Namespaces of objects which notifications are routed by this rule. If empty - objects from all namespaces are matched.
+	// +optional
+	// +listType=set
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Label selector of objects which notifications are routed by this rule. If empty - all objects are matched.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Types of messages routed by this rule. Possible values are OK, Warning and Error. If empty - all message types are matched.
+	// +optional
+	// +listType=set
+	MessageTypes []NotificationMessageType `json:"message_types,omitempty"`
+	// Slack channel to which matched messages would be sent.
+	// +kubebuilder:validation:MinLength=1
+	SlackChannel string `json:"slack_channel"`
+}
+
+// NotificationMessageType is type of message operator sends
+// +kubebuilder:validation:Enum=OK;Warning;Error
+type NotificationMessageType string
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster
+
+// ElasticSearchNotificationRoute is the Schema for the elasticsearchnotificationroutes API
+type ElasticSearchNotificationRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ElasticSearchNotificationRouteSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticSearchNotificationRouteList contains a list of ElasticSearchNotificationRoute
+type ElasticSearchNotificationRouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticSearchNotificationRoute `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticSearchNotificationRoute{}, &ElasticSearchNotificationRouteList{})
+}
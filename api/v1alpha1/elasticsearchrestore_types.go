@@ -0,0 +1,110 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ElasticSearchRestoreSpec defines the desired state of ElasticSearchRestore
+type ElasticSearchRestoreSpec struct {
+	// See more at https://www.elastic.co/guide/en/elasticsearch/reference/7.x/restore-snapshot-api.html
+	// (Required, string) Snapshot repository snapshot is stored in.
+	// +kubebuilder:validation:MinLength=1
+	Repository string `json:"repository"`
+	// (Required, string) Name of snapshot to restore.
+	// +kubebuilder:validation:MinLength=1
+	Snapshot string `json:"snapshot"`
+
+	// (Optional, array of strings) Data streams and indices to restore, wildcards are supported. Defaults to all regular indices of snapshot.
+	// +optional
+	Indices []string `json:"indices,omitempty"`
+	// (Optional, boolean) If true, indices missing in snapshot are ignored. Defaults to false.
+	// +optional
+	IgnoreUnavailable bool `json:"ignore_unavailable,omitempty"`
+	// (Optional, boolean) If true, cluster state is restored as well. Defaults to false.
+	// +optional
+	IncludeGlobalState bool `json:"include_global_state,omitempty"`
+	// (Optional, boolean) If true, indices which were snapshotted partially are restored. Defaults to false.
+	// +optional
+	Partial bool `json:"partial,omitempty"`
+	// (Optional, boolean) If true, aliases are restored together with indices. Defaults to true.
+	// +optional
+	IncludeAliases *bool `json:"include_aliases,omitempty"`
+	// (Optional, regular expression) Pattern of index names to rename on restore, e.g. (.+).
+	// +optional
+	RenamePattern string `json:"rename_pattern,omitempty"`
+	// (Optional, string) Replacement of rename pattern, it may reference pattern groups, e.g. restored-$1.
+	// +optional
+	RenameReplacement string `json:"rename_replacement,omitempty"`
+	// (Optional, index settings object in string form) Settings which override settings of restored indices, e.g. {"index.number_of_replicas":0}.
+	// +optional
+	// +kubebuilder:validation:Pattern=`[{\[]{1}([,:{}\[\]0-9.\-+Eaeflnr-u \n\r\t]|".*?")+[}\]]{1}`
+	IndexSettings string `json:"index_settings,omitempty"`
+	// (Optional, array of strings) Settings of restored indices to reset to default values.
+	// +optional
+	IgnoreIndexSettings []string `json:"ignore_index_settings,omitempty"`
+}
+
+// ElasticSearchRestoreStatus defines the observed state of ElasticSearchRestore
+type ElasticSearchRestoreStatus struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// Phase of restore: Running, Succeeded or Failed. Spec changes are ignored once restore is started.
+	// +optional
+	Phase ESJobPhase `json:"phase,omitempty"`
+	// Time restore was started
+	// +optional
+	StartTime *metav1.Time `json:"start_time,omitempty"`
+	// Time all restored shards were recovered
+	// +optional
+	CompletionTime *metav1.Time `json:"completion_time,omitempty"`
+	// Indices which are restored, with names after rename
+	// +optional
+	Indices []string `json:"indices,omitempty"`
+	// Number of shards to recover
+	// +optional
+	ShardsTotal int64 `json:"shards_total,omitempty"`
+	// Number of shards which are recovered
+	// +optional
+	ShardsDone int64 `json:"shards_done,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticSearchRestore is the Schema for the elasticsearchrestores API
+type ElasticSearchRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticSearchRestoreSpec   `json:"spec,omitempty"`
+	Status ElasticSearchRestoreStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticSearchRestoreList contains a list of ElasticSearchRestore
+type ElasticSearchRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticSearchRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticSearchRestore{}, &ElasticSearchRestoreList{})
+}
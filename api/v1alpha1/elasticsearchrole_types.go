@@ -0,0 +1,103 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ESRoleFieldSecurity is field level security of index privileges
+type ESRoleFieldSecurity struct {
+	// (Optional, array of strings) Fields the role grants read access to, wildcards are supported.
+	// +optional
+	Grant []string `json:"grant,omitempty"`
+	// (Optional, array of strings) Fields excluded from granted ones, wildcards are supported.
+	// +optional
+	Except []string `json:"except,omitempty"`
+}
+
+// ESRoleIndexPrivileges are privileges of role on indices
+type ESRoleIndexPrivileges struct {
+	// (Required, array of strings) Data streams, aliases and indices privileges apply to, wildcards are supported.
+	// +kubebuilder:validation:MinItems=1
+	Names []string `json:"names"`
+	// (Required, array of strings) Index privileges, e.g. read, write, create_index, manage.
+	// +kubebuilder:validation:MinItems=1
+	Privileges []string `json:"privileges"`
+	// (Optional) Document fields the role has read access to.
+	// +optional
+	FieldSecurity *ESRoleFieldSecurity `json:"field_security,omitempty"`
+	// (Optional, string) Search query which defines documents the role has read access to, must be valid JSON.
+	// +optional
+	Query string `json:"query,omitempty"`
+	// (Optional, boolean) If true, names may match restricted indices such as .security. Defaults to false.
+	// +optional
+	AllowRestrictedIndices bool `json:"allow_restricted_indices,omitempty"`
+}
+
+// ElasticSearchRoleSpec defines the desired state of ElasticSearchRole
+type ElasticSearchRoleSpec struct {
+	// See more at https://www.elastic.co/guide/en/elasticsearch/reference/7.x/security-api-put-role.html
+	// Name of role in native realm
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=507
+	Name string `json:"name"`
+	// Should we drop role if K8S object is deleted, default false
+	// +optional
+	DropOnDelete bool `json:"drop_on_delete,omitempty"`
+
+	// (Optional, array of strings) Cluster privileges, e.g. monitor, manage_index_templates.
+	// +optional
+	Cluster []string `json:"cluster,omitempty"`
+	// (Optional) Index privileges.
+	// +optional
+	Indices []ESRoleIndexPrivileges `json:"indices,omitempty"`
+	// (Optional, array of strings) Users the owners of this role can impersonate.
+	// +optional
+	RunAs []string `json:"run_as,omitempty"`
+}
+
+// ElasticSearchRoleStatus defines the observed state of ElasticSearchRole
+type ElasticSearchRoleStatus struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticSearchRole is the Schema for the elasticsearchroles API
+type ElasticSearchRole struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticSearchRoleSpec   `json:"spec,omitempty"`
+	Status ElasticSearchRoleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticSearchRoleList contains a list of ElasticSearchRole
+type ElasticSearchRoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticSearchRole `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticSearchRole{}, &ElasticSearchRoleList{})
+}
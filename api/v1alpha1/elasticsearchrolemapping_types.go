@@ -0,0 +1,90 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ESRoleMappingTemplate is mustache template which resolves to role names
+type ESRoleMappingTemplate struct {
+	// (Required, object in string) Template object, must be valid JSON, for example {"source":"{{#tojson}}groups{{/tojson}}"}.
+	// +kubebuilder:validation:Pattern=`[{\[]{1}([,:{}\[\]0-9.\-+Eaeflnr-u \n\r\t]|".*?")+[}\]]{1}`
+	Template string `json:"template"`
+	// (Optional, string) Format of template output, string resolves to single role name and json to one or more. Defaults to string.
+	// +optional
+	// +kubebuilder:validation:Enum=string;json
+	// +kubebuilder:default=string
+	Format string `json:"format,omitempty"`
+}
+
+// ElasticSearchRoleMappingSpec defines the desired state of ElasticSearchRoleMapping
+type ElasticSearchRoleMappingSpec struct {
+	// See more at https://www.elastic.co/guide/en/elasticsearch/reference/7.x/security-api-put-role-mapping.html
+	// Name of role mapping
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=507
+	Name string `json:"name"`
+	// Should we drop role mapping if K8S object is deleted, default false
+	// +optional
+	DropOnDelete bool `json:"drop_on_delete,omitempty"`
+
+	// (Required, object in string) Rules user must match, must be valid JSON. Rules are built of any, all, field and except
+	// expressions, for example {"all":[{"field":{"realm.name":"saml1"}},{"field":{"groups":"admins"}}]}.
+	// +kubebuilder:validation:Pattern=`[{\[]{1}([,:{}\[\]0-9.\-+Eaeflnr-u \n\r\t]|".*?")+[}\]]{1}`
+	Rules string `json:"rules"`
+	// (Optional, array of strings) Roles granted to users which match rules. Either roles or role_templates must be set.
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+	// (Optional) Templates of roles granted to users which match rules. Either roles or role_templates must be set.
+	// +optional
+	RoleTemplates []ESRoleMappingTemplate `json:"role_templates,omitempty"`
+	// (Optional, boolean) Mappings that have enabled set to false are ignored. Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// ElasticSearchRoleMappingStatus defines the observed state of ElasticSearchRoleMapping
+type ElasticSearchRoleMappingStatus struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticSearchRoleMapping is the Schema for the elasticsearchrolemappings API
+type ElasticSearchRoleMapping struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticSearchRoleMappingSpec   `json:"spec,omitempty"`
+	Status ElasticSearchRoleMappingStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticSearchRoleMappingList contains a list of ElasticSearchRoleMapping
+type ElasticSearchRoleMappingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticSearchRoleMapping `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticSearchRoleMapping{}, &ElasticSearchRoleMappingList{})
+}
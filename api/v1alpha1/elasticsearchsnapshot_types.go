@@ -0,0 +1,120 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ESJobPhase is phase of one-shot operation like snapshot or restore
+type ESJobPhase string
+
+const (
+	// JobPhaseRunning is phase of operation which is started and not yet finished
+	JobPhaseRunning ESJobPhase = "Running"
+	// JobPhaseSucceeded is phase of operation which finished successfully
+	JobPhaseSucceeded ESJobPhase = "Succeeded"
+	// JobPhaseFailed is phase of operation which finished with error
+	JobPhaseFailed ESJobPhase = "Failed"
+)
+
+// ElasticSearchSnapshotSpec defines the desired state of ElasticSearchSnapshot
+type ElasticSearchSnapshotSpec struct {
+	// See more at https://www.elastic.co/guide/en/elasticsearch/reference/7.x/create-snapshot-api.html
+	// Name of snapshot, date math is not supported
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:Pattern=`^[^-_+A-Z][^A-Z\\\/\*\?"\<\> ,|#]{0,254}$`
+	Name string `json:"name"`
+	// (Required, string) Snapshot repository to store snapshot in.
+	// +kubebuilder:validation:MinLength=1
+	Repository string `json:"repository"`
+	// Should we delete snapshot from repository if K8S object is deleted, default false
+	// +optional
+	DropOnDelete bool `json:"drop_on_delete,omitempty"`
+
+	// (Optional, array of strings) Data streams and indices to include in snapshot, wildcards are supported. Defaults to all data streams and indices.
+	// +optional
+	Indices []string `json:"indices,omitempty"`
+	// (Optional, boolean) If true, missing data streams and indices are ignored. Defaults to false.
+	// +optional
+	IgnoreUnavailable bool `json:"ignore_unavailable,omitempty"`
+	// (Optional, boolean) If true, cluster state is included in snapshot. Defaults to true.
+	// +optional
+	IncludeGlobalState *bool `json:"include_global_state,omitempty"`
+	// (Optional, boolean) If true, snapshot of index with unavailable primary shards is taken partially and snapshot succeeds. Defaults to false.
+	// +optional
+	Partial bool `json:"partial,omitempty"`
+}
+
+// ElasticSearchSnapshotStatus defines the observed state of ElasticSearchSnapshot
+type ElasticSearchSnapshotStatus struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// Phase of snapshot: Running, Succeeded or Failed. Spec changes are ignored once snapshot is started.
+	// +optional
+	Phase ESJobPhase `json:"phase,omitempty"`
+	// State of snapshot as ES reports it, e.g. IN_PROGRESS, SUCCESS, PARTIAL or FAILED
+	// +optional
+	State string `json:"state,omitempty"`
+	// Time snapshot was started
+	// +optional
+	StartTime *metav1.Time `json:"start_time,omitempty"`
+	// Time snapshot was finished
+	// +optional
+	CompletionTime *metav1.Time `json:"completion_time,omitempty"`
+	// Indices included in snapshot
+	// +optional
+	Indices []string `json:"indices,omitempty"`
+	// Number of shards to snapshot
+	// +optional
+	ShardsTotal int64 `json:"shards_total,omitempty"`
+	// Number of shards which are snapshotted
+	// +optional
+	ShardsDone int64 `json:"shards_done,omitempty"`
+	// Number of shards which failed to snapshot
+	// +optional
+	ShardsFailed int64 `json:"shards_failed,omitempty"`
+	// Reasons of shard failures
+	// +optional
+	Failures []string `json:"failures,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticSearchSnapshot is the Schema for the elasticsearchsnapshots API
+type ElasticSearchSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticSearchSnapshotSpec   `json:"spec,omitempty"`
+	Status ElasticSearchSnapshotStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticSearchSnapshotList contains a list of ElasticSearchSnapshot
+type ElasticSearchSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticSearchSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticSearchSnapshot{}, &ElasticSearchSnapshotList{})
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ESSnapshotPolicyConfig is configuration of snapshots taken by SLM policy
+type ESSnapshotPolicyConfig struct {
+	// (Optional, array of strings) Data streams and indices to include in snapshot, wildcards are supported. Defaults to all data streams and indices.
+	// +optional
+	Indices []string `json:"indices,omitempty"`
+	// (Optional, boolean) If true, missing data streams and indices are ignored. Defaults to false.
+	// +optional
+	IgnoreUnavailable bool `json:"ignore_unavailable,omitempty"`
+	// (Optional, boolean) If true, cluster state is included in snapshot. Defaults to true.
+	// +optional
+	IncludeGlobalState *bool `json:"include_global_state,omitempty"`
+	// (Optional, boolean) If true, snapshot of index with unavailable primary shards is taken partially. Defaults to false.
+	// +optional
+	Partial bool `json:"partial,omitempty"`
+	// (Optional, array of strings) Feature states to include in snapshot.
+	// +optional
+	FeatureStates []string `json:"feature_states,omitempty"`
+}
+
+// ESSnapshotPolicyRetention is retention of snapshots taken by SLM policy
+type ESSnapshotPolicyRetention struct {
+	// (Optional, time units) Time period after which snapshot is considered expired and eligible for deletion, e.g. 30d.
+	// +optional
+	ExpireAfter string `json:"expire_after,omitempty"`
+	// (Optional, integer) Minimum number of snapshots to retain, even if they are expired.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinCount int64 `json:"min_count,omitempty"`
+	// (Optional, integer) Maximum number of snapshots to retain, even if they are not yet expired.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxCount int64 `json:"max_count,omitempty"`
+}
+
+// ElasticSearchSnapshotPolicySpec defines the desired state of ElasticSearchSnapshotPolicy
+type ElasticSearchSnapshotPolicySpec struct {
+	// See more at https://www.elastic.co/guide/en/elasticsearch/reference/7.x/slm-api-put-policy.html
+	// Name (policy_id) of SLM policy
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	Name string `json:"name"`
+	// Should we drop policy if K8S object is deleted, default false. Snapshots taken by policy are never deleted.
+	// +optional
+	DropOnDelete bool `json:"drop_on_delete,omitempty"`
+
+	// (Required, cron expression) Periodic schedule of snapshots, e.g. "0 30 1 * * ?".
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+	// (Required, string) Name pattern of snapshots, date math is supported, e.g. "<nightly-snap-{now/d}>".
+	// +kubebuilder:validation:MinLength=1
+	SnapshotName string `json:"snapshot_name"`
+	// (Required, string) Snapshot repository to store snapshots in, e.g. repository of ElasticSearchSnapshotRepository.
+	// +kubebuilder:validation:MinLength=1
+	Repository string `json:"repository"`
+	// (Optional) Configuration of snapshots, it selects indices to back up.
+	// +optional
+	Config *ESSnapshotPolicyConfig `json:"config,omitempty"`
+	// (Optional) Retention of snapshots taken by policy.
+	// +optional
+	Retention *ESSnapshotPolicyRetention `json:"retention,omitempty"`
+}
+
+// ElasticSearchSnapshotPolicyStatus defines the observed state of ElasticSearchSnapshotPolicy
+type ElasticSearchSnapshotPolicyStatus struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// Time of last successful snapshot
+	// +optional
+	LastSuccess *metav1.Time `json:"last_success,omitempty"`
+	// Name of last successful snapshot
+	// +optional
+	LastSuccessSnapshot string `json:"last_success_snapshot,omitempty"`
+	// Time of last failed snapshot
+	// +optional
+	LastFailure *metav1.Time `json:"last_failure,omitempty"`
+	// Name of last failed snapshot
+	// +optional
+	LastFailureSnapshot string `json:"last_failure_snapshot,omitempty"`
+	// Details of last snapshot failure
+	// +optional
+	LastFailureDetails string `json:"last_failure_details,omitempty"`
+	// Time of next scheduled snapshot
+	// +optional
+	NextExecution *metav1.Time `json:"next_execution,omitempty"`
+	// Value of execute annotation which was last handled
+	// +optional
+	LastExecute string `json:"last_execute,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticSearchSnapshotPolicy is the Schema for the elasticsearchsnapshotpolicies API
+type ElasticSearchSnapshotPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticSearchSnapshotPolicySpec   `json:"spec,omitempty"`
+	Status ElasticSearchSnapshotPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticSearchSnapshotPolicyList contains a list of ElasticSearchSnapshotPolicy
+type ElasticSearchSnapshotPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticSearchSnapshotPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticSearchSnapshotPolicy{}, &ElasticSearchSnapshotPolicyList{})
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ESSnapshotRepositoryType is type of snapshot repository
+// +kubebuilder:validation:Enum=fs;url;s3;gcs;azure
+type ESSnapshotRepositoryType string
+
+const (
+	// SnapshotRepositoryFS is shared file system repository
+	SnapshotRepositoryFS ESSnapshotRepositoryType = "fs"
+	// SnapshotRepositoryURL is read-only URL repository
+	SnapshotRepositoryURL ESSnapshotRepositoryType = "url"
+	// SnapshotRepositoryS3 is AWS S3 repository
+	SnapshotRepositoryS3 ESSnapshotRepositoryType = "s3"
+	// SnapshotRepositoryGCS is Google Cloud Storage repository
+	SnapshotRepositoryGCS ESSnapshotRepositoryType = "gcs"
+	// SnapshotRepositoryAzure is Azure Blob Storage repository
+	SnapshotRepositoryAzure ESSnapshotRepositoryType = "azure"
+)
+
+// ESSnapshotRepositorySecretSetting is repository setting with value taken from Secret
+type ESSnapshotRepositorySecretSetting struct {
+	// (Required, string) Name of repository setting, e.g. access_key.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// (Required) Key of Secret in namespace of object which holds setting value.
+	SecretKeyRef corev1.SecretKeySelector `json:"secret_key_ref"`
+}
+
+// ElasticSearchSnapshotRepositorySpec defines the desired state of ElasticSearchSnapshotRepository
+type ElasticSearchSnapshotRepositorySpec struct {
+	// See more at https://www.elastic.co/guide/en/elasticsearch/reference/7.x/put-snapshot-repo-api.html
+	// Name of snapshot repository
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	Name string `json:"name"`
+	// Should we drop repository if K8S object is deleted, default false. Snapshots in repository are never deleted.
+	// +optional
+	DropOnDelete bool `json:"drop_on_delete,omitempty"`
+
+	// (Required, string) Repository type: fs, url, s3, gcs or azure. Types other than fs and url require repository plugin on ES versions before 8.0.
+	Type ESSnapshotRepositoryType `json:"type"`
+	// (Optional) Settings of repository, e.g. location for fs or bucket for s3. Supported settings depend on repository type.
+	// +optional
+	Settings map[string]string `json:"settings,omitempty"`
+	// (Optional) Settings of repository with values taken from Secrets, they override settings with same name.
+	// +optional
+	SecretSettings []ESSnapshotRepositorySecretSetting `json:"secret_settings,omitempty"`
+}
+
+// ElasticSearchSnapshotRepositoryStatus defines the observed state of ElasticSearchSnapshotRepository
+type ElasticSearchSnapshotRepositoryStatus struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticSearchSnapshotRepository is the Schema for the elasticsearchsnapshotrepositories API
+type ElasticSearchSnapshotRepository struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticSearchSnapshotRepositorySpec   `json:"spec,omitempty"`
+	Status ElasticSearchSnapshotRepositoryStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticSearchSnapshotRepositoryList contains a list of ElasticSearchSnapshotRepository
+type ElasticSearchSnapshotRepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticSearchSnapshotRepository `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticSearchSnapshotRepository{}, &ElasticSearchSnapshotRepositoryList{})
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ElasticSearchStoredScriptSpec defines the desired state of ElasticSearchStoredScript
+type ElasticSearchStoredScriptSpec struct {
+	// See more at https://www.elastic.co/guide/en/elasticsearch/reference/7.x/create-stored-script-api.html
+	// Id of stored script or search template
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:Pattern=`^[^\\\/\*\?"\<\> ,|#]+$`
+	ID string `json:"id"`
+	// Should we drop stored script if K8S object is deleted, default false
+	// +optional
+	DropOnDelete bool `json:"drop_on_delete,omitempty"`
+
+	// (Optional, string) Script language, mustache for search templates. Defaults to painless.
+	// +optional
+	// +kubebuilder:validation:Enum=painless;mustache;expression
+	// +kubebuilder:default=painless
+	Lang string `json:"lang,omitempty"`
+	// (Required, string) Script source, for search templates it is mustache template of search request body.
+	// +kubebuilder:validation:MinLength=1
+	Source string `json:"source"`
+	// (Optional, object in string) Sample params search template is rendered with, must be valid JSON.
+	// +optional
+	// +kubebuilder:validation:Pattern=`[{\[]{1}([,:{}\[\]0-9.\-+Eaeflnr-u \n\r\t]|".*?")+[}\]]{1}`
+	Params string `json:"params,omitempty"`
+	// (Optional, boolean) If true, search template is rendered with params before it is stored,
+	// template which fails to render is not stored. Only mustache scripts can be validated. Defaults to false.
+	// +optional
+	Validate bool `json:"validate,omitempty"`
+}
+
+// ElasticSearchStoredScriptStatus defines the observed state of ElasticSearchStoredScript
+type ElasticSearchStoredScriptStatus struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// Id of stored script managed by object. Stored scripts have no metadata, so ownership is recorded here.
+	// +optional
+	ID string `json:"id,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticSearchStoredScript is the Schema for the elasticsearchstoredscripts API
+type ElasticSearchStoredScript struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticSearchStoredScriptSpec   `json:"spec,omitempty"`
+	Status ElasticSearchStoredScriptStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticSearchStoredScriptList contains a list of ElasticSearchStoredScript
+type ElasticSearchStoredScriptList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticSearchStoredScript `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticSearchStoredScript{}, &ElasticSearchStoredScriptList{})
+}
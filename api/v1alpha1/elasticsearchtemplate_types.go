@@ -82,6 +82,17 @@ type ElasticSearchTemplateSpec struct {
 	// +kubebuilder:validation:MinValue=1
 	Version int64 `json:"version,omitempty"`
 
+	// (Optional, boolean) Create composable index template (_index_template API) instead of legacy one. Templates are always composable on OpenSearch clusters.
+	// +optional
+	Composable bool `json:"composable,omitempty"`
+	// (Optional, integer) Priority of composable index template. Template with highest priority is applied when several templates match new index.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Priority int64 `json:"priority,omitempty"`
+	// (Optional, boolean) Indices matching template are created as data streams, see ElasticSearchDataStream. Implies composable template.
+	// +optional
+	DataStream bool `json:"data_stream,omitempty"`
+
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "operator-sdk generate k8s" to regenerate code after modifying this file
 	// Add custom validation using kubebuilder tags: https://book-v1.book.kubebuilder.io/beyond_basics/generating_crd.html
@@ -0,0 +1,151 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ESTransformSource is source of transform
+type ESTransformSource struct {
+	// (Required, array of strings) Source indices of transform, wildcards are supported.
+	// +kubebuilder:validation:MinItems=1
+	Index []string `json:"index"`
+	// (Optional, object in string) Query used to select source documents, must be valid JSON.
+	// +optional
+	// +kubebuilder:validation:Pattern=`[{\[]{1}([,:{}\[\]0-9.\-+Eaeflnr-u \n\r\t]|".*?")+[}\]]{1}`
+	Query string `json:"query,omitempty"`
+}
+
+// ESTransformDest is destination of transform
+type ESTransformDest struct {
+	// (Required, string) Destination index of transform.
+	// +kubebuilder:validation:MinLength=1
+	Index string `json:"index"`
+	// (Optional, string) Ingest pipeline documents are sent through before they are indexed.
+	// +optional
+	Pipeline string `json:"pipeline,omitempty"`
+}
+
+// ESTransformSync is how continuous transform is synchronised with source indices
+type ESTransformSync struct {
+	// (Required) Time based synchronisation.
+	Time ESTransformSyncTime `json:"time"`
+}
+
+// ESTransformSyncTime is time based synchronisation of continuous transform
+type ESTransformSyncTime struct {
+	// (Required, string) Date field of source documents used to find new documents.
+	// +kubebuilder:validation:MinLength=1
+	Field string `json:"field"`
+	// (Optional, time units) Delay between current time and latest indexed document. Defaults to 60s.
+	// +optional
+	Delay string `json:"delay,omitempty"`
+}
+
+// ElasticSearchTransformSpec defines the desired state of ElasticSearchTransform
+type ElasticSearchTransformSpec struct {
+	// See more at https://www.elastic.co/guide/en/elasticsearch/reference/7.x/put-transform.html
+	// Id of transform
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=64
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([a-z0-9_\-]*[a-z0-9])?$`
+	ID string `json:"id"`
+	// Should we drop transform if K8S object is deleted, default false
+	// +optional
+	DropOnDelete bool `json:"drop_on_delete,omitempty"`
+
+	// (Optional, string) Free text description of transform.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// (Required) Source of transform.
+	Source ESTransformSource `json:"source"`
+	// (Required) Destination of transform.
+	Dest ESTransformDest `json:"dest"`
+	// (Optional, object in string) Pivot transform definition with group_by and aggregations, must be valid JSON.
+	// Either pivot or latest must be set. Pivot can't be updated, transform is recreated when it changes.
+	// +optional
+	// +kubebuilder:validation:Pattern=`[{\[]{1}([,:{}\[\]0-9.\-+Eaeflnr-u \n\r\t]|".*?")+[}\]]{1}`
+	Pivot string `json:"pivot,omitempty"`
+	// (Optional, object in string) Latest transform definition with unique_key and sort, must be valid JSON.
+	// Either pivot or latest must be set. Latest can't be updated, transform is recreated when it changes.
+	// +optional
+	// +kubebuilder:validation:Pattern=`[{\[]{1}([,:{}\[\]0-9.\-+Eaeflnr-u \n\r\t]|".*?")+[}\]]{1}`
+	Latest string `json:"latest,omitempty"`
+	// (Optional) Synchronisation of continuous transform, transform without sync is batch transform which runs once.
+	// +optional
+	Sync *ESTransformSync `json:"sync,omitempty"`
+	// (Optional, time units) Interval between checks for changes in source indices of continuous transform. Defaults to 1m.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[0-9]+(s|m|h)$`
+	Frequency string `json:"frequency,omitempty"`
+	// (Optional, boolean) Transform is started when enabled and stopped otherwise. Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// ElasticSearchTransformStatus defines the observed state of ElasticSearchTransform
+type ElasticSearchTransformStatus struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// State of transform, one of started, indexing, stopping, stopped, aborting or failed
+	// +optional
+	State string `json:"state,omitempty"`
+	// Reason of failed transform
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Health of transform, one of green, yellow or red. Only reported by Elasticsearch 8.x
+	// +optional
+	Health string `json:"health,omitempty"`
+	// Issues which make transform unhealthy
+	// +optional
+	HealthIssues []string `json:"health_issues,omitempty"`
+	// Last completed checkpoint of transform
+	// +optional
+	Checkpoint int64 `json:"checkpoint,omitempty"`
+	// Time last checkpoint was completed
+	// +optional
+	CheckpointTime *metav1.Time `json:"checkpoint_time,omitempty"`
+	// Number of source documents not yet processed by continuous transform
+	// +optional
+	OperationsBehind int64 `json:"operations_behind,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticSearchTransform is the Schema for the elasticsearchtransforms API
+type ElasticSearchTransform struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticSearchTransformSpec   `json:"spec,omitempty"`
+	Status ElasticSearchTransformStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticSearchTransformList contains a list of ElasticSearchTransform
+type ElasticSearchTransformList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticSearchTransform `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticSearchTransform{}, &ElasticSearchTransformList{})
+}
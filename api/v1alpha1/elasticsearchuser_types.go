@@ -0,0 +1,89 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ElasticSearchUserSpec defines the desired state of ElasticSearchUser
+type ElasticSearchUserSpec struct {
+	// See more at https://www.elastic.co/guide/en/elasticsearch/reference/7.x/security-api-put-user.html
+	// Username of user in native realm
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=507
+	Username string `json:"username"`
+	// Should we drop user if K8S object is deleted, default false
+	// +optional
+	DropOnDelete bool `json:"drop_on_delete,omitempty"`
+
+	// (Optional) Key of Secret in namespace of object with password of user, password is changed every time
+	// Secret changes. If not set, password is generated and stored in Secret <metadata.name>-elasticsearch-user
+	// with keys username and password, Secret is owned by object.
+	// +optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"password_secret_ref,omitempty"`
+	// (Optional, array of strings) Roles of user, e.g. roles of ElasticSearchRole objects.
+	// +optional
+	Roles []string `json:"roles,omitempty"`
+	// (Optional, string) Full name of user.
+	// +optional
+	FullName string `json:"full_name,omitempty"`
+	// (Optional, string) Email of user.
+	// +optional
+	Email string `json:"email,omitempty"`
+	// (Optional, boolean) Specifies whether user is enabled. Defaults to true.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// ElasticSearchUserStatus defines the observed state of ElasticSearchUser
+type ElasticSearchUserStatus struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// Name of Secret password of user is taken from
+	// +optional
+	PasswordSecret string `json:"password_secret,omitempty"`
+	// Salted hash of password which was last set, password is only set again when it changes
+	// +optional
+	PasswordVersion string `json:"password_version,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// ElasticSearchUser is the Schema for the elasticsearchusers API
+type ElasticSearchUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ElasticSearchUserSpec   `json:"spec,omitempty"`
+	Status ElasticSearchUserStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ElasticSearchUserList contains a list of ElasticSearchUser
+type ElasticSearchUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ElasticSearchUser `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ElasticSearchUser{}, &ElasticSearchUserList{})
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ISMState is state of ISM policy, see https://opensearch.org/docs/latest/im-plugin/ism/policies/#states
+type ISMState struct {
+	// Name of the state.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// (Optional, array of action objects in string form) Actions to perform in this state, each action must be valid JSON, for example {"rollover":{"min_size":"50gb"}}.
+	// +optional
+	Actions []string `json:"actions,omitempty"`
+	// (Optional, array of transition objects) Next states and conditions required to transition to them. If no transitions are specified, the policy assumes that it is complete.
+	// +optional
+	Transitions []ISMTransition `json:"transitions,omitempty"`
+}
+
+// ISMTransition is transition between ISM policy states
+type ISMTransition struct {
+	// Name of the state to transition to if the conditions are met.
+	// +kubebuilder:validation:MinLength=1
+	StateName string `json:"state_name"`
+	// (Optional) Conditions of transition. If no conditions are specified, the transition happens immediately.
+	// +optional
+	Conditions *ISMConditions `json:"conditions,omitempty"`
+}
+
+// ISMConditions are conditions of ISM transition
+type ISMConditions struct {
+	// The minimum age of the index required to transition, for example 30d.
+	// +optional
+	MinIndexAge string `json:"min_index_age,omitempty"`
+	// The minimum document count of the index required to transition.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	MinDocCount int64 `json:"min_doc_count,omitempty"`
+	// The minimum size of the total primary shard storage required to transition, for example 50gb.
+	// +optional
+	MinSize string `json:"min_size,omitempty"`
+	// The minimum age required after a rollover has occurred to transition to the next state.
+	// +optional
+	MinRolloverAge string `json:"min_rollover_age,omitempty"`
+}
+
+// ISMTemplate would attach ISM policy to newly created indices
+type ISMTemplate struct {
+	// Index patterns on which this policy has to be applied.
+	// +listType=set
+	IndexPatterns []string `json:"index_patterns"`
+	// (Optional, integer) Priority of the template, policy of template with highest priority is attached when several templates match index.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	Priority int64 `json:"priority,omitempty"`
+}
+
+// OpenSearchISMPolicySpec defines the desired state of OpenSearchISMPolicy
+type OpenSearchISMPolicySpec struct {
+	// See more at https://opensearch.org/docs/latest/im-plugin/ism/policies/
+	// Name (policy_id) of ISM policy
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=255
+	Name string `json:"name"`
+	// Should we drop policy if K8S object is deleted, default false
+	// +optional
+	DropOnDelete bool `json:"drop_on_delete,omitempty"`
+
+	// (Optional, string) Human-readable description of the policy.
+	// +optional
+	Description string `json:"description,omitempty"`
+	// (Required, string) The default starting state for each index that uses this policy.
+	// +kubebuilder:validation:MinLength=1
+	DefaultState string `json:"default_state"`
+	// (Required, array of state objects) The states that you define in the policy.
+	// +kubebuilder:validation:MinItems=1
+	States []ISMState `json:"states"`
+	// (Optional, array of ISM template objects) Templates which automatically attach policy to newly created indices.
+	// +optional
+	ISMTemplate []ISMTemplate `json:"ism_template,omitempty"`
+}
+
+// OpenSearchISMPolicyStatus defines the observed state of OpenSearchISMPolicy
+type OpenSearchISMPolicyStatus struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// OpenSearchISMPolicy is the Schema for the opensearchismpolicies API
+type OpenSearchISMPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenSearchISMPolicySpec   `json:"spec,omitempty"`
+	Status OpenSearchISMPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// OpenSearchISMPolicyList contains a list of OpenSearchISMPolicy
+type OpenSearchISMPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenSearchISMPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenSearchISMPolicy{}, &OpenSearchISMPolicyList{})
+}
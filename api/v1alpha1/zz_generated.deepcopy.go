@@ -22,10 +22,38 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESAPIKeyRoleDescriptor) DeepCopyInto(out *ESAPIKeyRoleDescriptor) {
+	*out = *in
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]ESRoleIndexPrivileges, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESAPIKeyRoleDescriptor.
+func (in *ESAPIKeyRoleDescriptor) DeepCopy() *ESAPIKeyRoleDescriptor {
+	if in == nil {
+		return nil
+	}
+	out := new(ESAPIKeyRoleDescriptor)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ESAlias) DeepCopyInto(out *ESAlias) {
 	*out = *in
@@ -41,274 +69,2545 @@ func (in *ESAlias) DeepCopyInto(out *ESAlias) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESAlias.
-func (in *ESAlias) DeepCopy() *ESAlias {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESAlias.
+func (in *ESAlias) DeepCopy() *ESAlias {
+	if in == nil {
+		return nil
+	}
+	out := new(ESAlias)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESAliasIndex) DeepCopyInto(out *ESAliasIndex) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESAliasIndex.
+func (in *ESAliasIndex) DeepCopy() *ESAliasIndex {
+	if in == nil {
+		return nil
+	}
+	out := new(ESAliasIndex)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESAnalyze) DeepCopyInto(out *ESAnalyze) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESAnalyze.
+func (in *ESAnalyze) DeepCopy() *ESAnalyze {
+	if in == nil {
+		return nil
+	}
+	out := new(ESAnalyze)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESEnrichPolicyExecution) DeepCopyInto(out *ESEnrichPolicyExecution) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESEnrichPolicyExecution.
+func (in *ESEnrichPolicyExecution) DeepCopy() *ESEnrichPolicyExecution {
+	if in == nil {
+		return nil
+	}
+	out := new(ESEnrichPolicyExecution)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESHighlights) DeepCopyInto(out *ESHighlights) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESHighlights.
+func (in *ESHighlights) DeepCopy() *ESHighlights {
+	if in == nil {
+		return nil
+	}
+	out := new(ESHighlights)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESIdle) DeepCopyInto(out *ESIdle) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESIdle.
+func (in *ESIdle) DeepCopy() *ESIdle {
+	if in == nil {
+		return nil
+	}
+	out := new(ESIdle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESIndexBackup) DeepCopyInto(out *ESIndexBackup) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESIndexBackup.
+func (in *ESIndexBackup) DeepCopy() *ESIndexBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(ESIndexBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESIndexBackupStatus) DeepCopyInto(out *ESIndexBackupStatus) {
+	*out = *in
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESIndexBackupStatus.
+func (in *ESIndexBackupStatus) DeepCopy() *ESIndexBackupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ESIndexBackupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESIndexBlocks) DeepCopyInto(out *ESIndexBlocks) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESIndexBlocks.
+func (in *ESIndexBlocks) DeepCopy() *ESIndexBlocks {
+	if in == nil {
+		return nil
+	}
+	out := new(ESIndexBlocks)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESIndexLifecycle) DeepCopyInto(out *ESIndexLifecycle) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESIndexLifecycle.
+func (in *ESIndexLifecycle) DeepCopy() *ESIndexLifecycle {
+	if in == nil {
+		return nil
+	}
+	out := new(ESIndexLifecycle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESIndexPlugins) DeepCopyInto(out *ESIndexPlugins) {
+	*out = *in
+	out.IndexStateManagement = in.IndexStateManagement
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESIndexPlugins.
+func (in *ESIndexPlugins) DeepCopy() *ESIndexPlugins {
+	if in == nil {
+		return nil
+	}
+	out := new(ESIndexPlugins)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESIndexRollover) DeepCopyInto(out *ESIndexRollover) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESIndexRollover.
+func (in *ESIndexRollover) DeepCopy() *ESIndexRollover {
+	if in == nil {
+		return nil
+	}
+	out := new(ESIndexRollover)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESIndexRouting) DeepCopyInto(out *ESIndexRouting) {
+	*out = *in
+	out.Allocation = in.Allocation
+	out.Rebalance = in.Rebalance
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESIndexRouting.
+func (in *ESIndexRouting) DeepCopy() *ESIndexRouting {
+	if in == nil {
+		return nil
+	}
+	out := new(ESIndexRouting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESIndexSettings) DeepCopyInto(out *ESIndexSettings) {
+	*out = *in
+	out.Shard = in.Shard
+	out.SearchIdleAfter = in.SearchIdleAfter
+	out.Blocks = in.Blocks
+	out.Analyze = in.Analyze
+	out.Highlight = in.Highlight
+	out.Routing = in.Routing
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = new(ESIndexLifecycle)
+		**out = **in
+	}
+	if in.Plugins != nil {
+		in, out := &in.Plugins, &out.Plugins
+		*out = new(ESIndexPlugins)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESIndexSettings.
+func (in *ESIndexSettings) DeepCopy() *ESIndexSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ESIndexSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESIndexStateManagement) DeepCopyInto(out *ESIndexStateManagement) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESIndexStateManagement.
+func (in *ESIndexStateManagement) DeepCopy() *ESIndexStateManagement {
+	if in == nil {
+		return nil
+	}
+	out := new(ESIndexStateManagement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESIndexSynonyms) DeepCopyInto(out *ESIndexSynonyms) {
+	*out = *in
+	in.ConfigMapRef.DeepCopyInto(&out.ConfigMapRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESIndexSynonyms.
+func (in *ESIndexSynonyms) DeepCopy() *ESIndexSynonyms {
+	if in == nil {
+		return nil
+	}
+	out := new(ESIndexSynonyms)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESIndexSynonymsStatus) DeepCopyInto(out *ESIndexSynonymsStatus) {
+	*out = *in
+	if in.UpdateTime != nil {
+		in, out := &in.UpdateTime, &out.UpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESIndexSynonymsStatus.
+func (in *ESIndexSynonymsStatus) DeepCopy() *ESIndexSynonymsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ESIndexSynonymsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESRoleFieldSecurity) DeepCopyInto(out *ESRoleFieldSecurity) {
+	*out = *in
+	if in.Grant != nil {
+		in, out := &in.Grant, &out.Grant
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Except != nil {
+		in, out := &in.Except, &out.Except
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESRoleFieldSecurity.
+func (in *ESRoleFieldSecurity) DeepCopy() *ESRoleFieldSecurity {
+	if in == nil {
+		return nil
+	}
+	out := new(ESRoleFieldSecurity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESRoleIndexPrivileges) DeepCopyInto(out *ESRoleIndexPrivileges) {
+	*out = *in
+	if in.Names != nil {
+		in, out := &in.Names, &out.Names
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FieldSecurity != nil {
+		in, out := &in.FieldSecurity, &out.FieldSecurity
+		*out = new(ESRoleFieldSecurity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESRoleIndexPrivileges.
+func (in *ESRoleIndexPrivileges) DeepCopy() *ESRoleIndexPrivileges {
+	if in == nil {
+		return nil
+	}
+	out := new(ESRoleIndexPrivileges)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESRoleMappingTemplate) DeepCopyInto(out *ESRoleMappingTemplate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESRoleMappingTemplate.
+func (in *ESRoleMappingTemplate) DeepCopy() *ESRoleMappingTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ESRoleMappingTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESRoutingAllocationEnable) DeepCopyInto(out *ESRoutingAllocationEnable) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESRoutingAllocationEnable.
+func (in *ESRoutingAllocationEnable) DeepCopy() *ESRoutingAllocationEnable {
+	if in == nil {
+		return nil
+	}
+	out := new(ESRoutingAllocationEnable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESRoutingRebalanceEnable) DeepCopyInto(out *ESRoutingRebalanceEnable) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESRoutingRebalanceEnable.
+func (in *ESRoutingRebalanceEnable) DeepCopy() *ESRoutingRebalanceEnable {
+	if in == nil {
+		return nil
+	}
+	out := new(ESRoutingRebalanceEnable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESSearch) DeepCopyInto(out *ESSearch) {
+	*out = *in
+	out.Idle = in.Idle
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESSearch.
+func (in *ESSearch) DeepCopy() *ESSearch {
+	if in == nil {
+		return nil
+	}
+	out := new(ESSearch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESShard) DeepCopyInto(out *ESShard) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESShard.
+func (in *ESShard) DeepCopy() *ESShard {
+	if in == nil {
+		return nil
+	}
+	out := new(ESShard)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESSnapshotPolicyConfig) DeepCopyInto(out *ESSnapshotPolicyConfig) {
+	*out = *in
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeGlobalState != nil {
+		in, out := &in.IncludeGlobalState, &out.IncludeGlobalState
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FeatureStates != nil {
+		in, out := &in.FeatureStates, &out.FeatureStates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESSnapshotPolicyConfig.
+func (in *ESSnapshotPolicyConfig) DeepCopy() *ESSnapshotPolicyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ESSnapshotPolicyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESSnapshotPolicyRetention) DeepCopyInto(out *ESSnapshotPolicyRetention) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESSnapshotPolicyRetention.
+func (in *ESSnapshotPolicyRetention) DeepCopy() *ESSnapshotPolicyRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(ESSnapshotPolicyRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESSnapshotRepositorySecretSetting) DeepCopyInto(out *ESSnapshotRepositorySecretSetting) {
+	*out = *in
+	in.SecretKeyRef.DeepCopyInto(&out.SecretKeyRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESSnapshotRepositorySecretSetting.
+func (in *ESSnapshotRepositorySecretSetting) DeepCopy() *ESSnapshotRepositorySecretSetting {
+	if in == nil {
+		return nil
+	}
+	out := new(ESSnapshotRepositorySecretSetting)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESTransformDest) DeepCopyInto(out *ESTransformDest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESTransformDest.
+func (in *ESTransformDest) DeepCopy() *ESTransformDest {
+	if in == nil {
+		return nil
+	}
+	out := new(ESTransformDest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESTransformSource) DeepCopyInto(out *ESTransformSource) {
+	*out = *in
+	if in.Index != nil {
+		in, out := &in.Index, &out.Index
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESTransformSource.
+func (in *ESTransformSource) DeepCopy() *ESTransformSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ESTransformSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESTransformSync) DeepCopyInto(out *ESTransformSync) {
+	*out = *in
+	out.Time = in.Time
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESTransformSync.
+func (in *ESTransformSync) DeepCopy() *ESTransformSync {
+	if in == nil {
+		return nil
+	}
+	out := new(ESTransformSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ESTransformSyncTime) DeepCopyInto(out *ESTransformSyncTime) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESTransformSyncTime.
+func (in *ESTransformSyncTime) DeepCopy() *ESTransformSyncTime {
+	if in == nil {
+		return nil
+	}
+	out := new(ESTransformSyncTime)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchAPIKey) DeepCopyInto(out *ElasticSearchAPIKey) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchAPIKey.
+func (in *ElasticSearchAPIKey) DeepCopy() *ElasticSearchAPIKey {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchAPIKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchAPIKey) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchAPIKeyList) DeepCopyInto(out *ElasticSearchAPIKeyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticSearchAPIKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchAPIKeyList.
+func (in *ElasticSearchAPIKeyList) DeepCopy() *ElasticSearchAPIKeyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchAPIKeyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchAPIKeyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchAPIKeySpec) DeepCopyInto(out *ElasticSearchAPIKeySpec) {
+	*out = *in
+	if in.RoleDescriptors != nil {
+		in, out := &in.RoleDescriptors, &out.RoleDescriptors
+		*out = make(map[string]ESAPIKeyRoleDescriptor, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchAPIKeySpec.
+func (in *ElasticSearchAPIKeySpec) DeepCopy() *ElasticSearchAPIKeySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchAPIKeySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchAPIKeyStatus) DeepCopyInto(out *ElasticSearchAPIKeyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CreationTime != nil {
+		in, out := &in.CreationTime, &out.CreationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PreviousInvalidationTime != nil {
+		in, out := &in.PreviousInvalidationTime, &out.PreviousInvalidationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchAPIKeyStatus.
+func (in *ElasticSearchAPIKeyStatus) DeepCopy() *ElasticSearchAPIKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchAPIKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchAlias) DeepCopyInto(out *ElasticSearchAlias) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchAlias.
+func (in *ElasticSearchAlias) DeepCopy() *ElasticSearchAlias {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchAlias)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchAlias) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchAliasList) DeepCopyInto(out *ElasticSearchAliasList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticSearchAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchAliasList.
+func (in *ElasticSearchAliasList) DeepCopy() *ElasticSearchAliasList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchAliasList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchAliasList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchAliasSpec) DeepCopyInto(out *ElasticSearchAliasSpec) {
+	*out = *in
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]ESAliasIndex, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchAliasSpec.
+func (in *ElasticSearchAliasSpec) DeepCopy() *ElasticSearchAliasSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchAliasSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchAliasStatus) DeepCopyInto(out *ElasticSearchAliasStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchAliasStatus.
+func (in *ElasticSearchAliasStatus) DeepCopy() *ElasticSearchAliasStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchAliasStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchClusterSettings) DeepCopyInto(out *ElasticSearchClusterSettings) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchClusterSettings.
+func (in *ElasticSearchClusterSettings) DeepCopy() *ElasticSearchClusterSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchClusterSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchClusterSettings) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchClusterSettingsList) DeepCopyInto(out *ElasticSearchClusterSettingsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticSearchClusterSettings, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchClusterSettingsList.
+func (in *ElasticSearchClusterSettingsList) DeepCopy() *ElasticSearchClusterSettingsList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchClusterSettingsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchClusterSettingsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchClusterSettingsSpec) DeepCopyInto(out *ElasticSearchClusterSettingsSpec) {
+	*out = *in
+	if in.Persistent != nil {
+		in, out := &in.Persistent, &out.Persistent
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchClusterSettingsSpec.
+func (in *ElasticSearchClusterSettingsSpec) DeepCopy() *ElasticSearchClusterSettingsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchClusterSettingsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchClusterSettingsStatus) DeepCopyInto(out *ElasticSearchClusterSettingsStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AppliedKeys != nil {
+		in, out := &in.AppliedKeys, &out.AppliedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchClusterSettingsStatus.
+func (in *ElasticSearchClusterSettingsStatus) DeepCopy() *ElasticSearchClusterSettingsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchClusterSettingsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchDataStream) DeepCopyInto(out *ElasticSearchDataStream) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchDataStream.
+func (in *ElasticSearchDataStream) DeepCopy() *ElasticSearchDataStream {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchDataStream)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchDataStream) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchDataStreamList) DeepCopyInto(out *ElasticSearchDataStreamList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticSearchDataStream, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchDataStreamList.
+func (in *ElasticSearchDataStreamList) DeepCopy() *ElasticSearchDataStreamList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchDataStreamList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchDataStreamList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchDataStreamSpec) DeepCopyInto(out *ElasticSearchDataStreamSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchDataStreamSpec.
+func (in *ElasticSearchDataStreamSpec) DeepCopy() *ElasticSearchDataStreamSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchDataStreamSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchDataStreamStatus) DeepCopyInto(out *ElasticSearchDataStreamStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.BackingIndices != nil {
+		in, out := &in.BackingIndices, &out.BackingIndices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchDataStreamStatus.
+func (in *ElasticSearchDataStreamStatus) DeepCopy() *ElasticSearchDataStreamStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchDataStreamStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchEnrichPolicy) DeepCopyInto(out *ElasticSearchEnrichPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchEnrichPolicy.
+func (in *ElasticSearchEnrichPolicy) DeepCopy() *ElasticSearchEnrichPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchEnrichPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchEnrichPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchEnrichPolicyList) DeepCopyInto(out *ElasticSearchEnrichPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticSearchEnrichPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchEnrichPolicyList.
+func (in *ElasticSearchEnrichPolicyList) DeepCopy() *ElasticSearchEnrichPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchEnrichPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchEnrichPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchEnrichPolicySpec) DeepCopyInto(out *ElasticSearchEnrichPolicySpec) {
+	*out = *in
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.EnrichFields != nil {
+		in, out := &in.EnrichFields, &out.EnrichFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchEnrichPolicySpec.
+func (in *ElasticSearchEnrichPolicySpec) DeepCopy() *ElasticSearchEnrichPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchEnrichPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchEnrichPolicyStatus) DeepCopyInto(out *ElasticSearchEnrichPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastExecution != nil {
+		in, out := &in.LastExecution, &out.LastExecution
+		*out = new(ESEnrichPolicyExecution)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NextExecutionTime != nil {
+		in, out := &in.NextExecutionTime, &out.NextExecutionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchEnrichPolicyStatus.
+func (in *ElasticSearchEnrichPolicyStatus) DeepCopy() *ElasticSearchEnrichPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchEnrichPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchIndex) DeepCopyInto(out *ElasticSearchIndex) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchIndex.
+func (in *ElasticSearchIndex) DeepCopy() *ElasticSearchIndex {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchIndex)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchIndex) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchIndexList) DeepCopyInto(out *ElasticSearchIndexList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticSearchIndex, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchIndexList.
+func (in *ElasticSearchIndexList) DeepCopy() *ElasticSearchIndexList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchIndexList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchIndexList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchIndexSpec) DeepCopyInto(out *ElasticSearchIndexSpec) {
+	*out = *in
+	in.Settings.DeepCopyInto(&out.Settings)
+	if in.Rollover != nil {
+		in, out := &in.Rollover, &out.Rollover
+		*out = new(ESIndexRollover)
+		**out = **in
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(ESIndexBackup)
+		**out = **in
+	}
+	if in.Synonyms != nil {
+		in, out := &in.Synonyms, &out.Synonyms
+		*out = make([]ESIndexSynonyms, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchIndexSpec.
+func (in *ElasticSearchIndexSpec) DeepCopy() *ElasticSearchIndexSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchIndexSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchIndexStatus) DeepCopyInto(out *ElasticSearchIndexStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = new(ESIndexBackupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Synonyms != nil {
+		in, out := &in.Synonyms, &out.Synonyms
+		*out = make([]ESIndexSynonymsStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchIndexStatus.
+func (in *ElasticSearchIndexStatus) DeepCopy() *ElasticSearchIndexStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchIndexStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchNotificationRoute) DeepCopyInto(out *ElasticSearchNotificationRoute) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchNotificationRoute.
+func (in *ElasticSearchNotificationRoute) DeepCopy() *ElasticSearchNotificationRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchNotificationRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchNotificationRoute) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchNotificationRouteList) DeepCopyInto(out *ElasticSearchNotificationRouteList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticSearchNotificationRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchNotificationRouteList.
+func (in *ElasticSearchNotificationRouteList) DeepCopy() *ElasticSearchNotificationRouteList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchNotificationRouteList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchNotificationRouteList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchNotificationRouteSpec) DeepCopyInto(out *ElasticSearchNotificationRouteSpec) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MessageTypes != nil {
+		in, out := &in.MessageTypes, &out.MessageTypes
+		*out = make([]NotificationMessageType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchNotificationRouteSpec.
+func (in *ElasticSearchNotificationRouteSpec) DeepCopy() *ElasticSearchNotificationRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchNotificationRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchRestore) DeepCopyInto(out *ElasticSearchRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchRestore.
+func (in *ElasticSearchRestore) DeepCopy() *ElasticSearchRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchRestoreList) DeepCopyInto(out *ElasticSearchRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticSearchRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchRestoreList.
+func (in *ElasticSearchRestoreList) DeepCopy() *ElasticSearchRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchRestoreSpec) DeepCopyInto(out *ElasticSearchRestoreSpec) {
+	*out = *in
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeAliases != nil {
+		in, out := &in.IncludeAliases, &out.IncludeAliases
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IgnoreIndexSettings != nil {
+		in, out := &in.IgnoreIndexSettings, &out.IgnoreIndexSettings
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchRestoreSpec.
+func (in *ElasticSearchRestoreSpec) DeepCopy() *ElasticSearchRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchRestoreStatus) DeepCopyInto(out *ElasticSearchRestoreStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchRestoreStatus.
+func (in *ElasticSearchRestoreStatus) DeepCopy() *ElasticSearchRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchRole) DeepCopyInto(out *ElasticSearchRole) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchRole.
+func (in *ElasticSearchRole) DeepCopy() *ElasticSearchRole {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchRole) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchRoleList) DeepCopyInto(out *ElasticSearchRoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticSearchRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchRoleList.
+func (in *ElasticSearchRoleList) DeepCopy() *ElasticSearchRoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchRoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchRoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchRoleMapping) DeepCopyInto(out *ElasticSearchRoleMapping) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchRoleMapping.
+func (in *ElasticSearchRoleMapping) DeepCopy() *ElasticSearchRoleMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchRoleMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchRoleMapping) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchRoleMappingList) DeepCopyInto(out *ElasticSearchRoleMappingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticSearchRoleMapping, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchRoleMappingList.
+func (in *ElasticSearchRoleMappingList) DeepCopy() *ElasticSearchRoleMappingList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchRoleMappingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchRoleMappingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchRoleMappingSpec) DeepCopyInto(out *ElasticSearchRoleMappingSpec) {
+	*out = *in
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RoleTemplates != nil {
+		in, out := &in.RoleTemplates, &out.RoleTemplates
+		*out = make([]ESRoleMappingTemplate, len(*in))
+		copy(*out, *in)
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchRoleMappingSpec.
+func (in *ElasticSearchRoleMappingSpec) DeepCopy() *ElasticSearchRoleMappingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchRoleMappingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchRoleMappingStatus) DeepCopyInto(out *ElasticSearchRoleMappingStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchRoleMappingStatus.
+func (in *ElasticSearchRoleMappingStatus) DeepCopy() *ElasticSearchRoleMappingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchRoleMappingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchRoleSpec) DeepCopyInto(out *ElasticSearchRoleSpec) {
+	*out = *in
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]ESRoleIndexPrivileges, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.RunAs != nil {
+		in, out := &in.RunAs, &out.RunAs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchRoleSpec.
+func (in *ElasticSearchRoleSpec) DeepCopy() *ElasticSearchRoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchRoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchRoleStatus) DeepCopyInto(out *ElasticSearchRoleStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchRoleStatus.
+func (in *ElasticSearchRoleStatus) DeepCopy() *ElasticSearchRoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchRoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchSnapshot) DeepCopyInto(out *ElasticSearchSnapshot) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchSnapshot.
+func (in *ElasticSearchSnapshot) DeepCopy() *ElasticSearchSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchSnapshot) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchSnapshotList) DeepCopyInto(out *ElasticSearchSnapshotList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticSearchSnapshot, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchSnapshotList.
+func (in *ElasticSearchSnapshotList) DeepCopy() *ElasticSearchSnapshotList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchSnapshotList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchSnapshotList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchSnapshotPolicy) DeepCopyInto(out *ElasticSearchSnapshotPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchSnapshotPolicy.
+func (in *ElasticSearchSnapshotPolicy) DeepCopy() *ElasticSearchSnapshotPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchSnapshotPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchSnapshotPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchSnapshotPolicyList) DeepCopyInto(out *ElasticSearchSnapshotPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticSearchSnapshotPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchSnapshotPolicyList.
+func (in *ElasticSearchSnapshotPolicyList) DeepCopy() *ElasticSearchSnapshotPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchSnapshotPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchSnapshotPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchSnapshotPolicySpec) DeepCopyInto(out *ElasticSearchSnapshotPolicySpec) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(ESSnapshotPolicyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(ESSnapshotPolicyRetention)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchSnapshotPolicySpec.
+func (in *ElasticSearchSnapshotPolicySpec) DeepCopy() *ElasticSearchSnapshotPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchSnapshotPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchSnapshotPolicyStatus) DeepCopyInto(out *ElasticSearchSnapshotPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastSuccess != nil {
+		in, out := &in.LastSuccess, &out.LastSuccess
+		*out = (*in).DeepCopy()
+	}
+	if in.LastFailure != nil {
+		in, out := &in.LastFailure, &out.LastFailure
+		*out = (*in).DeepCopy()
+	}
+	if in.NextExecution != nil {
+		in, out := &in.NextExecution, &out.NextExecution
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchSnapshotPolicyStatus.
+func (in *ElasticSearchSnapshotPolicyStatus) DeepCopy() *ElasticSearchSnapshotPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchSnapshotPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchSnapshotRepository) DeepCopyInto(out *ElasticSearchSnapshotRepository) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchSnapshotRepository.
+func (in *ElasticSearchSnapshotRepository) DeepCopy() *ElasticSearchSnapshotRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchSnapshotRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchSnapshotRepository) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchSnapshotRepositoryList) DeepCopyInto(out *ElasticSearchSnapshotRepositoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticSearchSnapshotRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchSnapshotRepositoryList.
+func (in *ElasticSearchSnapshotRepositoryList) DeepCopy() *ElasticSearchSnapshotRepositoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchSnapshotRepositoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchSnapshotRepositoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchSnapshotRepositorySpec) DeepCopyInto(out *ElasticSearchSnapshotRepositorySpec) {
+	*out = *in
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SecretSettings != nil {
+		in, out := &in.SecretSettings, &out.SecretSettings
+		*out = make([]ESSnapshotRepositorySecretSetting, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchSnapshotRepositorySpec.
+func (in *ElasticSearchSnapshotRepositorySpec) DeepCopy() *ElasticSearchSnapshotRepositorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchSnapshotRepositorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchSnapshotRepositoryStatus) DeepCopyInto(out *ElasticSearchSnapshotRepositoryStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchSnapshotRepositoryStatus.
+func (in *ElasticSearchSnapshotRepositoryStatus) DeepCopy() *ElasticSearchSnapshotRepositoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchSnapshotRepositoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchSnapshotSpec) DeepCopyInto(out *ElasticSearchSnapshotSpec) {
+	*out = *in
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.IncludeGlobalState != nil {
+		in, out := &in.IncludeGlobalState, &out.IncludeGlobalState
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchSnapshotSpec.
+func (in *ElasticSearchSnapshotSpec) DeepCopy() *ElasticSearchSnapshotSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchSnapshotSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchSnapshotStatus) DeepCopyInto(out *ElasticSearchSnapshotStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Indices != nil {
+		in, out := &in.Indices, &out.Indices
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchSnapshotStatus.
+func (in *ElasticSearchSnapshotStatus) DeepCopy() *ElasticSearchSnapshotStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchSnapshotStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchStoredScript) DeepCopyInto(out *ElasticSearchStoredScript) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchStoredScript.
+func (in *ElasticSearchStoredScript) DeepCopy() *ElasticSearchStoredScript {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchStoredScript)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchStoredScript) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchStoredScriptList) DeepCopyInto(out *ElasticSearchStoredScriptList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticSearchStoredScript, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchStoredScriptList.
+func (in *ElasticSearchStoredScriptList) DeepCopy() *ElasticSearchStoredScriptList {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchStoredScriptList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchStoredScriptList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchStoredScriptSpec) DeepCopyInto(out *ElasticSearchStoredScriptSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchStoredScriptSpec.
+func (in *ElasticSearchStoredScriptSpec) DeepCopy() *ElasticSearchStoredScriptSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchStoredScriptSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchStoredScriptStatus) DeepCopyInto(out *ElasticSearchStoredScriptStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchStoredScriptStatus.
+func (in *ElasticSearchStoredScriptStatus) DeepCopy() *ElasticSearchStoredScriptStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchStoredScriptStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchTemplate) DeepCopyInto(out *ElasticSearchTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchTemplate.
+func (in *ElasticSearchTemplate) DeepCopy() *ElasticSearchTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ElasticSearchTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ElasticSearchTemplateList) DeepCopyInto(out *ElasticSearchTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticSearchTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchTemplateList.
+func (in *ElasticSearchTemplateList) DeepCopy() *ElasticSearchTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(ESAlias)
+	out := new(ElasticSearchTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ESAnalyze) DeepCopyInto(out *ESAnalyze) {
+func (in *ElasticSearchTemplateSpec) DeepCopyInto(out *ElasticSearchTemplateSpec) {
 	*out = *in
+	if in.IndexPatterns != nil {
+		in, out := &in.IndexPatterns, &out.IndexPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Aliases != nil {
+		in, out := &in.Aliases, &out.Aliases
+		*out = make(map[string]ESAlias, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	in.Settings.DeepCopyInto(&out.Settings)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESAnalyze.
-func (in *ESAnalyze) DeepCopy() *ESAnalyze {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchTemplateSpec.
+func (in *ElasticSearchTemplateSpec) DeepCopy() *ElasticSearchTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ESAnalyze)
+	out := new(ElasticSearchTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ESHighlights) DeepCopyInto(out *ESHighlights) {
+func (in *ElasticSearchTemplateStatus) DeepCopyInto(out *ElasticSearchTemplateStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESHighlights.
-func (in *ESHighlights) DeepCopy() *ESHighlights {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchTemplateStatus.
+func (in *ElasticSearchTemplateStatus) DeepCopy() *ElasticSearchTemplateStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ESHighlights)
+	out := new(ElasticSearchTemplateStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ESIdle) DeepCopyInto(out *ESIdle) {
+func (in *ElasticSearchTransform) DeepCopyInto(out *ElasticSearchTransform) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESIdle.
-func (in *ESIdle) DeepCopy() *ESIdle {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchTransform.
+func (in *ElasticSearchTransform) DeepCopy() *ElasticSearchTransform {
 	if in == nil {
 		return nil
 	}
-	out := new(ESIdle)
+	out := new(ElasticSearchTransform)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchTransform) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ESIndexBlocks) DeepCopyInto(out *ESIndexBlocks) {
+func (in *ElasticSearchTransformList) DeepCopyInto(out *ElasticSearchTransformList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticSearchTransform, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESIndexBlocks.
-func (in *ESIndexBlocks) DeepCopy() *ESIndexBlocks {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchTransformList.
+func (in *ElasticSearchTransformList) DeepCopy() *ElasticSearchTransformList {
 	if in == nil {
 		return nil
 	}
-	out := new(ESIndexBlocks)
+	out := new(ElasticSearchTransformList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchTransformList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ESIndexRouting) DeepCopyInto(out *ESIndexRouting) {
+func (in *ElasticSearchTransformSpec) DeepCopyInto(out *ElasticSearchTransformSpec) {
 	*out = *in
-	out.Allocation = in.Allocation
-	out.Rebalance = in.Rebalance
+	in.Source.DeepCopyInto(&out.Source)
+	out.Dest = in.Dest
+	if in.Sync != nil {
+		in, out := &in.Sync, &out.Sync
+		*out = new(ESTransformSync)
+		**out = **in
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESIndexRouting.
-func (in *ESIndexRouting) DeepCopy() *ESIndexRouting {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchTransformSpec.
+func (in *ElasticSearchTransformSpec) DeepCopy() *ElasticSearchTransformSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ESIndexRouting)
+	out := new(ElasticSearchTransformSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ESIndexSettings) DeepCopyInto(out *ESIndexSettings) {
+func (in *ElasticSearchTransformStatus) DeepCopyInto(out *ElasticSearchTransformStatus) {
 	*out = *in
-	out.Shard = in.Shard
-	out.SearchIdleAfter = in.SearchIdleAfter
-	out.Blocks = in.Blocks
-	out.Analyze = in.Analyze
-	out.Highlight = in.Highlight
-	out.Routing = in.Routing
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.HealthIssues != nil {
+		in, out := &in.HealthIssues, &out.HealthIssues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CheckpointTime != nil {
+		in, out := &in.CheckpointTime, &out.CheckpointTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESIndexSettings.
-func (in *ESIndexSettings) DeepCopy() *ESIndexSettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchTransformStatus.
+func (in *ElasticSearchTransformStatus) DeepCopy() *ElasticSearchTransformStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ESIndexSettings)
+	out := new(ElasticSearchTransformStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ESRoutingAllocationEnable) DeepCopyInto(out *ESRoutingAllocationEnable) {
+func (in *ElasticSearchUser) DeepCopyInto(out *ElasticSearchUser) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESRoutingAllocationEnable.
-func (in *ESRoutingAllocationEnable) DeepCopy() *ESRoutingAllocationEnable {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchUser.
+func (in *ElasticSearchUser) DeepCopy() *ElasticSearchUser {
 	if in == nil {
 		return nil
 	}
-	out := new(ESRoutingAllocationEnable)
+	out := new(ElasticSearchUser)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchUser) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ESRoutingRebalanceEnable) DeepCopyInto(out *ESRoutingRebalanceEnable) {
+func (in *ElasticSearchUserList) DeepCopyInto(out *ElasticSearchUserList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ElasticSearchUser, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESRoutingRebalanceEnable.
-func (in *ESRoutingRebalanceEnable) DeepCopy() *ESRoutingRebalanceEnable {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchUserList.
+func (in *ElasticSearchUserList) DeepCopy() *ElasticSearchUserList {
 	if in == nil {
 		return nil
 	}
-	out := new(ESRoutingRebalanceEnable)
+	out := new(ElasticSearchUserList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ElasticSearchUserList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ESSearch) DeepCopyInto(out *ESSearch) {
+func (in *ElasticSearchUserSpec) DeepCopyInto(out *ElasticSearchUserSpec) {
 	*out = *in
-	out.Idle = in.Idle
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(corev1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESSearch.
-func (in *ESSearch) DeepCopy() *ESSearch {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchUserSpec.
+func (in *ElasticSearchUserSpec) DeepCopy() *ElasticSearchUserSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ESSearch)
+	out := new(ElasticSearchUserSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ESShard) DeepCopyInto(out *ESShard) {
+func (in *ElasticSearchUserStatus) DeepCopyInto(out *ElasticSearchUserStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ESShard.
-func (in *ESShard) DeepCopy() *ESShard {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchUserStatus.
+func (in *ElasticSearchUserStatus) DeepCopy() *ElasticSearchUserStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ESShard)
+	out := new(ElasticSearchUserStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticSearchIndex) DeepCopyInto(out *ElasticSearchIndex) {
+func (in *ISMConditions) DeepCopyInto(out *ISMConditions) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchIndex.
-func (in *ElasticSearchIndex) DeepCopy() *ElasticSearchIndex {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ISMConditions.
+func (in *ISMConditions) DeepCopy() *ISMConditions {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticSearchIndex)
+	out := new(ISMConditions)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticSearchIndex) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticSearchIndexList) DeepCopyInto(out *ElasticSearchIndexList) {
+func (in *ISMState) DeepCopyInto(out *ISMState) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]ElasticSearchIndex, len(*in))
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Transitions != nil {
+		in, out := &in.Transitions, &out.Transitions
+		*out = make([]ISMTransition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchIndexList.
-func (in *ElasticSearchIndexList) DeepCopy() *ElasticSearchIndexList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ISMState.
+func (in *ISMState) DeepCopy() *ISMState {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticSearchIndexList)
+	out := new(ISMState)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticSearchIndexList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticSearchIndexSpec) DeepCopyInto(out *ElasticSearchIndexSpec) {
+func (in *ISMTemplate) DeepCopyInto(out *ISMTemplate) {
 	*out = *in
-	out.Settings = in.Settings
+	if in.IndexPatterns != nil {
+		in, out := &in.IndexPatterns, &out.IndexPatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchIndexSpec.
-func (in *ElasticSearchIndexSpec) DeepCopy() *ElasticSearchIndexSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ISMTemplate.
+func (in *ISMTemplate) DeepCopy() *ISMTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticSearchIndexSpec)
+	out := new(ISMTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticSearchIndexStatus) DeepCopyInto(out *ElasticSearchIndexStatus) {
+func (in *ISMTransition) DeepCopyInto(out *ISMTransition) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+		*out = new(ISMConditions)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchIndexStatus.
-func (in *ElasticSearchIndexStatus) DeepCopy() *ElasticSearchIndexStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ISMTransition.
+func (in *ISMTransition) DeepCopy() *ISMTransition {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticSearchIndexStatus)
+	out := new(ISMTransition)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticSearchTemplate) DeepCopyInto(out *ElasticSearchTemplate) {
+func (in *OpenSearchISMPolicy) DeepCopyInto(out *OpenSearchISMPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -316,18 +2615,18 @@ func (in *ElasticSearchTemplate) DeepCopyInto(out *ElasticSearchTemplate) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchTemplate.
-func (in *ElasticSearchTemplate) DeepCopy() *ElasticSearchTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchISMPolicy.
+func (in *OpenSearchISMPolicy) DeepCopy() *OpenSearchISMPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticSearchTemplate)
+	out := new(OpenSearchISMPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticSearchTemplate) DeepCopyObject() runtime.Object {
+func (in *OpenSearchISMPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -335,31 +2634,31 @@ func (in *ElasticSearchTemplate) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticSearchTemplateList) DeepCopyInto(out *ElasticSearchTemplateList) {
+func (in *OpenSearchISMPolicyList) DeepCopyInto(out *OpenSearchISMPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ElasticSearchTemplate, len(*in))
+		*out = make([]OpenSearchISMPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchTemplateList.
-func (in *ElasticSearchTemplateList) DeepCopy() *ElasticSearchTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchISMPolicyList.
+func (in *OpenSearchISMPolicyList) DeepCopy() *OpenSearchISMPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticSearchTemplateList)
+	out := new(OpenSearchISMPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ElasticSearchTemplateList) DeepCopyObject() runtime.Object {
+func (in *OpenSearchISMPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -367,35 +2666,36 @@ func (in *ElasticSearchTemplateList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticSearchTemplateSpec) DeepCopyInto(out *ElasticSearchTemplateSpec) {
+func (in *OpenSearchISMPolicySpec) DeepCopyInto(out *OpenSearchISMPolicySpec) {
 	*out = *in
-	if in.IndexPatterns != nil {
-		in, out := &in.IndexPatterns, &out.IndexPatterns
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.States != nil {
+		in, out := &in.States, &out.States
+		*out = make([]ISMState, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.Aliases != nil {
-		in, out := &in.Aliases, &out.Aliases
-		*out = make(map[string]ESAlias, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
+	if in.ISMTemplate != nil {
+		in, out := &in.ISMTemplate, &out.ISMTemplate
+		*out = make([]ISMTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.Settings = in.Settings
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchTemplateSpec.
-func (in *ElasticSearchTemplateSpec) DeepCopy() *ElasticSearchTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchISMPolicySpec.
+func (in *OpenSearchISMPolicySpec) DeepCopy() *OpenSearchISMPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticSearchTemplateSpec)
+	out := new(OpenSearchISMPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ElasticSearchTemplateStatus) DeepCopyInto(out *ElasticSearchTemplateStatus) {
+func (in *OpenSearchISMPolicyStatus) DeepCopyInto(out *OpenSearchISMPolicyStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -406,12 +2706,12 @@ func (in *ElasticSearchTemplateStatus) DeepCopyInto(out *ElasticSearchTemplateSt
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ElasticSearchTemplateStatus.
-func (in *ElasticSearchTemplateStatus) DeepCopy() *ElasticSearchTemplateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenSearchISMPolicyStatus.
+func (in *OpenSearchISMPolicyStatus) DeepCopy() *OpenSearchISMPolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ElasticSearchTemplateStatus)
+	out := new(OpenSearchISMPolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -34,7 +34,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/config"
 	"github.com/90poe/elasticsearch-objects-operator/internal/controller"
+	"github.com/90poe/elasticsearch-objects-operator/internal/elasticsearch"
+	"github.com/90poe/elasticsearch-objects-operator/internal/reporter"
 	"github.com/90poe/elasticsearch-objects-operator/internal/version"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	//+kubebuilder:scaffold:imports
@@ -97,20 +100,203 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Slack messenger is shared by all controllers and flushes pending messages on shutdown
+	c := config.Get()
+	messenger, err := reporter.New(c.SlackToken,
+		reporter.SlackChannel(c.SlackChannel),
+		reporter.DedupInterval(c.NotificationDedupInterval),
+		reporter.QueueLength(c.NotificationQueueSize),
+	)
+	if err != nil {
+		setupLog.Error(err, "unable to create messenger")
+		os.Exit(1)
+	}
+	if err = mgr.Add(messenger); err != nil {
+		setupLog.Error(err, "unable to add messenger to manager")
+		os.Exit(1)
+	}
+
+	// ES client is shared by all controllers, it connects to ES cluster on first use
+	esFlavor, err := elasticsearch.ParseFlavor(c.ESFlavor)
+	if err != nil {
+		setupLog.Error(err, "invalid elasticsearch flavor")
+		os.Exit(1)
+	}
+	esOptions := []elasticsearch.Option{
+		elasticsearch.URL(c.ESurl),
+		elasticsearch.Timeout(c.ESTimeout),
+		elasticsearch.Retries(c.ESRetries, c.ESRetryInitialBackoff, c.ESRetryMaxBackoff),
+		elasticsearch.Sniff(c.ESSniff),
+		elasticsearch.HealthcheckInterval(c.ESHealthcheckInterval),
+		elasticsearch.ClusterFlavor(esFlavor),
+	}
+	switch c.ESAuthMode {
+	case elasticsearch.AuthModeNone:
+	case elasticsearch.AuthModeAWSSigV4:
+		esOptions = append(esOptions, elasticsearch.AWSSigV4(c.ESAWSRegion, c.ESAWSService))
+	default:
+		setupLog.Error(fmt.Errorf("unknown auth mode '%s'", c.ESAuthMode), "invalid elasticsearch auth mode")
+		os.Exit(1)
+	}
+	esClient, err := elasticsearch.New(esOptions...)
+	if err != nil {
+		setupLog.Error(err, "unable to create elasticsearch client")
+		os.Exit(1)
+	}
+
 	if err = (&controller.ElasticSearchIndexReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Messenger: messenger,
+		ES:        esClient,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ElasticSearchIndex")
 		os.Exit(1)
 	}
 	if err = (&controller.ElasticSearchTemplateReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Messenger: messenger,
+		ES:        esClient,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ElasticSearchTemplate")
 		os.Exit(1)
 	}
+	if err = (&controller.OpenSearchISMPolicyReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Messenger: messenger,
+		ES:        esClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "OpenSearchISMPolicy")
+		os.Exit(1)
+	}
+	if err = (&controller.ElasticSearchDataStreamReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Messenger: messenger,
+		ES:        esClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticSearchDataStream")
+		os.Exit(1)
+	}
+	if err = (&controller.ElasticSearchAliasReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Messenger: messenger,
+		ES:        esClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticSearchAlias")
+		os.Exit(1)
+	}
+	if err = (&controller.ElasticSearchSnapshotRepositoryReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Messenger: messenger,
+		ES:        esClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticSearchSnapshotRepository")
+		os.Exit(1)
+	}
+	if err = (&controller.ElasticSearchSnapshotPolicyReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Messenger: messenger,
+		ES:        esClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticSearchSnapshotPolicy")
+		os.Exit(1)
+	}
+	if err = (&controller.ElasticSearchSnapshotReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Messenger: messenger,
+		ES:        esClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticSearchSnapshot")
+		os.Exit(1)
+	}
+	if err = (&controller.ElasticSearchRestoreReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Messenger: messenger,
+		ES:        esClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticSearchRestore")
+		os.Exit(1)
+	}
+	if err = (&controller.ElasticSearchRoleReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Messenger: messenger,
+		ES:        esClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticSearchRole")
+		os.Exit(1)
+	}
+	if err = (&controller.ElasticSearchUserReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Messenger: messenger,
+		ES:        esClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticSearchUser")
+		os.Exit(1)
+	}
+	if err = (&controller.ElasticSearchAPIKeyReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Messenger: messenger,
+		ES:        esClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticSearchAPIKey")
+		os.Exit(1)
+	}
+	if err = (&controller.ElasticSearchRoleMappingReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Messenger: messenger,
+		ES:        esClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticSearchRoleMapping")
+		os.Exit(1)
+	}
+	if err = (&controller.ElasticSearchClusterSettingsReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Messenger: messenger,
+		ES:        esClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticSearchClusterSettings")
+		os.Exit(1)
+	}
+	if err = (&controller.ElasticSearchStoredScriptReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Messenger: messenger,
+		ES:        esClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticSearchStoredScript")
+		os.Exit(1)
+	}
+	if err = (&controller.ElasticSearchEnrichPolicyReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Messenger: messenger,
+		ES:        esClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticSearchEnrichPolicy")
+		os.Exit(1)
+	}
+	if err = (&controller.ElasticSearchTransformReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Messenger: messenger,
+		ES:        esClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ElasticSearchTransform")
+		os.Exit(1)
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
@@ -121,6 +307,10 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if err := mgr.AddReadyzCheck("elasticsearch", esClient.ReadyzCheck); err != nil {
+		setupLog.Error(err, "unable to set up elasticsearch ready check")
+		os.Exit(1)
+	}
 
 	setupLog.Info(fmt.Sprintf("starting manager version=%v, built at=%v, git hash=%v",
 		version.Version, version.BuildDate, version.GitHash))
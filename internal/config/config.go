@@ -3,15 +3,30 @@ package config
 import (
 	"log"
 	"sync"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
 
 type cfg struct {
-	ESurl                   string `env:"ES_URL" env-default:""`
-	MaxConcurrentReconciles int    `env:"MAX_CONCURRENT_RECONCILES" env-default:"2"`
-	SlackToken              string `env:"SLACK_TOKEN" env-default:""`
-	SlackChannel            string `env:"SLACK_CHANNEL" env-default:""`
+	ESurl                   string        `env:"ES_URL" env-default:""`
+	ESTimeout               time.Duration `env:"ES_TIMEOUT" env-default:"30s"`
+	ESRetries               int           `env:"ES_RETRIES" env-default:"3"`
+	ESRetryInitialBackoff   time.Duration `env:"ES_RETRY_INITIAL_BACKOFF" env-default:"100ms"`
+	ESRetryMaxBackoff       time.Duration `env:"ES_RETRY_MAX_BACKOFF" env-default:"5s"`
+	ESSniff                 bool          `env:"ES_SNIFF" env-default:"false"`
+	ESHealthcheckInterval   time.Duration `env:"ES_HEALTHCHECK_INTERVAL" env-default:"60s"`
+	ESFlavor                string        `env:"ES_FLAVOR" env-default:""`
+	ESAuthMode              string        `env:"ES_AUTH_MODE" env-default:"none"`
+	ESAWSRegion             string        `env:"ES_AWS_REGION" env-default:""`
+	ESAWSService            string        `env:"ES_AWS_SERVICE" env-default:"es"`
+	MaxConcurrentReconciles int           `env:"MAX_CONCURRENT_RECONCILES" env-default:"2"`
+	SlackToken              string        `env:"SLACK_TOKEN" env-default:""`
+	SlackChannel            string        `env:"SLACK_CHANNEL" env-default:""`
+	// Interval between summaries of repeated notifications about the same object
+	NotificationDedupInterval time.Duration `env:"NOTIFICATION_DEDUP_INTERVAL" env-default:"1h"`
+	// Number of notifications queued for sending, new ones are dropped when queue is full
+	NotificationQueueSize int `env:"NOTIFICATION_QUEUE_SIZE" env-default:"100"`
 }
 
 var doOnce sync.Once
@@ -7,6 +7,8 @@ const (
 	ESUpdateOperation = "update"
 	ESManagedByField  = "managed-by"
 	ESManagedByValue  = "elasticsearch-objects-operator.xo.90poe.io"
+	// ESManagedByDescription marks ES objects without metadata, it is added to their description
+	ESManagedByDescription = "[" + ESManagedByField + ": " + ESManagedByValue + "]"
 )
 
 // ESStaticSettings is map which has ES settings static part
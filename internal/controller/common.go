@@ -1,18 +1,76 @@
 package controller
 
 import (
+	"context"
+	"errors"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/90poe/elasticsearch-objects-operator/internal/elasticsearch"
 )
 
 const (
-	ConditionsInsert              = "Insert"
-	ConditionsUpdate              = "Update"
-	ConditionReasonCreateIndex    = "CreateIndex"
-	ConditionReasonUpdateIndex    = "UpdateIndex"
-	ConditionReasonCreateTemplate = "CreateTemplate"
-	ConditionReasonUpdateTemplate = "UpdateTemplate"
-	RevisitIntervalSec            = 36000 // 10 hours
+	ConditionsInsert                  = "Insert"
+	ConditionsUpdate                  = "Update"
+	ConditionReasonCreateIndex        = "CreateIndex"
+	ConditionReasonUpdateIndex        = "UpdateIndex"
+	ConditionReasonCreateTemplate     = "CreateTemplate"
+	ConditionReasonUpdateTemplate     = "UpdateTemplate"
+	ConditionReasonCreateISMPolicy    = "CreateISMPolicy"
+	ConditionReasonUpdateISMPolicy    = "UpdateISMPolicy"
+	ConditionReasonCreateAlias        = "CreateAlias"
+	ConditionReasonUpdateAlias        = "UpdateAlias"
+	ConditionReasonCreateDataStream   = "CreateDataStream"
+	ConditionReasonUpdateDataStream   = "UpdateDataStream"
+	ConditionReasonRolloverDataStream = "RolloverDataStream"
+	ConditionReasonUnsupportedFlavor  = "UnsupportedFlavor"
+	// ConditionsVerified is condition with result of snapshot repository verification
+	ConditionsVerified                      = "Verified"
+	ConditionReasonCreateSnapshotRepository = "CreateSnapshotRepository"
+	ConditionReasonUpdateSnapshotRepository = "UpdateSnapshotRepository"
+	ConditionReasonVerifySnapshotRepository = "VerifySnapshotRepository"
+	ConditionReasonCreateSnapshotPolicy     = "CreateSnapshotPolicy"
+	ConditionReasonUpdateSnapshotPolicy     = "UpdateSnapshotPolicy"
+	ConditionReasonExecuteSnapshotPolicy    = "ExecuteSnapshotPolicy"
+	ConditionReasonCreateSnapshot           = "CreateSnapshot"
+	ConditionReasonRestoreSnapshot          = "RestoreSnapshot"
+	ConditionReasonBackupIndex              = "BackupIndex"
+	ConditionReasonCreateRole               = "CreateRole"
+	ConditionReasonUpdateRole               = "UpdateRole"
+	ConditionReasonCreateUser               = "CreateUser"
+	ConditionReasonUpdateUser               = "UpdateUser"
+	ConditionReasonCreateAPIKey             = "CreateAPIKey"
+	ConditionReasonRenewAPIKey              = "RenewAPIKey"
+	ConditionReasonInvalidateAPIKey         = "InvalidateAPIKey"
+	ConditionReasonCreateRoleMapping        = "CreateRoleMapping"
+	ConditionReasonUpdateRoleMapping        = "UpdateRoleMapping"
+	ConditionReasonUpdateClusterSettings    = "UpdateClusterSettings"
+	ConditionReasonDuplicateClusterSettings = "DuplicateClusterSettings"
+	ConditionReasonCreateStoredScript       = "CreateStoredScript"
+	ConditionReasonUpdateStoredScript       = "UpdateStoredScript"
+	ConditionReasonCreateEnrichPolicy       = "CreateEnrichPolicy"
+	ConditionReasonUpdateEnrichPolicy       = "UpdateEnrichPolicy"
+	ConditionReasonExecuteEnrichPolicy      = "ExecuteEnrichPolicy"
+	ConditionReasonCreateTransform          = "CreateTransform"
+	ConditionReasonUpdateTransform          = "UpdateTransform"
+	ConditionReasonStartTransform           = "StartTransform"
+	ConditionReasonStopTransform            = "StopTransform"
+	RevisitIntervalSec                      = 36000 // 10 hours
+	// ProgressIntervalSec is interval of progress checks of running snapshots and restores
+	ProgressIntervalSec = 10
+	// HealthIntervalSec is interval of health checks of continuously running transforms
+	HealthIntervalSec = 300
+	// DropOnDeleteFinalizer is set on objects which must be dropped from ES cluster on delete
+	DropOnDeleteFinalizer = "xo.90poe.io/drop-on-delete"
+	// RolloverAnnotation triggers manual rollover of data stream every time its value changes
+	RolloverAnnotation = "xo.90poe.io/rollover"
+	// ExecuteAnnotation triggers snapshot with SLM policy every time its value changes
+	ExecuteAnnotation = "xo.90poe.io/execute"
 )
 
 // ignoreUpdateDeletePredicater is brilliantly useful function, it will prevent multiple reconcile calls
@@ -29,3 +87,83 @@ func ignoreUpdateDeletePredicate() predicate.Predicate {
 		},
 	}
 }
+
+// annotationChangedPredicate would pass updates which change annotation, it is used together with
+// ignoreUpdateDeletePredicate to react on annotations as metadata.Generation doesn't change with them
+func annotationChangedPredicate(annotation string) predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectOld.GetAnnotations()[annotation] != e.ObjectNew.GetAnnotations()[annotation]
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return false
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// deletedPredicate would pass only deletions of objects
+func deletedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return false
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return false
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return true
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return false
+		},
+	}
+}
+
+// isUnsupportedFlavor would check if error is caused by feature ES cluster flavor doesn't have
+func isUnsupportedFlavor(err error) bool {
+	flavorErr := &elasticsearch.UnsupportedFlavorError{}
+	return errors.As(err, &flavorErr)
+}
+
+// reconcileDropOnDelete would keep drop on delete finalizer in sync with dropOnDelete flag.
+// If object is being deleted it calls drop and removes finalizer, in which case true is returned
+// and reconcile must stop. ES object which is not managed by operator is left in cluster.
+func reconcileDropOnDelete(ctx context.Context, c client.Client, obj client.Object, dropOnDelete bool,
+	drop func(ctx context.Context) error) (bool, error) {
+	if !obj.GetDeletionTimestamp().IsZero() {
+		if !controllerutil.ContainsFinalizer(obj, DropOnDeleteFinalizer) {
+			return true, nil
+		}
+		err := drop(ctx)
+		if errors.Is(err, elasticsearch.ErrNotManaged) {
+			log.FromContext(ctx).Info(fmt.Sprintf("Object is not dropped from ES cluster: %v", err))
+		} else if err != nil {
+			return true, fmt.Errorf("can't drop object from ES cluster: %w", err)
+		}
+		controllerutil.RemoveFinalizer(obj, DropOnDeleteFinalizer)
+		err = c.Update(ctx, obj)
+		if err != nil {
+			return true, fmt.Errorf("can't remove finalizer: %w", err)
+		}
+		return true, nil
+	}
+	var changed bool
+	if dropOnDelete {
+		changed = controllerutil.AddFinalizer(obj, DropOnDeleteFinalizer)
+	} else {
+		changed = controllerutil.RemoveFinalizer(obj, DropOnDeleteFinalizer)
+	}
+	if changed {
+		err := c.Update(ctx, obj)
+		if err != nil {
+			return false, fmt.Errorf("can't update finalizers: %w", err)
+		}
+	}
+	return false, nil
+}
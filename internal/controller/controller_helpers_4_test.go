@@ -0,0 +1,146 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// testClient is in-memory K8S client shared by controller tests. Objects are kept by their type and key,
+// only calls made by reconcilers are implemented.
+type testClient struct {
+	client.Client
+	objects map[reflect.Type]map[client.ObjectKey]client.Object
+	// statusUpdates are copies of objects passed to status updates, oldest first
+	statusUpdates []client.Object
+	// fail would return error of call with verb on object, call is made if it returns nil
+	fail func(verb string, obj client.Object) error
+}
+
+func newTestClient(objects ...client.Object) *testClient {
+	c := &testClient{objects: map[reflect.Type]map[client.ObjectKey]client.Object{}}
+	for _, obj := range objects {
+		c.store(obj)
+	}
+	return c
+}
+
+func (c *testClient) store(obj client.Object) {
+	objType := reflect.TypeOf(obj)
+	if c.objects[objType] == nil {
+		c.objects[objType] = map[client.ObjectKey]client.Object{}
+	}
+	c.objects[objType][client.ObjectKeyFromObject(obj)] = obj.DeepCopyObject().(client.Object)
+}
+
+func (c *testClient) failure(verb string, obj client.Object) error {
+	if c.fail == nil {
+		return nil
+	}
+	return c.fail(verb, obj)
+}
+
+func notFound(obj client.Object, key client.ObjectKey) error {
+	return kerrors.NewNotFound(schema.GroupResource{Resource: reflect.TypeOf(obj).Elem().Name()}, key.Name)
+}
+
+func (c *testClient) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	err := c.failure("get", obj)
+	if err != nil {
+		return err
+	}
+	stored, ok := c.objects[reflect.TypeOf(obj)][key]
+	if !ok {
+		return notFound(obj, key)
+	}
+	reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(stored.DeepCopyObject()).Elem())
+	return nil
+}
+
+// List would return objects of list item type sorted by key, only namespace option is applied
+func (c *testClient) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	items := reflect.ValueOf(list).Elem().FieldByName("Items")
+	objects := c.objects[reflect.PointerTo(items.Type().Elem())]
+	keys := make([]client.ObjectKey, 0, len(objects))
+	for key := range objects {
+		if len(listOpts.Namespace) == 0 || key.Namespace == listOpts.Namespace {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+	items.SetLen(0)
+	for _, key := range keys {
+		items.Set(reflect.Append(items, reflect.ValueOf(objects[key].DeepCopyObject()).Elem()))
+	}
+	return nil
+}
+
+func (c *testClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	err := c.failure("create", obj)
+	if err != nil {
+		return err
+	}
+	key := client.ObjectKeyFromObject(obj)
+	if _, ok := c.objects[reflect.TypeOf(obj)][key]; ok {
+		return kerrors.NewAlreadyExists(schema.GroupResource{Resource: reflect.TypeOf(obj).Elem().Name()}, key.Name)
+	}
+	c.store(obj)
+	return nil
+}
+
+func (c *testClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	err := c.failure("update", obj)
+	if err != nil {
+		return err
+	}
+	key := client.ObjectKeyFromObject(obj)
+	if _, ok := c.objects[reflect.TypeOf(obj)][key]; !ok {
+		return notFound(obj, key)
+	}
+	c.store(obj)
+	return nil
+}
+
+func (c *testClient) Status() client.SubResourceWriter {
+	return testStatusWriter{c: c}
+}
+
+// testStatusWriter would record status updates of testClient
+type testStatusWriter struct {
+	client.SubResourceWriter
+	c *testClient
+}
+
+func (w testStatusWriter) Update(_ context.Context, obj client.Object, _ ...client.SubResourceUpdateOption) error {
+	err := w.c.failure("status update", obj)
+	if err != nil {
+		return err
+	}
+	w.c.statusUpdates = append(w.c.statusUpdates, obj.DeepCopyObject().(client.Object))
+	w.c.store(obj)
+	return nil
+}
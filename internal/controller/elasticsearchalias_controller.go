@@ -0,0 +1,158 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/config"
+	"github.com/90poe/elasticsearch-objects-operator/internal/elasticsearch"
+	"github.com/90poe/elasticsearch-objects-operator/internal/reporter"
+)
+
+// ElasticSearchAliasReconciler reconciles a ElasticSearchAlias object
+type ElasticSearchAliasReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Messenger *reporter.Messenger
+	ES        elasticsearch.ES
+}
+
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchaliases,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchaliases/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchaliases/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ElasticSearchAliasReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx).WithValues("elasticsearchalias", req.NamespacedName)
+
+	// Fetch the ElasticSearchAlias instance
+	instance := &xov1alpha1.ElasticSearchAlias{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			reqLogger.V(0).Info("ElasticSearchAlias resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		reqLogger.V(0).Info(fmt.Sprintf("Failed to get ElasticSearchAlias: %v", err))
+		return reconcile.Result{}, err
+	}
+
+	deleted, err := reconcileDropOnDelete(ctx, r.Client, instance, instance.Spec.DropOnDelete,
+		func(ctx context.Context) error {
+			return r.ES.DeleteAlias(ctx, instance)
+		})
+	if err != nil || deleted {
+		return reconcile.Result{}, err
+	}
+
+	return r.upsertAlias(ctx, instance, reqLogger)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticSearchAliasReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c := config.Get()
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&xov1alpha1.ElasticSearchAlias{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: c.MaxConcurrentReconciles}).
+		WithEventFilter(ignoreUpdateDeletePredicate()).
+		Complete(r)
+}
+
+// upsertAlias will point alias in ES cluster to indices from spec
+func (r *ElasticSearchAliasReconciler) upsertAlias(ctx context.Context, alias *xov1alpha1.ElasticSearchAlias, reqLogger logr.Logger) (_ ctrl.Result, retErr error) {
+	// Init status
+	statusMessage := "Succeeded"
+	status := metav1.ConditionTrue
+	condition := ConditionsInsert
+	reason := ConditionReasonCreateAlias
+
+	// Defer function to update status
+	defer func() {
+		// Log status update
+		reqLogger.Info(fmt.Sprintf("elasticsearch %s %s status: %s", alias.Spec.Name,
+			reason, statusMessage))
+		// Send message to slack, OK messages are only delivered when they resolve previous errors
+		msgType := reporter.MessageType(reporter.ErrorMessage)
+		notification := statusMessage
+		if status == metav1.ConditionTrue {
+			msgType = reporter.OKMessage
+			notification = fmt.Sprintf("elasticsearch %s %s status: %s", alias.Spec.Name, reason, statusMessage)
+		}
+		notify(ctx, r, r.Messenger, alias, notification, msgType)
+		// Remove last condition and set new one
+		meta.RemoveStatusCondition(&alias.Status.Conditions, condition)
+		meta.SetStatusCondition(&alias.Status.Conditions, metav1.Condition{
+			Type:    condition,
+			Status:  status,
+			Reason:  reason,
+			Message: statusMessage,
+		})
+		// we will return error of status update if it is not nil
+		err := r.Status().Update(ctx, alias)
+		if err != nil {
+			reqLogger.V(0).Info(fmt.Sprintf("Failed to update alias status: %v", retErr))
+			retErr = errors.Join(retErr, err)
+		}
+	}()
+
+	// Check if alias exists in ES cluster
+	exists, err := r.ES.AliasExists(ctx, alias.Spec.Name)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't check if alias %s exists: %v", alias.Spec.Name, err)
+		return ctrl.Result{}, nil
+	}
+
+	// Create or update alias
+	if exists {
+		condition = ConditionsUpdate
+		reason = ConditionReasonUpdateAlias
+	}
+	_, err = r.ES.CreateUpdateAlias(ctx, alias)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't %s %s: %v", reason, alias.Spec.Name, err)
+		return ctrl.Result{}, nil
+	}
+	// Indices in status mark alias as managed by operator
+	alias.Status.Indices = make([]string, 0, len(alias.Spec.Indices))
+	for _, index := range alias.Spec.Indices {
+		alias.Status.Indices = append(alias.Status.Indices, index.Index)
+	}
+
+	return ctrl.Result{
+		RequeueAfter: RevisitIntervalSec * time.Second,
+	}, nil
+}
@@ -0,0 +1,366 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/config"
+	"github.com/90poe/elasticsearch-objects-operator/internal/elasticsearch"
+	"github.com/90poe/elasticsearch-objects-operator/internal/reporter"
+)
+
+const (
+	// apiKeySecretSuffix is suffix of default name of Secret with API key
+	apiKeySecretSuffix = "-elasticsearch-api-key"
+	// Keys of Secret with API key
+	apiKeySecretID      = "id"
+	apiKeySecretAPIKey  = "api_key"
+	apiKeySecretEncoded = "encoded"
+	// apiKeyGracePeriod is default time replaced API key stays valid
+	apiKeyGracePeriod = time.Hour
+)
+
+// ElasticSearchAPIKeyReconciler reconciles a ElasticSearchAPIKey object
+type ElasticSearchAPIKeyReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Messenger *reporter.Messenger
+	ES        elasticsearch.ES
+}
+
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchapikeys,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchapikeys/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchapikeys/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ElasticSearchAPIKeyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx).WithValues("elasticsearchapikey", req.NamespacedName)
+
+	// Fetch the ElasticSearchAPIKey instance
+	instance := &xov1alpha1.ElasticSearchAPIKey{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			reqLogger.V(0).Info("ElasticSearchAPIKey resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		reqLogger.V(0).Info(fmt.Sprintf("Failed to get ElasticSearchAPIKey: %v", err))
+		return reconcile.Result{}, err
+	}
+
+	// API keys are always invalidated on delete
+	deleted, err := reconcileDropOnDelete(ctx, r.Client, instance, true,
+		func(ctx context.Context) error {
+			return r.invalidateAPIKeys(ctx, instance)
+		})
+	if err != nil || deleted {
+		return reconcile.Result{}, err
+	}
+
+	return r.upsertAPIKey(ctx, instance, reqLogger)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticSearchAPIKeyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c := config.Get()
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&xov1alpha1.ElasticSearchAPIKey{}, builder.WithPredicates(ignoreUpdateDeletePredicate())).
+		// API key is created again if its Secret is deleted
+		Owns(&corev1.Secret{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: c.MaxConcurrentReconciles}).
+		Complete(r)
+}
+
+// upsertAPIKey will create API key in ES cluster and store it in Secret, API key is renewed before it expires
+func (r *ElasticSearchAPIKeyReconciler) upsertAPIKey(ctx context.Context, apiKey *xov1alpha1.ElasticSearchAPIKey, reqLogger logr.Logger) (_ ctrl.Result, retErr error) {
+	// Init status
+	statusMessage := "Succeeded"
+	status := metav1.ConditionTrue
+	condition := ConditionsInsert
+	reason := ConditionReasonCreateAPIKey
+
+	// Defer function to update status
+	defer func() {
+		// Log status update
+		reqLogger.Info(fmt.Sprintf("elasticsearch %s %s status: %s", apiKey.Spec.Name,
+			reason, statusMessage))
+		// Send message to slack, OK messages are only delivered when they resolve previous errors
+		msgType := reporter.MessageType(reporter.ErrorMessage)
+		notification := statusMessage
+		if status == metav1.ConditionTrue {
+			msgType = reporter.OKMessage
+			notification = fmt.Sprintf("elasticsearch %s %s status: %s", apiKey.Spec.Name, reason, statusMessage)
+		}
+		notify(ctx, r, r.Messenger, apiKey, notification, msgType)
+		// Remove last condition and set new one
+		meta.RemoveStatusCondition(&apiKey.Status.Conditions, condition)
+		meta.SetStatusCondition(&apiKey.Status.Conditions, metav1.Condition{
+			Type:    condition,
+			Status:  status,
+			Reason:  reason,
+			Message: statusMessage,
+		})
+		// we will return error of status update if it is not nil
+		err := r.Status().Update(ctx, apiKey)
+		if err != nil {
+			reqLogger.V(0).Info(fmt.Sprintf("Failed to update API key status: %v", retErr))
+			retErr = errors.Join(retErr, err)
+		}
+	}()
+
+	if len(apiKey.Status.ID) > 0 {
+		condition = ConditionsUpdate
+		reason = ConditionReasonRenewAPIKey
+	}
+	secret, err := r.apiKeySecret(ctx, apiKey)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't %s %s: %v", reason, apiKey.Spec.Name, err)
+		return ctrl.Result{}, nil
+	}
+	var current *elasticsearch.APIKeyInfo
+	if len(apiKey.Status.ID) > 0 {
+		current, err = r.ES.GetAPIKey(ctx, apiKey.Status.ID)
+		if err != nil {
+			status = metav1.ConditionFalse
+			statusMessage = fmt.Sprintf("can't %s %s: %v", reason, apiKey.Spec.Name, err)
+			return ctrl.Result{}, nil
+		}
+	}
+
+	storedID := ""
+	if secret != nil {
+		storedID = string(secret.Data[apiKeySecretID])
+	}
+	// New API key is created when there is no valid one, it is not stored in Secret, spec has changed or it is about to expire
+	now := time.Now()
+	renewAt := apiKeyRenewTime(apiKey)
+	if current == nil || current.Invalidated || (!current.Expiration.IsZero() && !now.Before(current.Expiration)) ||
+		storedID != apiKey.Status.ID ||
+		apiKey.Status.ObservedGeneration != apiKey.Generation ||
+		(!renewAt.IsZero() && !now.Before(renewAt)) {
+		// API key which is not stored in Secret, e.g. because storing it has failed, is useless
+		if current != nil && !current.Invalidated && current.ID != storedID {
+			err = r.ES.InvalidateAPIKey(ctx, current.ID)
+			if err != nil {
+				reason = ConditionReasonInvalidateAPIKey
+				status = metav1.ConditionFalse
+				statusMessage = err.Error()
+				return ctrl.Result{}, nil
+			}
+			current = nil
+		}
+		// API key stored in Secret stays valid for grace period, it is replaced one if last attempt has failed
+		replacedID := ""
+		if current != nil && !current.Invalidated {
+			replacedID = current.ID
+		} else if len(apiKey.Status.PreviousID) > 0 && apiKey.Status.PreviousID == storedID {
+			replacedID = apiKey.Status.PreviousID
+		}
+		// Only one replaced API key is kept valid
+		if len(apiKey.Status.PreviousID) > 0 && apiKey.Status.PreviousID != replacedID {
+			err = r.ES.InvalidateAPIKey(ctx, apiKey.Status.PreviousID)
+			if err != nil {
+				reason = ConditionReasonInvalidateAPIKey
+				status = metav1.ConditionFalse
+				statusMessage = err.Error()
+				return ctrl.Result{}, nil
+			}
+			apiKey.Status.PreviousID = ""
+			apiKey.Status.PreviousInvalidationTime = nil
+		}
+		key, err := r.ES.CreateAPIKey(ctx, apiKey)
+		if err != nil {
+			status = metav1.ConditionFalse
+			statusMessage = fmt.Sprintf("can't %s %s: %v", reason, apiKey.Spec.Name, err)
+			if isUnsupportedFlavor(err) {
+				reason = ConditionReasonUnsupportedFlavor
+			}
+			return ctrl.Result{}, nil
+		}
+		if len(replacedID) > 0 {
+			gracePeriod := apiKeyGracePeriod
+			if apiKey.Spec.GracePeriod != nil {
+				gracePeriod = apiKey.Spec.GracePeriod.Duration
+			}
+			apiKey.Status.PreviousID = replacedID
+			apiKey.Status.PreviousInvalidationTime = &metav1.Time{Time: now.Add(gracePeriod)}
+		}
+		apiKey.Status.ID = key.ID
+		apiKey.Status.CreationTime = &metav1.Time{Time: now}
+		apiKey.Status.ExpirationTime = optionalTime(key.Expiration)
+		apiKey.Status.ObservedGeneration = apiKey.Generation
+		// API key is recorded before it is stored in Secret, so it is invalidated on retry if storing it fails
+		err = r.Status().Update(ctx, apiKey)
+		if err != nil {
+			err = errors.Join(fmt.Errorf("can't record API key %s: %w", key.ID, err), r.ES.InvalidateAPIKey(ctx, key.ID))
+			status = metav1.ConditionFalse
+			statusMessage = fmt.Sprintf("can't %s %s: %v", reason, apiKey.Spec.Name, err)
+			return ctrl.Result{}, nil
+		}
+		err = r.writeAPIKeySecret(ctx, apiKey, secret, key)
+		if err != nil {
+			// API key nobody can read is useless
+			err = errors.Join(err, r.ES.InvalidateAPIKey(ctx, key.ID))
+			status = metav1.ConditionFalse
+			statusMessage = fmt.Sprintf("can't %s %s: %v", reason, apiKey.Spec.Name, err)
+			return ctrl.Result{}, nil
+		}
+		renewAt = apiKeyRenewTime(apiKey)
+		statusMessage = fmt.Sprintf("Succeeded, API key %s is stored in secret %s", key.ID, apiKeySecretName(apiKey))
+	}
+
+	// Replaced API key is invalidated after grace period
+	if len(apiKey.Status.PreviousID) > 0 && !now.Before(apiKey.Status.PreviousInvalidationTime.Time) {
+		err = r.ES.InvalidateAPIKey(ctx, apiKey.Status.PreviousID)
+		if err != nil {
+			reason = ConditionReasonInvalidateAPIKey
+			status = metav1.ConditionFalse
+			statusMessage = err.Error()
+			return ctrl.Result{RequeueAfter: RevisitIntervalSec * time.Second}, nil
+		}
+		apiKey.Status.PreviousID = ""
+		apiKey.Status.PreviousInvalidationTime = nil
+	}
+
+	// Object is revisited for renewal and invalidation of replaced API key
+	requeueAfter := RevisitIntervalSec * time.Second
+	if !renewAt.IsZero() && time.Until(renewAt) < requeueAfter {
+		requeueAfter = time.Until(renewAt)
+	}
+	if len(apiKey.Status.PreviousID) > 0 && time.Until(apiKey.Status.PreviousInvalidationTime.Time) < requeueAfter {
+		requeueAfter = time.Until(apiKey.Status.PreviousInvalidationTime.Time)
+	}
+	if requeueAfter < time.Second {
+		requeueAfter = time.Second
+	}
+	return ctrl.Result{
+		RequeueAfter: requeueAfter,
+	}, nil
+}
+
+// invalidateAPIKeys would invalidate current and replaced API keys of object
+func (r *ElasticSearchAPIKeyReconciler) invalidateAPIKeys(ctx context.Context, apiKey *xov1alpha1.ElasticSearchAPIKey) error {
+	for _, id := range []string{apiKey.Status.ID, apiKey.Status.PreviousID} {
+		if len(id) == 0 {
+			continue
+		}
+		err := r.ES.InvalidateAPIKey(ctx, id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apiKeySecret would get Secret with API key, nil is returned if it doesn't exist.
+// Secret which is not owned by object is never overwritten.
+func (r *ElasticSearchAPIKeyReconciler) apiKeySecret(ctx context.Context, apiKey *xov1alpha1.ElasticSearchAPIKey) (*corev1.Secret, error) {
+	name := apiKeySecretName(apiKey)
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: apiKey.Namespace, Name: name}, secret)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get secret %s: %w", name, err)
+	}
+	if !metav1.IsControlledBy(secret, apiKey) {
+		return nil, fmt.Errorf("secret %s is not owned by API key object", name)
+	}
+	return secret, nil
+}
+
+// writeAPIKeySecret would store API key in Secret owned by object, Secret is created if it doesn't exist
+func (r *ElasticSearchAPIKeyReconciler) writeAPIKeySecret(ctx context.Context, apiKey *xov1alpha1.ElasticSearchAPIKey,
+	secret *corev1.Secret, key *elasticsearch.APIKey) error {
+	data := map[string][]byte{
+		apiKeySecretID:      []byte(key.ID),
+		apiKeySecretAPIKey:  []byte(key.APIKey),
+		apiKeySecretEncoded: []byte(key.Encoded),
+	}
+	if secret != nil {
+		secret.Data = data
+		err := r.Update(ctx, secret)
+		if err != nil {
+			return fmt.Errorf("can't update secret %s: %w", secret.Name, err)
+		}
+		return nil
+	}
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      apiKeySecretName(apiKey),
+			Namespace: apiKey.Namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+	// Secret is garbage collected together with object
+	err := controllerutil.SetControllerReference(apiKey, secret, r.Scheme)
+	if err != nil {
+		return fmt.Errorf("can't set owner of secret %s: %w", secret.Name, err)
+	}
+	err = r.Create(ctx, secret)
+	if err != nil {
+		return fmt.Errorf("can't create secret %s: %w", secret.Name, err)
+	}
+	return nil
+}
+
+// apiKeySecretName would return name of Secret with API key
+func apiKeySecretName(apiKey *xov1alpha1.ElasticSearchAPIKey) string {
+	if len(apiKey.Spec.SecretName) > 0 {
+		return apiKey.Spec.SecretName
+	}
+	return apiKey.Name + apiKeySecretSuffix
+}
+
+// apiKeyRenewTime would return time new API key must be created at, it is zero for API key which never expires
+func apiKeyRenewTime(apiKey *xov1alpha1.ElasticSearchAPIKey) time.Time {
+	if apiKey.Status.ExpirationTime == nil || apiKey.Status.CreationTime == nil {
+		return time.Time{}
+	}
+	expiration := apiKey.Status.ExpirationTime.Time
+	renewBefore := expiration.Sub(apiKey.Status.CreationTime.Time) / 3
+	if apiKey.Spec.RenewBefore != nil {
+		renewBefore = apiKey.Spec.RenewBefore.Duration
+	}
+	return expiration.Add(-renewBefore)
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/elasticsearch"
+	"github.com/90poe/elasticsearch-objects-operator/internal/reporter"
+)
+
+// apiKeyTestES is ES cluster which keeps API keys in memory
+type apiKeyTestES struct {
+	elasticsearch.ES
+	keys          map[string]*elasticsearch.APIKeyInfo
+	created       int
+	failToInvalid error
+}
+
+func (e *apiKeyTestES) CreateAPIKey(_ context.Context, apiKey *xov1alpha1.ElasticSearchAPIKey) (*elasticsearch.APIKey, error) {
+	e.created++
+	id := fmt.Sprintf("key-%d", e.created)
+	e.keys[id] = &elasticsearch.APIKeyInfo{ID: id, Name: apiKey.Spec.Name, Creation: time.Now()}
+	return &elasticsearch.APIKey{ID: id, Name: apiKey.Spec.Name, APIKey: "secret-" + id, Encoded: "encoded-" + id}, nil
+}
+
+func (e *apiKeyTestES) GetAPIKey(_ context.Context, id string) (*elasticsearch.APIKeyInfo, error) {
+	key, ok := e.keys[id]
+	if !ok {
+		return nil, nil
+	}
+	info := *key
+	return &info, nil
+}
+
+func (e *apiKeyTestES) InvalidateAPIKey(_ context.Context, id string) error {
+	if e.failToInvalid != nil {
+		return e.failToInvalid
+	}
+	if key, ok := e.keys[id]; ok {
+		key.Invalidated = true
+	}
+	return nil
+}
+
+// apiKeyTestClient is shared test client which checks that API key is recorded in status before it is stored in Secret
+type apiKeyTestClient struct {
+	*testClient
+	failToWrite   error
+	unrecordedKey bool
+}
+
+func newAPIKeyTestReconciler(t *testing.T, apiKey *xov1alpha1.ElasticSearchAPIKey) (*ElasticSearchAPIKeyReconciler, *apiKeyTestClient, *apiKeyTestES) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, xov1alpha1.AddToScheme(scheme))
+	messenger, err := reporter.New("token")
+	require.NoError(t, err)
+	c := &apiKeyTestClient{testClient: newTestClient(apiKey)}
+	c.fail = func(verb string, obj client.Object) error {
+		secret, ok := obj.(*corev1.Secret)
+		if !ok || verb == "get" {
+			return nil
+		}
+		recorded := &xov1alpha1.ElasticSearchAPIKey{}
+		err := c.Get(context.Background(), client.ObjectKeyFromObject(apiKey), recorded)
+		if err != nil || string(secret.Data[apiKeySecretID]) != recorded.Status.ID {
+			c.unrecordedKey = true
+		}
+		return c.failToWrite
+	}
+	es := &apiKeyTestES{keys: map[string]*elasticsearch.APIKeyInfo{}}
+	return &ElasticSearchAPIKeyReconciler{
+		Client:    c,
+		Scheme:    scheme,
+		Messenger: messenger,
+		ES:        es,
+	}, c, es
+}
+
+func apiKeySecretData(t *testing.T, c client.Client, key string) string {
+	secret := &corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Namespace: "search", Name: "ingest-elasticsearch-api-key"}, secret))
+	return string(secret.Data[key])
+}
+
+func newTestAPIKey() *xov1alpha1.ElasticSearchAPIKey {
+	return &xov1alpha1.ElasticSearchAPIKey{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "ingest",
+			Namespace:  "search",
+			UID:        "7f0c1d52-4a0e-4bb4-9d43-2f1c2a5f8e11",
+			Generation: 1,
+		},
+		Spec: xov1alpha1.ElasticSearchAPIKeySpec{
+			Name: "ingest",
+		},
+	}
+}
+
+func TestUpsertAPIKeyRotation(t *testing.T) {
+	apiKey := newTestAPIKey()
+	r, c, es := newAPIKeyTestReconciler(t, apiKey)
+
+	_, err := r.upsertAPIKey(context.Background(), apiKey, logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, "key-1", apiKey.Status.ID)
+	assert.Equal(t, "key-1", apiKeySecretData(t, c, apiKeySecretID))
+
+	// Nothing changed, API key is kept
+	_, err = r.upsertAPIKey(context.Background(), apiKey, logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, 1, es.created)
+
+	// Replaced API key stays valid for grace period
+	apiKey.Generation = 2
+	_, err = r.upsertAPIKey(context.Background(), apiKey, logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", apiKey.Status.ID)
+	assert.Equal(t, "key-1", apiKey.Status.PreviousID)
+	assert.False(t, es.keys["key-1"].Invalidated)
+	assert.Equal(t, "key-2", apiKeySecretData(t, c, apiKeySecretID))
+
+	// Only one replaced API key is kept valid
+	apiKey.Generation = 3
+	_, err = r.upsertAPIKey(context.Background(), apiKey, logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, "key-3", apiKey.Status.ID)
+	assert.Equal(t, "key-2", apiKey.Status.PreviousID)
+	assert.True(t, es.keys["key-1"].Invalidated)
+	assert.False(t, es.keys["key-2"].Invalidated)
+
+	// Replaced API key is invalidated after grace period
+	apiKey.Status.PreviousInvalidationTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+	_, err = r.upsertAPIKey(context.Background(), apiKey, logr.Discard())
+	require.NoError(t, err)
+	assert.True(t, es.keys["key-2"].Invalidated)
+	assert.Empty(t, apiKey.Status.PreviousID)
+	assert.Equal(t, 3, es.created)
+
+	// API key is always recorded in status before it is stored in Secret
+	assert.False(t, c.unrecordedKey)
+}
+
+func TestUpsertAPIKeyOrphanInvalidation(t *testing.T) {
+	apiKey := newTestAPIKey()
+	r, c, es := newAPIKeyTestReconciler(t, apiKey)
+	_, err := r.upsertAPIKey(context.Background(), apiKey, logr.Discard())
+	require.NoError(t, err)
+
+	// API key which can't be stored in Secret is invalidated right away
+	apiKey.Generation = 2
+	c.failToWrite = errors.New("secrets is forbidden")
+	_, err = r.upsertAPIKey(context.Background(), apiKey, logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, "key-2", apiKey.Status.ID)
+	assert.True(t, es.keys["key-2"].Invalidated)
+	assert.False(t, es.keys["key-1"].Invalidated)
+
+	// API key which is neither stored nor invalidated is invalidated on retry,
+	// API key stored in Secret is replaced
+	es.failToInvalid = errors.New("connection refused")
+	_, err = r.upsertAPIKey(context.Background(), apiKey, logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, "key-3", apiKey.Status.ID)
+	assert.False(t, es.keys["key-3"].Invalidated)
+	c.failToWrite = nil
+	es.failToInvalid = nil
+	_, err = r.upsertAPIKey(context.Background(), apiKey, logr.Discard())
+	require.NoError(t, err)
+	assert.True(t, es.keys["key-3"].Invalidated)
+	assert.Equal(t, "key-4", apiKey.Status.ID)
+	assert.Equal(t, "key-1", apiKey.Status.PreviousID)
+	assert.False(t, es.keys["key-1"].Invalidated)
+	assert.Equal(t, "key-4", apiKeySecretData(t, c, apiKeySecretID))
+	assert.False(t, c.unrecordedKey)
+}
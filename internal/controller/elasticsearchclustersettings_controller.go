@@ -0,0 +1,226 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/config"
+	"github.com/90poe/elasticsearch-objects-operator/internal/elasticsearch"
+	"github.com/90poe/elasticsearch-objects-operator/internal/reporter"
+)
+
+// ElasticSearchClusterSettingsReconciler reconciles a ElasticSearchClusterSettings object
+type ElasticSearchClusterSettingsReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Messenger *reporter.Messenger
+	ES        elasticsearch.ES
+}
+
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchclustersettings,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchclustersettings/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchclustersettings/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ElasticSearchClusterSettingsReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx).WithValues("elasticsearchclustersettings", req.NamespacedName)
+
+	// Fetch the ElasticSearchClusterSettings instance
+	instance := &xov1alpha1.ElasticSearchClusterSettings{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			reqLogger.V(0).Info("ElasticSearchClusterSettings resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		reqLogger.V(0).Info(fmt.Sprintf("Failed to get ElasticSearchClusterSettings: %v", err))
+		return reconcile.Result{}, err
+	}
+
+	// Only settings object has applied are reset
+	deleted, err := reconcileDropOnDelete(ctx, r.Client, instance, instance.Spec.DropOnDelete,
+		func(ctx context.Context) error {
+			return r.ES.ResetClusterSettings(ctx, instance.Status.AppliedKeys)
+		})
+	if err != nil || deleted {
+		return reconcile.Result{}, err
+	}
+
+	return r.updateClusterSettings(ctx, instance, reqLogger)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticSearchClusterSettingsReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c := config.Get()
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&xov1alpha1.ElasticSearchClusterSettings{}, builder.WithPredicates(ignoreUpdateDeletePredicate())).
+		// Remaining objects are reconciled once one is deleted, so the next one takes over cluster settings
+		Watches(&xov1alpha1.ElasticSearchClusterSettings{},
+			handler.EnqueueRequestsFromMapFunc(r.otherClusterSettings), builder.WithPredicates(deletedPredicate())).
+		WithOptions(controller.Options{MaxConcurrentReconciles: c.MaxConcurrentReconciles}).
+		Complete(r)
+}
+
+// otherClusterSettings would map cluster settings object to all other cluster settings objects
+func (r *ElasticSearchClusterSettingsReconciler) otherClusterSettings(ctx context.Context, settings client.Object) []reconcile.Request {
+	list := &xov1alpha1.ElasticSearchClusterSettingsList{}
+	err := r.List(ctx, list)
+	if err != nil {
+		log.FromContext(ctx).V(0).Info(fmt.Sprintf("can't list cluster settings objects: %v", err))
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(list.Items))
+	for _, item := range list.Items {
+		if item.UID == settings.GetUID() {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: item.Namespace, Name: item.Name},
+		})
+	}
+	return requests
+}
+
+// updateClusterSettings will update persistent cluster settings in ES cluster
+func (r *ElasticSearchClusterSettingsReconciler) updateClusterSettings(ctx context.Context, settings *xov1alpha1.ElasticSearchClusterSettings, reqLogger logr.Logger) (_ ctrl.Result, retErr error) {
+	// Init status
+	statusMessage := "Succeeded"
+	status := metav1.ConditionTrue
+	condition := ConditionsInsert
+	reason := ConditionReasonUpdateClusterSettings
+
+	// Defer function to update status
+	defer func() {
+		// Log status update
+		reqLogger.Info(fmt.Sprintf("elasticsearch cluster settings %s status: %s", reason, statusMessage))
+		// Send message to slack, OK messages are only delivered when they resolve previous errors
+		msgType := reporter.MessageType(reporter.ErrorMessage)
+		notification := statusMessage
+		if status == metav1.ConditionTrue {
+			msgType = reporter.OKMessage
+			notification = fmt.Sprintf("elasticsearch cluster settings %s status: %s", reason, statusMessage)
+		}
+		notify(ctx, r, r.Messenger, settings, notification, msgType)
+		// Remove last condition and set new one
+		meta.RemoveStatusCondition(&settings.Status.Conditions, condition)
+		meta.SetStatusCondition(&settings.Status.Conditions, metav1.Condition{
+			Type:    condition,
+			Status:  status,
+			Reason:  reason,
+			Message: statusMessage,
+		})
+		// we will return error of status update if it is not nil
+		err := r.Status().Update(ctx, settings)
+		if err != nil {
+			reqLogger.V(0).Info(fmt.Sprintf("Failed to update cluster settings status: %v", retErr))
+			retErr = errors.Join(retErr, err)
+		}
+	}()
+
+	if len(settings.Status.AppliedKeys) > 0 {
+		condition = ConditionsUpdate
+	}
+	// Only one object manages cluster settings, so objects don't override each other
+	owner, err := r.clusterSettingsOwner(ctx)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't list cluster settings objects: %v", err)
+		return ctrl.Result{}, nil
+	}
+	if owner.UID != settings.UID {
+		reason = ConditionReasonDuplicateClusterSettings
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("cluster settings are managed by %s/%s, only one object per cluster is allowed",
+			owner.Namespace, owner.Name)
+		// Object is reconciled again once owner is deleted and takes over
+		return ctrl.Result{
+			RequeueAfter: RevisitIntervalSec * time.Second,
+		}, nil
+	}
+
+	msg, err := r.ES.UpdateClusterSettings(ctx, settings)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't %s: %v", reason, err)
+		return ctrl.Result{}, nil
+	}
+	statusMessage = msg
+	// Remember applied settings, so they are reset once removed from spec
+	settings.Status.AppliedKeys = make([]string, 0, len(settings.Spec.Persistent))
+	for key := range settings.Spec.Persistent {
+		settings.Status.AppliedKeys = append(settings.Status.AppliedKeys, key)
+	}
+	sort.Strings(settings.Status.AppliedKeys)
+	// Applied settings are recorded right away, they must not be lost if object is deleted before next status update
+	err = r.Status().Update(ctx, settings)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't record applied cluster settings: %v", err)
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{
+		RequeueAfter: RevisitIntervalSec * time.Second,
+	}, nil
+}
+
+// clusterSettingsOwner would return oldest cluster settings object, it is one which manages cluster settings
+func (r *ElasticSearchClusterSettingsReconciler) clusterSettingsOwner(ctx context.Context) (*xov1alpha1.ElasticSearchClusterSettings, error) {
+	list := &xov1alpha1.ElasticSearchClusterSettingsList{}
+	err := r.List(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	var owner *xov1alpha1.ElasticSearchClusterSettings
+	for i := range list.Items {
+		item := &list.Items[i]
+		if !item.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if owner == nil || item.CreationTimestamp.Before(&owner.CreationTimestamp) ||
+			(item.CreationTimestamp.Equal(&owner.CreationTimestamp) &&
+				item.Namespace+"/"+item.Name < owner.Namespace+"/"+owner.Name) {
+			owner = item
+		}
+	}
+	if owner == nil {
+		return nil, fmt.Errorf("no cluster settings objects found")
+	}
+	return owner, nil
+}
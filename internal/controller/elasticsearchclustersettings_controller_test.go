@@ -0,0 +1,106 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/elasticsearch"
+	"github.com/90poe/elasticsearch-objects-operator/internal/reporter"
+)
+
+// clusterSettingsTestES is ES cluster which keeps persistent cluster settings in memory
+type clusterSettingsTestES struct {
+	elasticsearch.ES
+	persistent map[string]string
+}
+
+func (e *clusterSettingsTestES) UpdateClusterSettings(_ context.Context, object *xov1alpha1.ElasticSearchClusterSettings) (string, error) {
+	for _, key := range object.Status.AppliedKeys {
+		if _, ok := object.Spec.Persistent[key]; !ok {
+			delete(e.persistent, key)
+		}
+	}
+	for key, value := range object.Spec.Persistent {
+		e.persistent[key] = value
+	}
+	return "successfully updated cluster settings", nil
+}
+
+func TestUpdateClusterSettingsAppliedKeys(t *testing.T) {
+	messenger, err := reporter.New("token")
+	require.NoError(t, err)
+	settings := &xov1alpha1.ElasticSearchClusterSettings{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster",
+			Namespace: "search",
+			UID:       "0b7d8a1e-6c4f-4f7e-9a55-3c2d1e0f9b21",
+		},
+		Spec: xov1alpha1.ElasticSearchClusterSettingsSpec{
+			Persistent: map[string]string{
+				"search.max_buckets":       "30000",
+				"action.auto_create_index": "false",
+			},
+		},
+	}
+	c := newTestClient(settings)
+	es := &clusterSettingsTestES{persistent: map[string]string{}}
+	r := &ElasticSearchClusterSettingsReconciler{
+		Client:    c,
+		Messenger: messenger,
+		ES:        es,
+	}
+
+	_, err = r.updateClusterSettings(context.Background(), settings, logr.Discard())
+	require.NoError(t, err)
+	// Applied settings are recorded before status of reconcile
+	require.Len(t, c.statusUpdates, 2)
+	assert.Equal(t, []string{"action.auto_create_index", "search.max_buckets"},
+		c.statusUpdates[0].(*xov1alpha1.ElasticSearchClusterSettings).Status.AppliedKeys)
+
+	// Setting removed from spec is reset
+	delete(settings.Spec.Persistent, "action.auto_create_index")
+	_, err = r.updateClusterSettings(context.Background(), settings, logr.Discard())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"search.max_buckets": "30000"}, es.persistent)
+	assert.Equal(t, []string{"search.max_buckets"}, settings.Status.AppliedKeys)
+}
+
+func TestOtherClusterSettings(t *testing.T) {
+	owner := &xov1alpha1.ElasticSearchClusterSettings{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "search", UID: "owner"},
+	}
+	other := &xov1alpha1.ElasticSearchClusterSettings{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster", Namespace: "logs", UID: "other"},
+	}
+	r := &ElasticSearchClusterSettingsReconciler{Client: newTestClient(owner, other)}
+
+	// Remaining object is reconciled once owner is deleted
+	requests := r.otherClusterSettings(context.Background(), owner)
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "logs", Name: "cluster"}},
+	}, requests)
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/config"
+	"github.com/90poe/elasticsearch-objects-operator/internal/elasticsearch"
+	"github.com/90poe/elasticsearch-objects-operator/internal/reporter"
+)
+
+// ElasticSearchDataStreamReconciler reconciles a ElasticSearchDataStream object
+type ElasticSearchDataStreamReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Messenger *reporter.Messenger
+	ES        elasticsearch.ES
+}
+
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchdatastreams,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchdatastreams/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchdatastreams/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ElasticSearchDataStreamReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx).WithValues("elasticsearchdatastream", req.NamespacedName)
+
+	// Fetch the ElasticSearchDataStream instance
+	instance := &xov1alpha1.ElasticSearchDataStream{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			reqLogger.V(0).Info("ElasticSearchDataStream resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		reqLogger.V(0).Info(fmt.Sprintf("Failed to get ElasticSearchDataStream: %v", err))
+		return reconcile.Result{}, err
+	}
+
+	deleted, err := reconcileDropOnDelete(ctx, r.Client, instance, instance.Spec.DropOnDelete,
+		func(ctx context.Context) error {
+			return r.ES.DeleteDataStream(ctx, instance.Spec.Name)
+		})
+	if err != nil || deleted {
+		return reconcile.Result{}, err
+	}
+
+	return r.upsertDataStream(ctx, instance, reqLogger)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticSearchDataStreamReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c := config.Get()
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&xov1alpha1.ElasticSearchDataStream{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: c.MaxConcurrentReconciles}).
+		WithEventFilter(predicate.Or(ignoreUpdateDeletePredicate(), annotationChangedPredicate(RolloverAnnotation))).
+		Complete(r)
+}
+
+// upsertDataStream will create data stream in ES cluster, rollover it if requested and report its state
+func (r *ElasticSearchDataStreamReconciler) upsertDataStream(ctx context.Context, dataStream *xov1alpha1.ElasticSearchDataStream, reqLogger logr.Logger) (_ ctrl.Result, retErr error) {
+	// Init status
+	statusMessage := "Succeeded"
+	status := metav1.ConditionTrue
+	condition := ConditionsInsert
+	reason := ConditionReasonCreateDataStream
+
+	// Defer function to update status
+	defer func() {
+		// Log status update
+		reqLogger.Info(fmt.Sprintf("elasticsearch %s %s status: %s", dataStream.Spec.Name,
+			reason, statusMessage))
+		// Send message to slack, OK messages are only delivered when they resolve previous errors
+		msgType := reporter.MessageType(reporter.ErrorMessage)
+		notification := statusMessage
+		if status == metav1.ConditionTrue {
+			msgType = reporter.OKMessage
+			notification = fmt.Sprintf("elasticsearch %s %s status: %s", dataStream.Spec.Name, reason, statusMessage)
+		}
+		notify(ctx, r, r.Messenger, dataStream, notification, msgType)
+		// Remove last condition and set new one
+		meta.RemoveStatusCondition(&dataStream.Status.Conditions, condition)
+		meta.SetStatusCondition(&dataStream.Status.Conditions, metav1.Condition{
+			Type:    condition,
+			Status:  status,
+			Reason:  reason,
+			Message: statusMessage,
+		})
+		// we will return error of status update if it is not nil
+		err := r.Status().Update(ctx, dataStream)
+		if err != nil {
+			reqLogger.V(0).Info(fmt.Sprintf("Failed to update data stream status: %v", retErr))
+			retErr = errors.Join(retErr, err)
+		}
+	}()
+
+	// Check if data stream exists in ES cluster
+	exists, err := r.ES.DataStreamExists(ctx, dataStream.Spec.Name)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't check if data stream %s exists: %v", dataStream.Spec.Name, err)
+		return ctrl.Result{}, nil
+	}
+
+	// Create data stream, it has nothing to update
+	if exists {
+		condition = ConditionsUpdate
+		reason = ConditionReasonUpdateDataStream
+	} else {
+		_, err = r.ES.CreateDataStream(ctx, dataStream)
+		if err != nil {
+			status = metav1.ConditionFalse
+			statusMessage = fmt.Sprintf("can't %s %s: %v", reason, dataStream.Spec.Name, err)
+			return ctrl.Result{}, nil
+		}
+	}
+
+	// Manual rollover is done once for every new value of annotation
+	rollover := dataStream.GetAnnotations()[RolloverAnnotation]
+	if len(rollover) > 0 && rollover != dataStream.Status.LastRollover {
+		reason = ConditionReasonRolloverDataStream
+		err = r.ES.RolloverDataStream(ctx, dataStream.Spec.Name)
+		if err != nil {
+			status = metav1.ConditionFalse
+			statusMessage = fmt.Sprintf("can't %s %s: %v", reason, dataStream.Spec.Name, err)
+			return ctrl.Result{}, nil
+		}
+		dataStream.Status.LastRollover = rollover
+	}
+
+	// Report data stream state
+	state, err := r.ES.GetDataStream(ctx, dataStream.Spec.Name)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = err.Error()
+		return ctrl.Result{}, nil
+	}
+	dataStream.Status.Generation = state.Generation
+	dataStream.Status.BackingIndices = state.BackingIndices()
+	dataStream.Status.Template = state.Template
+	dataStream.Status.ILMPolicy = state.ILMPolicy
+	dataStream.Status.Health = state.Status
+
+	return ctrl.Result{
+		RequeueAfter: RevisitIntervalSec * time.Second,
+	}, nil
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/config"
+	"github.com/90poe/elasticsearch-objects-operator/internal/cron"
+	"github.com/90poe/elasticsearch-objects-operator/internal/elasticsearch"
+	"github.com/90poe/elasticsearch-objects-operator/internal/reporter"
+)
+
+// ElasticSearchEnrichPolicyReconciler reconciles a ElasticSearchEnrichPolicy object
+type ElasticSearchEnrichPolicyReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Messenger *reporter.Messenger
+	ES        elasticsearch.ES
+}
+
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchenrichpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchenrichpolicies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchenrichpolicies/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ElasticSearchEnrichPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx).WithValues("elasticsearchenrichpolicy", req.NamespacedName)
+
+	// Fetch the ElasticSearchEnrichPolicy instance
+	instance := &xov1alpha1.ElasticSearchEnrichPolicy{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			reqLogger.V(0).Info("ElasticSearchEnrichPolicy resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		reqLogger.V(0).Info(fmt.Sprintf("Failed to get ElasticSearchEnrichPolicy: %v", err))
+		return reconcile.Result{}, err
+	}
+
+	// Only enrich policy created by operator is dropped
+	deleted, err := reconcileDropOnDelete(ctx, r.Client, instance, instance.Spec.DropOnDelete,
+		func(ctx context.Context) error {
+			if len(instance.Status.Name) == 0 {
+				return nil
+			}
+			return r.ES.DeleteEnrichPolicy(ctx, instance.Status.Name)
+		})
+	if err != nil || deleted {
+		return reconcile.Result{}, err
+	}
+
+	return r.upsertEnrichPolicy(ctx, instance, reqLogger)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticSearchEnrichPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c := config.Get()
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&xov1alpha1.ElasticSearchEnrichPolicy{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: c.MaxConcurrentReconciles}).
+		WithEventFilter(ignoreUpdateDeletePredicate()).
+		Complete(r)
+}
+
+// upsertEnrichPolicy will create or recreate enrich policy in ES cluster and execute it when needed
+func (r *ElasticSearchEnrichPolicyReconciler) upsertEnrichPolicy(ctx context.Context, policy *xov1alpha1.ElasticSearchEnrichPolicy, reqLogger logr.Logger) (_ ctrl.Result, retErr error) {
+	// Init status
+	statusMessage := "Succeeded"
+	status := metav1.ConditionTrue
+	condition := ConditionsInsert
+	reason := ConditionReasonCreateEnrichPolicy
+
+	// Defer function to update status
+	defer func() {
+		// Log status update
+		reqLogger.Info(fmt.Sprintf("elasticsearch %s %s status: %s", policy.Spec.Name,
+			reason, statusMessage))
+		// Send message to slack, OK messages are only delivered when they resolve previous errors
+		msgType := reporter.MessageType(reporter.ErrorMessage)
+		notification := statusMessage
+		if status == metav1.ConditionTrue {
+			msgType = reporter.OKMessage
+			notification = fmt.Sprintf("elasticsearch %s %s status: %s", policy.Spec.Name, reason, statusMessage)
+		}
+		notify(ctx, r, r.Messenger, policy, notification, msgType)
+		// Remove last condition and set new one
+		meta.RemoveStatusCondition(&policy.Status.Conditions, condition)
+		meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:    condition,
+			Status:  status,
+			Reason:  reason,
+			Message: statusMessage,
+		})
+		// we will return error of status update if it is not nil
+		err := r.Status().Update(ctx, policy)
+		if err != nil {
+			reqLogger.V(0).Info(fmt.Sprintf("Failed to update enrich policy status: %v", retErr))
+			retErr = errors.Join(retErr, err)
+		}
+	}()
+
+	var schedule *cron.Schedule
+	if len(policy.Spec.Schedule) > 0 {
+		var err error
+		schedule, err = cron.Parse(policy.Spec.Schedule)
+		if err != nil {
+			status = metav1.ConditionFalse
+			statusMessage = err.Error()
+			return ctrl.Result{}, nil
+		}
+		if schedule.Next(time.Now()).IsZero() {
+			status = metav1.ConditionFalse
+			statusMessage = fmt.Sprintf("schedule %s of enrich policy %s never fires", policy.Spec.Schedule, policy.Spec.Name)
+			return ctrl.Result{}, nil
+		}
+	}
+
+	// Enrich policy with previous name is released, with drop on delete it is dropped as K8S object no longer has it
+	if len(policy.Status.Name) != 0 && policy.Status.Name != policy.Spec.Name {
+		if policy.Spec.DropOnDelete {
+			err := r.ES.DeleteEnrichPolicy(ctx, policy.Status.Name)
+			if err != nil {
+				status = metav1.ConditionFalse
+				statusMessage = fmt.Sprintf("can't drop enrich policy %s with previous name: %v", policy.Status.Name, err)
+				return ctrl.Result{}, nil
+			}
+		}
+		policy.Status.Name = ""
+	}
+
+	// Check if enrich policy exists in ES cluster
+	exists, err := r.ES.EnrichPolicyExists(ctx, policy.Spec.Name)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't check if enrich policy %s exists: %v", policy.Spec.Name, err)
+		if isUnsupportedFlavor(err) {
+			reason = ConditionReasonUnsupportedFlavor
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Create or recreate enrich policy
+	if exists {
+		condition = ConditionsUpdate
+		reason = ConditionReasonUpdateEnrichPolicy
+	}
+	// Existing policy is only recreated if it is already managed by object, identical one is not adopted
+	owned := !exists || policy.Status.Name == policy.Spec.Name
+	// Enrich policy has no metadata, so it is marked as managed by object before it is created,
+	// created policy can't be told from one created outside of operator later
+	if owned && policy.Status.Name != policy.Spec.Name {
+		policy.Status.Name = policy.Spec.Name
+		err = r.Status().Update(ctx, policy)
+		if err != nil {
+			status = metav1.ConditionFalse
+			statusMessage = fmt.Sprintf("can't record enrich policy %s: %v", policy.Spec.Name, err)
+			return ctrl.Result{}, err
+		}
+	}
+	_, err = r.ES.CreateUpdateEnrichPolicy(ctx, policy)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't %s %s: %v", reason, policy.Spec.Name, err)
+		if isUnsupportedFlavor(err) {
+			reason = ConditionReasonUnsupportedFlavor
+		}
+		return ctrl.Result{}, nil
+	}
+	if !owned {
+		statusMessage = fmt.Sprintf("Succeeded, enrich policy %s is identical to spec and is not managed by this operator",
+			policy.Spec.Name)
+		return ctrl.Result{
+			RequeueAfter: RevisitIntervalSec * time.Second,
+		}, nil
+	}
+
+	// Running execution is followed until it is finished
+	last := policy.Status.LastExecution
+	if last != nil && last.Phase == xov1alpha1.JobPhaseRunning {
+		reason = ConditionReasonExecuteEnrichPolicy
+		execution, err := r.ES.GetEnrichPolicyExecution(ctx, last.Task)
+		if err == nil && !execution.Completed {
+			statusMessage = fmt.Sprintf("Succeeded, execution of enrich policy %s is running", policy.Spec.Name)
+			return ctrl.Result{RequeueAfter: ProgressIntervalSec * time.Second}, nil
+		}
+		last.CompletionTime = &metav1.Time{Time: time.Now()}
+		last.Phase = xov1alpha1.JobPhaseSucceeded
+		switch {
+		case err != nil:
+			last.Error = err.Error()
+		case len(execution.Error) > 0:
+			last.Error = execution.Error
+		}
+		if len(last.Error) > 0 {
+			last.Phase = xov1alpha1.JobPhaseFailed
+			status = metav1.ConditionFalse
+			statusMessage = fmt.Sprintf("execution of enrich policy %s failed: %s", policy.Spec.Name, last.Error)
+			return ctrl.Result{RequeueAfter: enrichPolicyRequeueAfter(policy)}, nil
+		}
+		statusMessage = fmt.Sprintf("Succeeded, execution of enrich policy %s is finished", policy.Spec.Name)
+	}
+
+	// Enrich index is built when policy is (re)created and every time schedule is due
+	now := time.Now()
+	due := policy.Status.NextExecutionTime != nil && !now.Before(policy.Status.NextExecutionTime.Time)
+	if !exists || last == nil || policy.Status.ObservedGeneration != policy.Generation || due {
+		reason = ConditionReasonExecuteEnrichPolicy
+		task, err := r.ES.ExecuteEnrichPolicy(ctx, policy.Spec.Name)
+		if err != nil {
+			status = metav1.ConditionFalse
+			statusMessage = fmt.Sprintf("can't %s %s: %v", reason, policy.Spec.Name, err)
+			return ctrl.Result{}, nil
+		}
+		policy.Status.LastExecution = &xov1alpha1.ESEnrichPolicyExecution{
+			Task:      task,
+			Phase:     xov1alpha1.JobPhaseRunning,
+			StartTime: &metav1.Time{Time: now},
+		}
+		policy.Status.ObservedGeneration = policy.Generation
+		policy.Status.NextExecutionTime = nil
+		if schedule != nil {
+			policy.Status.NextExecutionTime = optionalTime(schedule.Next(now))
+		}
+		statusMessage = fmt.Sprintf("Succeeded, execution of enrich policy %s is started", policy.Spec.Name)
+		return ctrl.Result{RequeueAfter: ProgressIntervalSec * time.Second}, nil
+	}
+
+	return ctrl.Result{
+		RequeueAfter: enrichPolicyRequeueAfter(policy),
+	}, nil
+}
+
+// enrichPolicyRequeueAfter would return time until next scheduled execution if it is earlier than next revisit
+func enrichPolicyRequeueAfter(policy *xov1alpha1.ElasticSearchEnrichPolicy) time.Duration {
+	requeueAfter := RevisitIntervalSec * time.Second
+	if policy.Status.NextExecutionTime != nil {
+		untilNext := time.Until(policy.Status.NextExecutionTime.Time)
+		if untilNext < time.Second {
+			untilNext = time.Second
+		}
+		if untilNext < requeueAfter {
+			requeueAfter = untilNext
+		}
+	}
+	return requeueAfter
+}
@@ -18,17 +18,25 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -39,17 +47,30 @@ import (
 	"github.com/go-logr/logr"
 )
 
+// errBackupPending is returned by drop of index while backup snapshot of it is being taken
+var errBackupPending = errors.New("backup snapshot is not finished yet")
+
+const (
+	// indexSynonymsConfigMapField is index of indices by names of ConfigMaps with their synonyms
+	indexSynonymsConfigMapField = "spec.synonyms.config_map_ref"
+	// backupOperationDrop is drop of index on delete
+	backupOperationDrop = "drop"
+	// backupOperationRetention is deletion of rollover generations beyond retention
+	backupOperationRetention = "deletion of old generations"
+)
+
 // ElasticSearchIndexReconciler reconciles a ElasticSearchIndex object
 type ElasticSearchIndexReconciler struct {
 	client.Client
 	Scheme    *runtime.Scheme
-	es        elasticsearch.ES
-	messenger *reporter.Messenger
+	Messenger *reporter.Messenger
+	ES        elasticsearch.ES
 }
 
 //+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchindices,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchindices/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchindices/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -65,7 +86,7 @@ func (r *ElasticSearchIndexReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 	// Fetch the ElasticSearchIndex instance
 	instance := &xov1alpha1.ElasticSearchIndex{}
-	err := r.Get(context.TODO(), req.NamespacedName, instance)
+	err := r.Get(ctx, req.NamespacedName, instance)
 	if err != nil {
 		if kerrors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
@@ -79,29 +100,42 @@ func (r *ElasticSearchIndexReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return reconcile.Result{}, err
 	}
 
+	deleted, err := reconcileDropOnDelete(ctx, r.Client, instance, instance.Spec.DropOnDelete,
+		func(ctx context.Context) error {
+			return r.dropIndex(ctx, instance)
+		})
+	if errors.Is(err, errBackupPending) {
+		return reconcile.Result{RequeueAfter: ProgressIntervalSec * time.Second}, nil
+	}
+	if err != nil || deleted {
+		return reconcile.Result{}, err
+	}
+
 	return r.upsertIndex(ctx, instance, reqLogger)
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ElasticSearchIndexReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	c := config.Get()
-	es, err := elasticsearch.New(
-		elasticsearch.URL(c.ESurl),
-	)
-	if err != nil {
-		return err
-	}
-	r.es = es
-	// Make slack messenger
-	r.messenger, err = reporter.New(c.SlackToken,
-		reporter.SlackChannel(c.SlackChannel))
+	// Indices are indexed by ConfigMaps with their synonyms, so ConfigMap is mapped to indices without listing them all
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &xov1alpha1.ElasticSearchIndex{}, indexSynonymsConfigMapField,
+		func(obj client.Object) []string {
+			names := []string{}
+			for _, filter := range obj.(*xov1alpha1.ElasticSearchIndex).Spec.Synonyms {
+				if !slices.Contains(names, filter.ConfigMapRef.Name) {
+					names = append(names, filter.ConfigMapRef.Name)
+				}
+			}
+			return names
+		})
 	if err != nil {
-		return err
+		return fmt.Errorf("can't index indices by synonyms configmap: %w", err)
 	}
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&xov1alpha1.ElasticSearchIndex{}).
+		For(&xov1alpha1.ElasticSearchIndex{}, builder.WithPredicates(ignoreUpdateDeletePredicate())).
+		// Synonyms are pushed every time their ConfigMap changes, ConfigMaps have no generation so they are not filtered
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.indicesOfConfigMap)).
 		WithOptions(controller.Options{MaxConcurrentReconciles: c.MaxConcurrentReconciles}).
-		WithEventFilter(ignoreUpdateDeletePredicate()).
 		Complete(r)
 }
 
@@ -118,11 +152,14 @@ func (r *ElasticSearchIndexReconciler) upsertIndex(ctx context.Context, index *x
 		// Log status update
 		reqLogger.Info(fmt.Sprintf("elasticsearch %s %s status: %s", index.Spec.Name,
 			reason, statusMessage))
-		// Send message to slack
-		if status == metav1.ConditionFalse {
-			// send message only on error
-			r.messenger.Send(statusMessage, reporter.ErrorMessage)
+		// Send message to slack, OK messages are only delivered when they resolve previous errors
+		msgType := reporter.MessageType(reporter.ErrorMessage)
+		notification := statusMessage
+		if status == metav1.ConditionTrue {
+			msgType = reporter.OKMessage
+			notification = fmt.Sprintf("elasticsearch %s %s status: %s", index.Spec.Name, reason, statusMessage)
 		}
+		notify(ctx, r, r.Messenger, index, notification, msgType)
 		// Remove last condition and set new one
 		meta.RemoveStatusCondition(&index.Status.Conditions, condition)
 		meta.SetStatusCondition(&index.Status.Conditions, metav1.Condition{
@@ -140,7 +177,7 @@ func (r *ElasticSearchIndexReconciler) upsertIndex(ctx context.Context, index *x
 	}()
 
 	// Check if index exists in ES cluster
-	exists, err := r.es.IndexExists(index.Spec.Name)
+	exists, err := r.ES.IndexExists(ctx, index.Spec.Name)
 	if err != nil {
 		status = metav1.ConditionFalse
 		statusMessage = fmt.Sprintf("can't check if index %s exists: %v", index.Spec.Name, err)
@@ -152,15 +189,318 @@ func (r *ElasticSearchIndexReconciler) upsertIndex(ctx context.Context, index *x
 		condition = ConditionsUpdate
 		reason = ConditionReasonUpdateIndex
 	}
-	_, err = r.es.CreateUpdateIndex(index)
+	// Synonym rules are taken from ConfigMaps
+	synonyms, versions, err := r.indexSynonyms(ctx, index)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't %s ES index %s: %v", reason, index.Name, err)
+		return ctrl.Result{}, nil
+	}
+	// Index is only closed to update inline synonyms of filters which allow it, it is backed up first
+	synonymsChange, err := r.ES.InlineSynonymsChanges(ctx, index, synonyms)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't check synonyms of ES index %s: %v", index.Spec.Name, err)
+		return ctrl.Result{RequeueAfter: RevisitIntervalSec * time.Second}, nil
+	}
+	if synonymsChange != nil && len(synonymsChange.Closed) > 0 && index.Spec.Backup != nil {
+		done, err := r.backup(ctx, index, []string{synonymsChange.Index}, synonymsBackupOperation(versions))
+		if err != nil {
+			status = metav1.ConditionFalse
+			reason = ConditionReasonBackupIndex
+			statusMessage = err.Error()
+			return ctrl.Result{RequeueAfter: RevisitIntervalSec * time.Second}, nil
+		}
+		if !done {
+			statusMessage = fmt.Sprintf("waiting for backup snapshot %s", index.Status.Backup.Snapshot)
+			return ctrl.Result{RequeueAfter: ProgressIntervalSec * time.Second}, nil
+		}
+	}
+	_, err = r.ES.CreateUpdateIndex(ctx, index, synonyms)
 
 	if err != nil {
 		status = metav1.ConditionFalse
 		statusMessage = fmt.Sprintf("can't %s ES index %s: %v", reason, index.Name, err)
+		if isUnsupportedFlavor(err) {
+			reason = ConditionReasonUnsupportedFlavor
+		}
 		return ctrl.Result{}, nil
 	}
+	if len(index.Spec.Synonyms) > 0 || len(index.Status.Synonyms) > 0 {
+		useSets, err := r.ES.SynonymsSetsSupported(ctx)
+		if err != nil {
+			status = metav1.ConditionFalse
+			statusMessage = fmt.Sprintf("can't check if synonyms sets are supported: %v", err)
+			return ctrl.Result{RequeueAfter: RevisitIntervalSec * time.Second}, nil
+		}
+		pending := []string{}
+		if synonymsChange != nil {
+			pending = synonymsChange.Pending
+		}
+		index.Status.Synonyms = synonymsStatus(index, versions, pending, useSets)
+		if len(pending) > 0 {
+			statusMessage = fmt.Sprintf("Succeeded, synonyms of filters %s are not updated, index %s must be closed "+
+				"for it and close_index is not set", strings.Join(pending, ","), synonymsChange.Index)
+		}
+	}
+
+	// Old generations of rollover index with backup are deleted only once they are backed up
+	if index.Spec.Rollover != nil && index.Spec.Backup != nil {
+		expired, err := r.ES.ExpiredRolloverIndices(ctx, index)
+		if err != nil {
+			status = metav1.ConditionFalse
+			statusMessage = fmt.Sprintf("can't get old generations of ES index %s: %v", index.Spec.Name, err)
+			return ctrl.Result{RequeueAfter: RevisitIntervalSec * time.Second}, nil
+		}
+		if len(expired) > 0 {
+			done, err := r.backup(ctx, index, expired, backupOperationRetention)
+			if err != nil {
+				status = metav1.ConditionFalse
+				reason = ConditionReasonBackupIndex
+				statusMessage = err.Error()
+				return ctrl.Result{RequeueAfter: RevisitIntervalSec * time.Second}, nil
+			}
+			if !done {
+				statusMessage = fmt.Sprintf("waiting for backup snapshot %s", index.Status.Backup.Snapshot)
+				return ctrl.Result{RequeueAfter: ProgressIntervalSec * time.Second}, nil
+			}
+			err = r.deleteIndices(ctx, expired)
+			if err != nil {
+				status = metav1.ConditionFalse
+				statusMessage = err.Error()
+				return ctrl.Result{RequeueAfter: RevisitIntervalSec * time.Second}, nil
+			}
+		}
+	}
+
+	// Rollover conditions are only checked on reconcile, so index is revisited once write index reaches max_age
+	nextRollover, err := r.ES.NextRollover(ctx, index)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't get next rollover of ES index %s: %v", index.Spec.Name, err)
+		return ctrl.Result{RequeueAfter: RevisitIntervalSec * time.Second}, nil
+	}
 
 	return ctrl.Result{
-		RequeueAfter: RevisitIntervalSec * time.Second,
+		RequeueAfter: rolloverRequeueAfter(nextRollover),
 	}, nil
 }
+
+// rolloverRequeueAfter would return time until next rollover if it is earlier than next revisit
+func rolloverRequeueAfter(nextRollover time.Time) time.Duration {
+	requeueAfter := RevisitIntervalSec * time.Second
+	if !nextRollover.IsZero() {
+		untilNext := time.Until(nextRollover)
+		if untilNext < ProgressIntervalSec*time.Second {
+			// Overdue rollover is checked again shortly, not in a busy loop
+			untilNext = ProgressIntervalSec * time.Second
+		}
+		if untilNext < requeueAfter {
+			requeueAfter = untilNext
+		}
+	}
+	return requeueAfter
+}
+
+// dropIndex would delete indices of object, backup snapshot of them is taken first if it is requested
+func (r *ElasticSearchIndexReconciler) dropIndex(ctx context.Context, index *xov1alpha1.ElasticSearchIndex) error {
+	indices, err := r.ES.ManagedIndices(ctx, index)
+	if err != nil {
+		return err
+	}
+	if len(indices) == 0 {
+		return r.ES.DeleteSynonymsSets(ctx, index)
+	}
+	if index.Spec.Backup != nil {
+		done, err := r.backup(ctx, index, indices, backupOperationDrop)
+		// Backup state must survive until next reconcile
+		statusErr := r.Status().Update(ctx, index)
+		if err != nil {
+			notify(ctx, r, r.Messenger, index, err.Error(), reporter.ErrorMessage)
+			return errors.Join(err, statusErr)
+		}
+		if statusErr != nil {
+			return fmt.Errorf("can't update index status: %w", statusErr)
+		}
+		if !done {
+			return errBackupPending
+		}
+	}
+	err = r.deleteIndices(ctx, indices)
+	if err != nil {
+		return err
+	}
+	// Synonyms sets can only be deleted once no index uses them
+	return r.ES.DeleteSynonymsSets(ctx, index)
+}
+
+// backup would take backup snapshot of indices and track it in status, true is returned once snapshot has succeeded.
+// New snapshot is taken if operation or indices have changed since last backup or last backup has failed.
+func (r *ElasticSearchIndexReconciler) backup(ctx context.Context, index *xov1alpha1.ElasticSearchIndex, indices []string,
+	operation string) (bool, error) {
+	state := index.Status.Backup
+	if state == nil || state.Phase == xov1alpha1.JobPhaseFailed || state.Operation != operation ||
+		!slices.Equal(state.Indices, indices) {
+		now := metav1.Now()
+		includeGlobalState := false
+		snapshot := &xov1alpha1.ElasticSearchSnapshot{
+			Spec: xov1alpha1.ElasticSearchSnapshotSpec{
+				Name:               fmt.Sprintf("%s-backup-%s", index.Spec.Name, now.UTC().Format("20060102-150405")),
+				Repository:         index.Spec.Backup.Repository,
+				Indices:            indices,
+				IncludeGlobalState: &includeGlobalState,
+			},
+		}
+		_, err := r.ES.CreateSnapshot(ctx, snapshot)
+		if err != nil {
+			return false, fmt.Errorf("can't take backup snapshot of %s, %s is not done: %w",
+				strings.Join(indices, ","), operation, err)
+		}
+		index.Status.Backup = &xov1alpha1.ESIndexBackupStatus{
+			Snapshot:  snapshot.Spec.Name,
+			Indices:   indices,
+			Operation: operation,
+			Phase:     xov1alpha1.JobPhaseRunning,
+			StartTime: &now,
+		}
+		return false, nil
+	}
+	if state.Phase == xov1alpha1.JobPhaseSucceeded {
+		return true, nil
+	}
+	snapshot, err := r.ES.GetSnapshot(ctx, index.Spec.Backup.Repository, state.Snapshot)
+	if err == nil && snapshot.State == elasticsearch.SnapshotStateInProgress {
+		return false, nil
+	}
+	state.CompletionTime = &metav1.Time{Time: time.Now()}
+	if err == nil && snapshot.State == elasticsearch.SnapshotStateSuccess {
+		state.Phase = xov1alpha1.JobPhaseSucceeded
+		return true, nil
+	}
+	// Snapshot which can't be tracked is failed as well, next backup takes new one
+	state.Phase = xov1alpha1.JobPhaseFailed
+	if err != nil {
+		return false, fmt.Errorf("backup snapshot %s failed, %s is not done: %w", state.Snapshot, operation, err)
+	}
+	return false, fmt.Errorf("backup snapshot %s finished in state %s, %s is not done",
+		state.Snapshot, snapshot.State, operation)
+}
+
+// deleteIndices would delete ES indices one by one
+func (r *ElasticSearchIndexReconciler) deleteIndices(ctx context.Context, indices []string) error {
+	for _, name := range indices {
+		err := r.ES.DeleteIndex(ctx, name)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexSynonyms would get synonym rules of index synonym filters from ConfigMaps with their versions
+func (r *ElasticSearchIndexReconciler) indexSynonyms(ctx context.Context, index *xov1alpha1.ElasticSearchIndex) (elasticsearch.Synonyms, map[string]string, error) {
+	synonyms := elasticsearch.Synonyms{}
+	versions := map[string]string{}
+	for _, filter := range index.Spec.Synonyms {
+		ref := filter.ConfigMapRef
+		optional := ref.Optional != nil && *ref.Optional
+		configMap := &corev1.ConfigMap{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: index.Namespace, Name: ref.Name}, configMap)
+		if err != nil && !(kerrors.IsNotFound(err) && optional) {
+			return nil, nil, fmt.Errorf("can't get configmap %s with synonyms of filter %s: %w", ref.Name, filter.Filter, err)
+		}
+		data, ok := configMap.Data[ref.Key]
+		if !ok && !optional {
+			return nil, nil, fmt.Errorf("configmap %s has no key %s with synonyms of filter %s", ref.Name, ref.Key, filter.Filter)
+		}
+		rules := parseSynonyms(data)
+		synonyms[filter.Filter] = rules
+		versions[filter.Filter] = synonymsVersion(rules)
+	}
+	return synonyms, versions, nil
+}
+
+// indicesOfConfigMap would map ConfigMap to indices which take synonyms from it
+func (r *ElasticSearchIndexReconciler) indicesOfConfigMap(ctx context.Context, configMap client.Object) []reconcile.Request {
+	indices := &xov1alpha1.ElasticSearchIndexList{}
+	err := r.List(ctx, indices, client.InNamespace(configMap.GetNamespace()),
+		client.MatchingFields{indexSynonymsConfigMapField: configMap.GetName()})
+	if err != nil {
+		log.FromContext(ctx).V(0).Info(fmt.Sprintf("can't list indices of configmap %s: %v", configMap.GetName(), err))
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(indices.Items))
+	for _, index := range indices.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: index.Namespace, Name: index.Name},
+		})
+	}
+	return requests
+}
+
+// parseSynonyms would split synonyms in Solr format into rules, empty lines and comments are skipped
+func parseSynonyms(data string) []string {
+	rules := []string{}
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rules = append(rules, line)
+	}
+	return rules
+}
+
+// synonymsVersion would return version of synonym rules, it is short hash of them
+func synonymsVersion(rules []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(rules, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// synonymsBackupOperation would make backup operation of synonyms update, every new version of synonyms
+// gets new backup snapshot
+func synonymsBackupOperation(versions map[string]string) string {
+	filters := make([]string, 0, len(versions))
+	for filter, version := range versions {
+		filters = append(filters, fmt.Sprintf("%s:%s", filter, version))
+	}
+	slices.Sort(filters)
+	return fmt.Sprintf("synonyms update %s", strings.Join(filters, ","))
+}
+
+// synonymsStatus would make status of index synonym filters, update time is kept while version doesn't change
+func synonymsStatus(index *xov1alpha1.ElasticSearchIndex, versions map[string]string, pending []string,
+	useSets bool) []xov1alpha1.ESIndexSynonymsStatus {
+	statuses := make([]xov1alpha1.ESIndexSynonymsStatus, 0, len(index.Spec.Synonyms))
+	now := metav1.Now()
+	for _, filter := range index.Spec.Synonyms {
+		status := xov1alpha1.ESIndexSynonymsStatus{
+			Filter:     filter.Filter,
+			Version:    versions[filter.Filter],
+			UpdateTime: &now,
+		}
+		if useSets {
+			status.SynonymsSet = elasticsearch.SynonymsSetName(index.Spec.Name, filter.Filter)
+		}
+		var prev *xov1alpha1.ESIndexSynonymsStatus
+		for i := range index.Status.Synonyms {
+			if index.Status.Synonyms[i].Filter == status.Filter {
+				prev = &index.Status.Synonyms[i]
+			}
+		}
+		// Pending synonyms are not pushed, filter still has previous version
+		if slices.Contains(pending, filter.Filter) {
+			status.PendingVersion = status.Version
+			status.Version = ""
+			status.UpdateTime = nil
+			if prev != nil {
+				status.Version = prev.Version
+				status.UpdateTime = prev.UpdateTime
+			}
+		} else if prev != nil && prev.Version == status.Version {
+			status.UpdateTime = prev.UpdateTime
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
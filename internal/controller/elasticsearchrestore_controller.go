@@ -0,0 +1,158 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/config"
+	"github.com/90poe/elasticsearch-objects-operator/internal/elasticsearch"
+	"github.com/90poe/elasticsearch-objects-operator/internal/reporter"
+)
+
+// ElasticSearchRestoreReconciler reconciles a ElasticSearchRestore object
+type ElasticSearchRestoreReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Messenger *reporter.Messenger
+	ES        elasticsearch.ES
+}
+
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchrestores,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchrestores/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchrestores/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ElasticSearchRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx).WithValues("elasticsearchrestore", req.NamespacedName)
+
+	// Fetch the ElasticSearchRestore instance
+	instance := &xov1alpha1.ElasticSearchRestore{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			reqLogger.V(0).Info("ElasticSearchRestore resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		reqLogger.V(0).Info(fmt.Sprintf("Failed to get ElasticSearchRestore: %v", err))
+		return reconcile.Result{}, err
+	}
+
+	// Finished restore is never touched again, same as finished Job
+	if isJobFinished(instance.Status.Phase) {
+		return reconcile.Result{}, nil
+	}
+
+	return r.runRestore(ctx, instance, reqLogger)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticSearchRestoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c := config.Get()
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&xov1alpha1.ElasticSearchRestore{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: c.MaxConcurrentReconciles}).
+		WithEventFilter(ignoreUpdateDeletePredicate()).
+		Complete(r)
+}
+
+// runRestore will start restore of snapshot in ES cluster and wait for recovery of restored indices
+func (r *ElasticSearchRestoreReconciler) runRestore(ctx context.Context, restore *xov1alpha1.ElasticSearchRestore, reqLogger logr.Logger) (_ ctrl.Result, retErr error) {
+	// Init status
+	statusMessage := "Succeeded"
+	status := metav1.ConditionTrue
+	condition := ConditionsInsert
+	reason := ConditionReasonRestoreSnapshot
+
+	// Defer function to update status
+	defer func() {
+		// Log status update
+		reqLogger.Info(fmt.Sprintf("elasticsearch %s %s status: %s", restore.Spec.Snapshot,
+			reason, statusMessage))
+		// Send message to slack, OK messages are only delivered when they resolve previous errors
+		msgType := reporter.MessageType(reporter.ErrorMessage)
+		notification := statusMessage
+		if status == metav1.ConditionTrue {
+			msgType = reporter.OKMessage
+			notification = fmt.Sprintf("elasticsearch %s %s status: %s", restore.Spec.Snapshot, reason, statusMessage)
+		}
+		notify(ctx, r, r.Messenger, restore, notification, msgType)
+		// Remove last condition and set new one
+		meta.RemoveStatusCondition(&restore.Status.Conditions, condition)
+		meta.SetStatusCondition(&restore.Status.Conditions, metav1.Condition{
+			Type:    condition,
+			Status:  status,
+			Reason:  reason,
+			Message: statusMessage,
+		})
+		// we will return error of status update if it is not nil
+		err := r.Status().Update(ctx, restore)
+		if err != nil {
+			reqLogger.V(0).Info(fmt.Sprintf("Failed to update restore status: %v", retErr))
+			retErr = errors.Join(retErr, err)
+		}
+	}()
+
+	// Start restore, restore which can't be started is failed
+	if len(restore.Status.Phase) == 0 {
+		indices, err := r.ES.RestoreSnapshot(ctx, restore)
+		if err != nil {
+			status = metav1.ConditionFalse
+			statusMessage = fmt.Sprintf("can't %s %s: %v", reason, restore.Spec.Snapshot, err)
+			restore.Status.Phase = xov1alpha1.JobPhaseFailed
+			restore.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+			return ctrl.Result{}, nil
+		}
+		restore.Status.Phase = xov1alpha1.JobPhaseRunning
+		restore.Status.StartTime = &metav1.Time{Time: time.Now()}
+		restore.Status.Indices = indices
+	}
+
+	// Track recovery of restored indices
+	progress, err := r.ES.GetRestoreProgress(ctx, restore.Status.Indices)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = err.Error()
+		return ctrl.Result{RequeueAfter: ProgressIntervalSec * time.Second}, nil
+	}
+	restore.Status.ShardsTotal = progress.ShardsTotal
+	restore.Status.ShardsDone = progress.ShardsDone
+	if !progress.Done {
+		statusMessage = fmt.Sprintf("Running, %d of %d primary shards recovered", progress.ShardsDone, progress.ShardsTotal)
+		return ctrl.Result{RequeueAfter: ProgressIntervalSec * time.Second}, nil
+	}
+	restore.Status.Phase = xov1alpha1.JobPhaseSucceeded
+	restore.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/config"
+	"github.com/90poe/elasticsearch-objects-operator/internal/elasticsearch"
+	"github.com/90poe/elasticsearch-objects-operator/internal/reporter"
+)
+
+// ElasticSearchSnapshotReconciler reconciles a ElasticSearchSnapshot object
+type ElasticSearchSnapshotReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Messenger *reporter.Messenger
+	ES        elasticsearch.ES
+}
+
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchsnapshots,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchsnapshots/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchsnapshots/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ElasticSearchSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx).WithValues("elasticsearchsnapshot", req.NamespacedName)
+
+	// Fetch the ElasticSearchSnapshot instance
+	instance := &xov1alpha1.ElasticSearchSnapshot{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			reqLogger.V(0).Info("ElasticSearchSnapshot resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		reqLogger.V(0).Info(fmt.Sprintf("Failed to get ElasticSearchSnapshot: %v", err))
+		return reconcile.Result{}, err
+	}
+
+	deleted, err := reconcileDropOnDelete(ctx, r.Client, instance, instance.Spec.DropOnDelete,
+		func(ctx context.Context) error {
+			return r.ES.DeleteSnapshot(ctx, instance.Spec.Repository, instance.Spec.Name)
+		})
+	if err != nil || deleted {
+		return reconcile.Result{}, err
+	}
+
+	// Finished snapshot is never touched again, same as finished Job
+	if isJobFinished(instance.Status.Phase) {
+		return reconcile.Result{}, nil
+	}
+
+	return r.runSnapshot(ctx, instance, reqLogger)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticSearchSnapshotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c := config.Get()
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&xov1alpha1.ElasticSearchSnapshot{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: c.MaxConcurrentReconciles}).
+		WithEventFilter(ignoreUpdateDeletePredicate()).
+		Complete(r)
+}
+
+// runSnapshot will start snapshot in ES cluster and track it until it is finished
+func (r *ElasticSearchSnapshotReconciler) runSnapshot(ctx context.Context, snapshot *xov1alpha1.ElasticSearchSnapshot, reqLogger logr.Logger) (_ ctrl.Result, retErr error) {
+	// Init status
+	statusMessage := "Succeeded"
+	status := metav1.ConditionTrue
+	condition := ConditionsInsert
+	reason := ConditionReasonCreateSnapshot
+
+	// Defer function to update status
+	defer func() {
+		// Log status update
+		reqLogger.Info(fmt.Sprintf("elasticsearch %s %s status: %s", snapshot.Spec.Name,
+			reason, statusMessage))
+		// Send message to slack, OK messages are only delivered when they resolve previous errors
+		msgType := reporter.MessageType(reporter.ErrorMessage)
+		notification := statusMessage
+		if status == metav1.ConditionTrue {
+			msgType = reporter.OKMessage
+			notification = fmt.Sprintf("elasticsearch %s %s status: %s", snapshot.Spec.Name, reason, statusMessage)
+		}
+		notify(ctx, r, r.Messenger, snapshot, notification, msgType)
+		// Remove last condition and set new one
+		meta.RemoveStatusCondition(&snapshot.Status.Conditions, condition)
+		meta.SetStatusCondition(&snapshot.Status.Conditions, metav1.Condition{
+			Type:    condition,
+			Status:  status,
+			Reason:  reason,
+			Message: statusMessage,
+		})
+		// we will return error of status update if it is not nil
+		err := r.Status().Update(ctx, snapshot)
+		if err != nil {
+			reqLogger.V(0).Info(fmt.Sprintf("Failed to update snapshot status: %v", retErr))
+			retErr = errors.Join(retErr, err)
+		}
+	}()
+
+	// Start snapshot, snapshot which can't be started is failed
+	if len(snapshot.Status.Phase) == 0 {
+		_, err := r.ES.CreateSnapshot(ctx, snapshot)
+		if err != nil {
+			status = metav1.ConditionFalse
+			statusMessage = fmt.Sprintf("can't %s %s: %v", reason, snapshot.Spec.Name, err)
+			snapshot.Status.Phase = xov1alpha1.JobPhaseFailed
+			snapshot.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+			return ctrl.Result{}, nil
+		}
+		snapshot.Status.Phase = xov1alpha1.JobPhaseRunning
+		snapshot.Status.StartTime = &metav1.Time{Time: time.Now()}
+	}
+
+	// Track snapshot progress
+	state, err := r.ES.GetSnapshot(ctx, snapshot.Spec.Repository, snapshot.Spec.Name)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = err.Error()
+		return ctrl.Result{RequeueAfter: ProgressIntervalSec * time.Second}, nil
+	}
+	snapshot.Status.State = state.State
+	snapshot.Status.Indices = state.Indices
+	snapshot.Status.ShardsTotal = state.ShardsTotal
+	snapshot.Status.ShardsDone = state.ShardsDone
+	snapshot.Status.ShardsFailed = state.ShardsFailed
+	snapshot.Status.Failures = state.Failures
+	if !state.StartTime.IsZero() {
+		snapshot.Status.StartTime = &metav1.Time{Time: state.StartTime}
+	}
+	if state.State == elasticsearch.SnapshotStateInProgress {
+		statusMessage = fmt.Sprintf("Running, %d of %d shards done", state.ShardsDone, state.ShardsTotal)
+		return ctrl.Result{RequeueAfter: ProgressIntervalSec * time.Second}, nil
+	}
+
+	snapshot.Status.CompletionTime = optionalTime(state.EndTime)
+	if snapshot.Status.CompletionTime == nil {
+		snapshot.Status.CompletionTime = &metav1.Time{Time: time.Now()}
+	}
+	// Partial snapshot is only fine if it is requested
+	if state.State == elasticsearch.SnapshotStateSuccess ||
+		(state.State == elasticsearch.SnapshotStatePartial && snapshot.Spec.Partial) {
+		snapshot.Status.Phase = xov1alpha1.JobPhaseSucceeded
+		return ctrl.Result{}, nil
+	}
+	snapshot.Status.Phase = xov1alpha1.JobPhaseFailed
+	status = metav1.ConditionFalse
+	statusMessage = fmt.Sprintf("snapshot %s finished in state %s", snapshot.Spec.Name, state.State)
+	if len(state.Failures) > 0 {
+		statusMessage = fmt.Sprintf("%s: %s", statusMessage, strings.Join(state.Failures, "; "))
+	}
+	return ctrl.Result{}, nil
+}
+
+// isJobFinished would check if one-shot operation is finished
+func isJobFinished(phase xov1alpha1.ESJobPhase) bool {
+	return phase == xov1alpha1.JobPhaseSucceeded || phase == xov1alpha1.JobPhaseFailed
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/config"
+	"github.com/90poe/elasticsearch-objects-operator/internal/elasticsearch"
+	"github.com/90poe/elasticsearch-objects-operator/internal/reporter"
+)
+
+// ElasticSearchSnapshotPolicyReconciler reconciles a ElasticSearchSnapshotPolicy object
+type ElasticSearchSnapshotPolicyReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Messenger *reporter.Messenger
+	ES        elasticsearch.ES
+}
+
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchsnapshotpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchsnapshotpolicies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchsnapshotpolicies/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ElasticSearchSnapshotPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx).WithValues("elasticsearchsnapshotpolicy", req.NamespacedName)
+
+	// Fetch the ElasticSearchSnapshotPolicy instance
+	instance := &xov1alpha1.ElasticSearchSnapshotPolicy{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			reqLogger.V(0).Info("ElasticSearchSnapshotPolicy resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		reqLogger.V(0).Info(fmt.Sprintf("Failed to get ElasticSearchSnapshotPolicy: %v", err))
+		return reconcile.Result{}, err
+	}
+
+	deleted, err := reconcileDropOnDelete(ctx, r.Client, instance, instance.Spec.DropOnDelete,
+		func(ctx context.Context) error {
+			return r.ES.DeleteSnapshotPolicy(ctx, instance.Spec.Name)
+		})
+	if err != nil || deleted {
+		return reconcile.Result{}, err
+	}
+
+	return r.upsertSnapshotPolicy(ctx, instance, reqLogger)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticSearchSnapshotPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c := config.Get()
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&xov1alpha1.ElasticSearchSnapshotPolicy{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: c.MaxConcurrentReconciles}).
+		WithEventFilter(predicate.Or(ignoreUpdateDeletePredicate(), annotationChangedPredicate(ExecuteAnnotation))).
+		Complete(r)
+}
+
+// upsertSnapshotPolicy will update or insert SLM policy in ES cluster, execute it if requested and report its state
+func (r *ElasticSearchSnapshotPolicyReconciler) upsertSnapshotPolicy(ctx context.Context, policy *xov1alpha1.ElasticSearchSnapshotPolicy, reqLogger logr.Logger) (_ ctrl.Result, retErr error) {
+	// Init status
+	statusMessage := "Succeeded"
+	status := metav1.ConditionTrue
+	condition := ConditionsInsert
+	reason := ConditionReasonCreateSnapshotPolicy
+
+	// Defer function to update status
+	defer func() {
+		// Log status update
+		reqLogger.Info(fmt.Sprintf("elasticsearch %s %s status: %s", policy.Spec.Name,
+			reason, statusMessage))
+		// Send message to slack, OK messages are only delivered when they resolve previous errors
+		msgType := reporter.MessageType(reporter.ErrorMessage)
+		notification := statusMessage
+		if status == metav1.ConditionTrue {
+			msgType = reporter.OKMessage
+			notification = fmt.Sprintf("elasticsearch %s %s status: %s", policy.Spec.Name, reason, statusMessage)
+		}
+		notify(ctx, r, r.Messenger, policy, notification, msgType)
+		// Remove last condition and set new one
+		meta.RemoveStatusCondition(&policy.Status.Conditions, condition)
+		meta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+			Type:    condition,
+			Status:  status,
+			Reason:  reason,
+			Message: statusMessage,
+		})
+		// we will return error of status update if it is not nil
+		err := r.Status().Update(ctx, policy)
+		if err != nil {
+			reqLogger.V(0).Info(fmt.Sprintf("Failed to update SLM policy status: %v", retErr))
+			retErr = errors.Join(retErr, err)
+		}
+	}()
+
+	// Check if policy exists in ES cluster
+	exists, err := r.ES.SnapshotPolicyExists(ctx, policy.Spec.Name)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't check if SLM policy %s exists: %v", policy.Spec.Name, err)
+		if isUnsupportedFlavor(err) {
+			reason = ConditionReasonUnsupportedFlavor
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Create or update policy
+	if exists {
+		condition = ConditionsUpdate
+		reason = ConditionReasonUpdateSnapshotPolicy
+	}
+	_, err = r.ES.CreateUpdateSnapshotPolicy(ctx, policy)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't %s %s: %v", reason, policy.Spec.Name, err)
+		if isUnsupportedFlavor(err) {
+			reason = ConditionReasonUnsupportedFlavor
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Snapshot on demand is taken once for every new value of annotation
+	execute := policy.GetAnnotations()[ExecuteAnnotation]
+	if len(execute) > 0 && execute != policy.Status.LastExecute {
+		reason = ConditionReasonExecuteSnapshotPolicy
+		snapshot, err := r.ES.ExecuteSnapshotPolicy(ctx, policy.Spec.Name)
+		if err != nil {
+			status = metav1.ConditionFalse
+			statusMessage = fmt.Sprintf("can't %s %s: %v", reason, policy.Spec.Name, err)
+			return ctrl.Result{}, nil
+		}
+		policy.Status.LastExecute = execute
+		statusMessage = fmt.Sprintf("Succeeded, snapshot %s is started", snapshot)
+	}
+
+	// Report policy executions
+	state, err := r.ES.GetSnapshotPolicyState(ctx, policy.Spec.Name)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = err.Error()
+		return ctrl.Result{}, nil
+	}
+	policy.Status.LastSuccess = optionalTime(state.LastSuccess)
+	policy.Status.LastSuccessSnapshot = state.LastSuccessSnapshot
+	policy.Status.LastFailure = optionalTime(state.LastFailure)
+	policy.Status.LastFailureSnapshot = state.LastFailureSnapshot
+	policy.Status.LastFailureDetails = state.LastFailureDetails
+	policy.Status.NextExecution = optionalTime(state.NextExecution)
+
+	// Status is refreshed shortly after next scheduled snapshot
+	requeueAfter := RevisitIntervalSec * time.Second
+	if !state.NextExecution.IsZero() {
+		untilNext := time.Until(state.NextExecution) + time.Minute
+		if untilNext > 0 && untilNext < requeueAfter {
+			requeueAfter = untilNext
+		}
+	}
+	return ctrl.Result{
+		RequeueAfter: requeueAfter,
+	}, nil
+}
+
+// optionalTime would convert zero time to nil, so it is omitted from status
+func optionalTime(t time.Time) *metav1.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &metav1.Time{Time: t}
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/config"
+	"github.com/90poe/elasticsearch-objects-operator/internal/elasticsearch"
+	"github.com/90poe/elasticsearch-objects-operator/internal/reporter"
+)
+
+// ElasticSearchSnapshotRepositoryReconciler reconciles a ElasticSearchSnapshotRepository object
+type ElasticSearchSnapshotRepositoryReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Messenger *reporter.Messenger
+	ES        elasticsearch.ES
+}
+
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchsnapshotrepositories,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchsnapshotrepositories/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchsnapshotrepositories/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ElasticSearchSnapshotRepositoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx).WithValues("elasticsearchsnapshotrepository", req.NamespacedName)
+
+	// Fetch the ElasticSearchSnapshotRepository instance
+	instance := &xov1alpha1.ElasticSearchSnapshotRepository{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			reqLogger.V(0).Info("ElasticSearchSnapshotRepository resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		reqLogger.V(0).Info(fmt.Sprintf("Failed to get ElasticSearchSnapshotRepository: %v", err))
+		return reconcile.Result{}, err
+	}
+
+	deleted, err := reconcileDropOnDelete(ctx, r.Client, instance, instance.Spec.DropOnDelete,
+		func(ctx context.Context) error {
+			return r.ES.DeleteSnapshotRepository(ctx, instance.Spec.Name)
+		})
+	if err != nil || deleted {
+		return reconcile.Result{}, err
+	}
+
+	return r.upsertSnapshotRepository(ctx, instance, reqLogger)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticSearchSnapshotRepositoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c := config.Get()
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&xov1alpha1.ElasticSearchSnapshotRepository{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: c.MaxConcurrentReconciles}).
+		WithEventFilter(ignoreUpdateDeletePredicate()).
+		Complete(r)
+}
+
+// upsertSnapshotRepository will update or insert snapshot repository in ES cluster and verify it
+func (r *ElasticSearchSnapshotRepositoryReconciler) upsertSnapshotRepository(ctx context.Context,
+	repository *xov1alpha1.ElasticSearchSnapshotRepository, reqLogger logr.Logger) (_ ctrl.Result, retErr error) {
+	// Init status
+	statusMessage := "Succeeded"
+	status := metav1.ConditionTrue
+	condition := ConditionsInsert
+	reason := ConditionReasonCreateSnapshotRepository
+
+	// Defer function to update status
+	defer func() {
+		// Log status update
+		reqLogger.Info(fmt.Sprintf("elasticsearch %s %s status: %s", repository.Spec.Name,
+			reason, statusMessage))
+		// Send message to slack, OK messages are only delivered when they resolve previous errors
+		msgType := reporter.MessageType(reporter.ErrorMessage)
+		notification := statusMessage
+		if status == metav1.ConditionTrue {
+			msgType = reporter.OKMessage
+			notification = fmt.Sprintf("elasticsearch %s %s status: %s", repository.Spec.Name, reason, statusMessage)
+		}
+		notify(ctx, r, r.Messenger, repository, notification, msgType)
+		// Remove last condition and set new one
+		meta.RemoveStatusCondition(&repository.Status.Conditions, condition)
+		meta.SetStatusCondition(&repository.Status.Conditions, metav1.Condition{
+			Type:    condition,
+			Status:  status,
+			Reason:  reason,
+			Message: statusMessage,
+		})
+		// we will return error of status update if it is not nil
+		err := r.Status().Update(ctx, repository)
+		if err != nil {
+			reqLogger.V(0).Info(fmt.Sprintf("Failed to update snapshot repository status: %v", retErr))
+			retErr = errors.Join(retErr, err)
+		}
+	}()
+
+	// Check if snapshot repository exists in ES cluster
+	exists, err := r.ES.SnapshotRepositoryExists(ctx, repository.Spec.Name)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't check if snapshot repository %s exists: %v", repository.Spec.Name, err)
+		return ctrl.Result{}, nil
+	}
+
+	// Create or update snapshot repository
+	if exists {
+		condition = ConditionsUpdate
+		reason = ConditionReasonUpdateSnapshotRepository
+	}
+	secretSettings, err := r.secretSettings(ctx, repository)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't %s %s: %v", reason, repository.Spec.Name, err)
+		return ctrl.Result{}, nil
+	}
+	_, err = r.ES.CreateUpdateSnapshotRepository(ctx, repository, secretSettings)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't %s %s: %v", reason, repository.Spec.Name, err)
+		return ctrl.Result{}, nil
+	}
+
+	// Verify snapshot repository on all nodes, result is kept as separate condition
+	verified := metav1.Condition{
+		Type:   ConditionsVerified,
+		Status: metav1.ConditionTrue,
+		Reason: ConditionReasonVerifySnapshotRepository,
+	}
+	nodes, err := r.ES.VerifySnapshotRepository(ctx, repository.Spec.Name)
+	if err != nil {
+		verified.Status = metav1.ConditionFalse
+		verified.Message = err.Error()
+		reqLogger.Info(fmt.Sprintf("elasticsearch %s %s status: %s", repository.Spec.Name,
+			ConditionReasonVerifySnapshotRepository, verified.Message))
+		notify(ctx, r, r.Messenger, repository, verified.Message, reporter.ErrorMessage)
+	} else {
+		verified.Message = fmt.Sprintf("verified on nodes: %s", strings.Join(nodes, ","))
+	}
+	meta.SetStatusCondition(&repository.Status.Conditions, verified)
+
+	return ctrl.Result{
+		RequeueAfter: RevisitIntervalSec * time.Second,
+	}, nil
+}
+
+// secretSettings would read repository settings from Secrets in namespace of object
+func (r *ElasticSearchSnapshotRepositoryReconciler) secretSettings(ctx context.Context,
+	repository *xov1alpha1.ElasticSearchSnapshotRepository) (map[string]string, error) {
+	settings := make(map[string]string, len(repository.Spec.SecretSettings))
+	for _, setting := range repository.Spec.SecretSettings {
+		ref := setting.SecretKeyRef
+		optional := ref.Optional != nil && *ref.Optional
+		secret := &corev1.Secret{}
+		err := r.Get(ctx, types.NamespacedName{Namespace: repository.Namespace, Name: ref.Name}, secret)
+		if err != nil {
+			if kerrors.IsNotFound(err) && optional {
+				continue
+			}
+			return nil, fmt.Errorf("can't get secret %s for setting %s: %w", ref.Name, setting.Name, err)
+		}
+		value, ok := secret.Data[ref.Key]
+		if !ok {
+			if optional {
+				continue
+			}
+			return nil, fmt.Errorf("secret %s has no key %s for setting %s", ref.Name, ref.Key, setting.Name)
+		}
+		settings[setting.Name] = string(value)
+	}
+	return settings, nil
+}
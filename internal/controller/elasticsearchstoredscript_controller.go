@@ -0,0 +1,187 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/config"
+	"github.com/90poe/elasticsearch-objects-operator/internal/elasticsearch"
+	"github.com/90poe/elasticsearch-objects-operator/internal/reporter"
+)
+
+// ElasticSearchStoredScriptReconciler reconciles a ElasticSearchStoredScript object
+type ElasticSearchStoredScriptReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Messenger *reporter.Messenger
+	ES        elasticsearch.ES
+}
+
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchstoredscripts,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchstoredscripts/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchstoredscripts/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ElasticSearchStoredScriptReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx).WithValues("elasticsearchstoredscript", req.NamespacedName)
+
+	// Fetch the ElasticSearchStoredScript instance
+	instance := &xov1alpha1.ElasticSearchStoredScript{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			reqLogger.V(0).Info("ElasticSearchStoredScript resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		reqLogger.V(0).Info(fmt.Sprintf("Failed to get ElasticSearchStoredScript: %v", err))
+		return reconcile.Result{}, err
+	}
+
+	// Only stored script created by operator is dropped
+	deleted, err := reconcileDropOnDelete(ctx, r.Client, instance, instance.Spec.DropOnDelete,
+		func(ctx context.Context) error {
+			if len(instance.Status.ID) == 0 {
+				return nil
+			}
+			return r.ES.DeleteStoredScript(ctx, instance.Status.ID)
+		})
+	if err != nil || deleted {
+		return reconcile.Result{}, err
+	}
+
+	return r.upsertStoredScript(ctx, instance, reqLogger)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticSearchStoredScriptReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c := config.Get()
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&xov1alpha1.ElasticSearchStoredScript{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: c.MaxConcurrentReconciles}).
+		WithEventFilter(ignoreUpdateDeletePredicate()).
+		Complete(r)
+}
+
+// upsertStoredScript will update or insert stored script in ES cluster
+func (r *ElasticSearchStoredScriptReconciler) upsertStoredScript(ctx context.Context, script *xov1alpha1.ElasticSearchStoredScript, reqLogger logr.Logger) (_ ctrl.Result, retErr error) {
+	// Init status
+	statusMessage := "Succeeded"
+	status := metav1.ConditionTrue
+	condition := ConditionsInsert
+	reason := ConditionReasonCreateStoredScript
+
+	// Defer function to update status
+	defer func() {
+		// Log status update
+		reqLogger.Info(fmt.Sprintf("elasticsearch %s %s status: %s", script.Spec.ID,
+			reason, statusMessage))
+		// Send message to slack, OK messages are only delivered when they resolve previous errors
+		msgType := reporter.MessageType(reporter.ErrorMessage)
+		notification := statusMessage
+		if status == metav1.ConditionTrue {
+			msgType = reporter.OKMessage
+			notification = fmt.Sprintf("elasticsearch %s %s status: %s", script.Spec.ID, reason, statusMessage)
+		}
+		notify(ctx, r, r.Messenger, script, notification, msgType)
+		// Remove last condition and set new one
+		meta.RemoveStatusCondition(&script.Status.Conditions, condition)
+		meta.SetStatusCondition(&script.Status.Conditions, metav1.Condition{
+			Type:    condition,
+			Status:  status,
+			Reason:  reason,
+			Message: statusMessage,
+		})
+		// we will return error of status update if it is not nil
+		err := r.Status().Update(ctx, script)
+		if err != nil {
+			reqLogger.V(0).Info(fmt.Sprintf("Failed to update stored script status: %v", retErr))
+			retErr = errors.Join(retErr, err)
+		}
+	}()
+
+	// Stored script with previous ID is released, with drop on delete it is dropped as K8S object no longer has it
+	if len(script.Status.ID) != 0 && script.Status.ID != script.Spec.ID {
+		if script.Spec.DropOnDelete {
+			err := r.ES.DeleteStoredScript(ctx, script.Status.ID)
+			if err != nil {
+				status = metav1.ConditionFalse
+				statusMessage = fmt.Sprintf("can't drop stored script %s with previous ID: %v", script.Status.ID, err)
+				return ctrl.Result{}, nil
+			}
+		}
+		script.Status.ID = ""
+	}
+
+	// Check if stored script exists in ES cluster
+	exists, err := r.ES.StoredScriptExists(ctx, script.Spec.ID)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't check if stored script %s exists: %v", script.Spec.ID, err)
+		return ctrl.Result{}, nil
+	}
+
+	// Create or update stored script
+	if exists {
+		condition = ConditionsUpdate
+		reason = ConditionReasonUpdateStoredScript
+	}
+	// Existing script is only changed if it is already managed by object, identical one is not adopted
+	owned := !exists || script.Status.ID == script.Spec.ID
+	// Stored script has no metadata, so it is marked as managed by object before it is created,
+	// created script can't be told from one created outside of operator later
+	if owned && script.Status.ID != script.Spec.ID {
+		script.Status.ID = script.Spec.ID
+		err = r.Status().Update(ctx, script)
+		if err != nil {
+			status = metav1.ConditionFalse
+			statusMessage = fmt.Sprintf("can't record stored script %s: %v", script.Spec.ID, err)
+			return ctrl.Result{}, err
+		}
+	}
+	_, err = r.ES.CreateUpdateStoredScript(ctx, script)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't %s %s: %v", reason, script.Spec.ID, err)
+		return ctrl.Result{}, nil
+	}
+	if !owned {
+		statusMessage = fmt.Sprintf("Succeeded, stored script %s is identical to spec and is not managed by this operator",
+			script.Spec.ID)
+	}
+
+	return ctrl.Result{
+		RequeueAfter: RevisitIntervalSec * time.Second,
+	}, nil
+}
@@ -43,8 +43,8 @@ import (
 type ElasticSearchTemplateReconciler struct {
 	client.Client
 	Scheme    *runtime.Scheme
-	es        elasticsearch.ES
-	messenger *reporter.Messenger
+	Messenger *reporter.Messenger
+	ES        elasticsearch.ES
 }
 
 //+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchtemplates,verbs=get;list;watch;create;update;patch;delete
@@ -65,7 +65,7 @@ func (r *ElasticSearchTemplateReconciler) Reconcile(ctx context.Context, req ctr
 
 	// Fetch the ElasticSearchTemplate instance
 	instance := &xov1alpha1.ElasticSearchTemplate{}
-	err := r.Get(context.TODO(), req.NamespacedName, instance)
+	err := r.Get(ctx, req.NamespacedName, instance)
 	if err != nil {
 		if kerrors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
@@ -85,19 +85,6 @@ func (r *ElasticSearchTemplateReconciler) Reconcile(ctx context.Context, req ctr
 // SetupWithManager sets up the controller with the Manager.
 func (r *ElasticSearchTemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	c := config.Get()
-	es, err := elasticsearch.New(
-		elasticsearch.URL(c.ESurl),
-	)
-	if err != nil {
-		return err
-	}
-	r.es = es
-	// Make slack messenger
-	r.messenger, err = reporter.New(c.SlackToken,
-		reporter.SlackChannel(c.SlackChannel))
-	if err != nil {
-		return err
-	}
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&xov1alpha1.ElasticSearchTemplate{}).
 		WithOptions(controller.Options{MaxConcurrentReconciles: c.MaxConcurrentReconciles}).
@@ -118,11 +105,14 @@ func (r *ElasticSearchTemplateReconciler) upsertTemplate(ctx context.Context, te
 		// Log status update
 		reqLogger.Info(fmt.Sprintf("elasticsearch %s %s status: %s", template.Spec.Name,
 			reason, statusMessage))
-		// Send message to slack
-		if status == metav1.ConditionFalse {
-			// send message only on error
-			r.messenger.Send(statusMessage, reporter.ErrorMessage)
+		// Send message to slack, OK messages are only delivered when they resolve previous errors
+		msgType := reporter.MessageType(reporter.ErrorMessage)
+		notification := statusMessage
+		if status == metav1.ConditionTrue {
+			msgType = reporter.OKMessage
+			notification = fmt.Sprintf("elasticsearch %s %s status: %s", template.Spec.Name, reason, statusMessage)
 		}
+		notify(ctx, r, r.Messenger, template, notification, msgType)
 		// Remove last condition and set new one
 		meta.RemoveStatusCondition(&template.Status.Conditions, condition)
 		meta.SetStatusCondition(&template.Status.Conditions, metav1.Condition{
@@ -140,7 +130,7 @@ func (r *ElasticSearchTemplateReconciler) upsertTemplate(ctx context.Context, te
 	}()
 
 	// Check if index exists in ES cluster
-	exists, err := r.es.TemplateExists(template.Spec.Name)
+	exists, err := r.ES.TemplateExists(ctx, template)
 	if err != nil {
 		status = metav1.ConditionFalse
 		statusMessage = fmt.Sprintf("can't check if template %s exists: %v", template.Spec.Name, err)
@@ -152,11 +142,14 @@ func (r *ElasticSearchTemplateReconciler) upsertTemplate(ctx context.Context, te
 		condition = ConditionsUpdate
 		reason = ConditionReasonUpdateIndex
 	}
-	_, err = r.es.CreateUpdateTemplate(template)
+	_, err = r.ES.CreateUpdateTemplate(ctx, template)
 
 	if err != nil {
 		status = metav1.ConditionFalse
 		statusMessage = fmt.Sprintf("can't %s ES template %s: %v", reason, template.Name, err)
+		if isUnsupportedFlavor(err) {
+			reason = ConditionReasonUnsupportedFlavor
+		}
 		return ctrl.Result{}, nil
 	}
 
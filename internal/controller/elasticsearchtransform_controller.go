@@ -0,0 +1,210 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/config"
+	"github.com/90poe/elasticsearch-objects-operator/internal/elasticsearch"
+	"github.com/90poe/elasticsearch-objects-operator/internal/reporter"
+)
+
+// ElasticSearchTransformReconciler reconciles a ElasticSearchTransform object
+type ElasticSearchTransformReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Messenger *reporter.Messenger
+	ES        elasticsearch.ES
+}
+
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchtransforms,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchtransforms/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchtransforms/finalizers,verbs=update
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ElasticSearchTransformReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx).WithValues("elasticsearchtransform", req.NamespacedName)
+
+	// Fetch the ElasticSearchTransform instance
+	instance := &xov1alpha1.ElasticSearchTransform{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			reqLogger.V(0).Info("ElasticSearchTransform resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		reqLogger.V(0).Info(fmt.Sprintf("Failed to get ElasticSearchTransform: %v", err))
+		return reconcile.Result{}, err
+	}
+
+	deleted, err := reconcileDropOnDelete(ctx, r.Client, instance, instance.Spec.DropOnDelete,
+		func(ctx context.Context) error {
+			return r.ES.DeleteTransform(ctx, instance.Spec.ID)
+		})
+	if err != nil || deleted {
+		return reconcile.Result{}, err
+	}
+
+	return r.upsertTransform(ctx, instance, reqLogger)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticSearchTransformReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c := config.Get()
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&xov1alpha1.ElasticSearchTransform{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: c.MaxConcurrentReconciles}).
+		WithEventFilter(ignoreUpdateDeletePredicate()).
+		Complete(r)
+}
+
+// upsertTransform will update or insert transform in ES cluster, start or stop it and report its health
+func (r *ElasticSearchTransformReconciler) upsertTransform(ctx context.Context, transform *xov1alpha1.ElasticSearchTransform, reqLogger logr.Logger) (_ ctrl.Result, retErr error) {
+	// Init status
+	statusMessage := "Succeeded"
+	status := metav1.ConditionTrue
+	condition := ConditionsInsert
+	reason := ConditionReasonCreateTransform
+
+	// Defer function to update status
+	defer func() {
+		// Log status update
+		reqLogger.Info(fmt.Sprintf("elasticsearch %s %s status: %s", transform.Spec.ID,
+			reason, statusMessage))
+		// Send message to slack, OK messages are only delivered when they resolve previous errors
+		msgType := reporter.MessageType(reporter.ErrorMessage)
+		notification := statusMessage
+		if status == metav1.ConditionTrue {
+			msgType = reporter.OKMessage
+			notification = fmt.Sprintf("elasticsearch %s %s status: %s", transform.Spec.ID, reason, statusMessage)
+		}
+		notify(ctx, r, r.Messenger, transform, notification, msgType)
+		// Remove last condition and set new one
+		meta.RemoveStatusCondition(&transform.Status.Conditions, condition)
+		meta.SetStatusCondition(&transform.Status.Conditions, metav1.Condition{
+			Type:    condition,
+			Status:  status,
+			Reason:  reason,
+			Message: statusMessage,
+		})
+		// we will return error of status update if it is not nil
+		err := r.Status().Update(ctx, transform)
+		if err != nil {
+			reqLogger.V(0).Info(fmt.Sprintf("Failed to update transform status: %v", retErr))
+			retErr = errors.Join(retErr, err)
+		}
+	}()
+
+	// Check if transform exists in ES cluster
+	exists, err := r.ES.TransformExists(ctx, transform.Spec.ID)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't check if transform %s exists: %v", transform.Spec.ID, err)
+		if isUnsupportedFlavor(err) {
+			reason = ConditionReasonUnsupportedFlavor
+		}
+		return ctrl.Result{}, nil
+	}
+
+	// Create, update or recreate transform
+	if exists {
+		condition = ConditionsUpdate
+		reason = ConditionReasonUpdateTransform
+	}
+	_, err = r.ES.CreateUpdateTransform(ctx, transform)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't %s %s: %v", reason, transform.Spec.ID, err)
+		if isUnsupportedFlavor(err) {
+			reason = ConditionReasonUnsupportedFlavor
+		}
+		return ctrl.Result{}, nil
+	}
+
+	stats, err := r.ES.GetTransformStats(ctx, transform.Spec.ID)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = err.Error()
+		return ctrl.Result{}, nil
+	}
+
+	// Continuous transform is restarted whenever it is stopped, batch transform is only started before first checkpoint
+	enabled := transform.Spec.Enabled == nil || *transform.Spec.Enabled
+	switch {
+	case enabled && stats.State == elasticsearch.TransformStateStopped &&
+		(transform.Spec.Sync != nil || stats.Checkpoint == 0):
+		reason = ConditionReasonStartTransform
+		err = r.ES.StartTransform(ctx, transform.Spec.ID)
+	case !enabled && stats.State != elasticsearch.TransformStateStopped &&
+		stats.State != elasticsearch.TransformStateStopping && stats.State != elasticsearch.TransformStateFailed:
+		reason = ConditionReasonStopTransform
+		err = r.ES.StopTransform(ctx, transform.Spec.ID)
+	}
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = err.Error()
+		return ctrl.Result{}, nil
+	}
+	if reason == ConditionReasonStartTransform || reason == ConditionReasonStopTransform {
+		stats, err = r.ES.GetTransformStats(ctx, transform.Spec.ID)
+		if err != nil {
+			status = metav1.ConditionFalse
+			statusMessage = err.Error()
+			return ctrl.Result{}, nil
+		}
+	}
+
+	// Report transform health
+	transform.Status.State = stats.State
+	transform.Status.Reason = stats.Reason
+	transform.Status.Health = stats.Health
+	transform.Status.HealthIssues = stats.HealthIssues
+	transform.Status.Checkpoint = stats.Checkpoint
+	transform.Status.CheckpointTime = optionalTime(stats.CheckpointTime)
+	transform.Status.OperationsBehind = stats.OperationsBehind
+	if stats.State == elasticsearch.TransformStateFailed {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("transform %s failed: %s", transform.Spec.ID, stats.Reason)
+	}
+
+	// Health of running transform is checked often, so stopped or failed transform is noticed
+	requeueAfter := RevisitIntervalSec * time.Second
+	if enabled {
+		requeueAfter = HealthIntervalSec * time.Second
+	}
+	return ctrl.Result{
+		RequeueAfter: requeueAfter,
+	}, nil
+}
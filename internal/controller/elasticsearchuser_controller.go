@@ -0,0 +1,310 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/config"
+	"github.com/90poe/elasticsearch-objects-operator/internal/elasticsearch"
+	"github.com/90poe/elasticsearch-objects-operator/internal/reporter"
+)
+
+const (
+	// userSecretSuffix is suffix of name of Secret with generated password of user
+	userSecretSuffix = "-elasticsearch-user"
+	// userPasswordLength is length of generated password
+	userPasswordLength = 32
+	// userPasswordChars are characters of generated password
+	userPasswordChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	// userPasswordSecretField is index of users by name of their password Secret
+	userPasswordSecretField = "spec.password_secret"
+)
+
+// ElasticSearchUserReconciler reconciles a ElasticSearchUser object
+type ElasticSearchUserReconciler struct {
+	client.Client
+	Scheme    *runtime.Scheme
+	Messenger *reporter.Messenger
+	ES        elasticsearch.ES
+}
+
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchusers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchusers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchusers/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ElasticSearchUserReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	reqLogger := log.FromContext(ctx).WithValues("elasticsearchuser", req.NamespacedName)
+
+	// Fetch the ElasticSearchUser instance
+	instance := &xov1alpha1.ElasticSearchUser{}
+	err := r.Get(ctx, req.NamespacedName, instance)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			reqLogger.V(0).Info("ElasticSearchUser resource not found. Ignoring since object must be deleted.")
+			return reconcile.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		reqLogger.V(0).Info(fmt.Sprintf("Failed to get ElasticSearchUser: %v", err))
+		return reconcile.Result{}, err
+	}
+
+	deleted, err := reconcileDropOnDelete(ctx, r.Client, instance, instance.Spec.DropOnDelete,
+		func(ctx context.Context) error {
+			return r.ES.DeleteUser(ctx, instance.Spec.Username)
+		})
+	if err != nil || deleted {
+		return reconcile.Result{}, err
+	}
+
+	return r.upsertUser(ctx, instance, reqLogger)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ElasticSearchUserReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	c := config.Get()
+	// Users are indexed by their password Secret, so Secret is mapped to users without listing them all
+	err := mgr.GetFieldIndexer().IndexField(context.Background(), &xov1alpha1.ElasticSearchUser{}, userPasswordSecretField,
+		func(obj client.Object) []string {
+			return []string{userPasswordSecretName(obj.(*xov1alpha1.ElasticSearchUser))}
+		})
+	if err != nil {
+		return fmt.Errorf("can't index users by password secret: %w", err)
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&xov1alpha1.ElasticSearchUser{}, builder.WithPredicates(ignoreUpdateDeletePredicate())).
+		// Password is changed every time it changes in its Secret, Secrets have no generation so they are not filtered
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.usersOfSecret)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: c.MaxConcurrentReconciles}).
+		Complete(r)
+}
+
+// upsertUser will update or insert user in ES cluster and set its password when password Secret changes
+func (r *ElasticSearchUserReconciler) upsertUser(ctx context.Context, user *xov1alpha1.ElasticSearchUser, reqLogger logr.Logger) (_ ctrl.Result, retErr error) {
+	// Init status
+	statusMessage := "Succeeded"
+	status := metav1.ConditionTrue
+	condition := ConditionsInsert
+	reason := ConditionReasonCreateUser
+
+	// Defer function to update status
+	defer func() {
+		// Log status update
+		reqLogger.Info(fmt.Sprintf("elasticsearch %s %s status: %s", user.Spec.Username,
+			reason, statusMessage))
+		// Send message to slack, OK messages are only delivered when they resolve previous errors
+		msgType := reporter.MessageType(reporter.ErrorMessage)
+		notification := statusMessage
+		if status == metav1.ConditionTrue {
+			msgType = reporter.OKMessage
+			notification = fmt.Sprintf("elasticsearch %s %s status: %s", user.Spec.Username, reason, statusMessage)
+		}
+		notify(ctx, r, r.Messenger, user, notification, msgType)
+		// Remove last condition and set new one
+		meta.RemoveStatusCondition(&user.Status.Conditions, condition)
+		meta.SetStatusCondition(&user.Status.Conditions, metav1.Condition{
+			Type:    condition,
+			Status:  status,
+			Reason:  reason,
+			Message: statusMessage,
+		})
+		// we will return error of status update if it is not nil
+		err := r.Status().Update(ctx, user)
+		if err != nil {
+			reqLogger.V(0).Info(fmt.Sprintf("Failed to update user status: %v", retErr))
+			retErr = errors.Join(retErr, err)
+		}
+	}()
+
+	// Check if user exists in ES cluster
+	exists, err := r.ES.UserExists(ctx, user.Spec.Username)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't check if user %s exists: %v", user.Spec.Username, err)
+		if isUnsupportedFlavor(err) {
+			reason = ConditionReasonUnsupportedFlavor
+		}
+		return ctrl.Result{}, nil
+	}
+	if exists {
+		condition = ConditionsUpdate
+		reason = ConditionReasonUpdateUser
+	}
+
+	secret, key, err := r.passwordSecret(ctx, user)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't %s %s: %v", reason, user.Spec.Username, err)
+		return ctrl.Result{}, nil
+	}
+	value, ok := secret.Data[key]
+	if !ok || len(value) == 0 {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't %s %s: secret %s has no key %s", reason, user.Spec.Username, secret.Name, key)
+		return ctrl.Result{}, nil
+	}
+	// Password is only sent to ES cluster when user is created or password in its Secret has changed
+	password := ""
+	version := passwordVersion(user, value)
+	rotate := !exists || user.Status.PasswordSecret != secret.Name || user.Status.PasswordVersion != version
+	if rotate {
+		password = string(value)
+	}
+
+	// Create or update user
+	_, err = r.ES.CreateUpdateUser(ctx, user, password)
+	if err != nil {
+		status = metav1.ConditionFalse
+		statusMessage = fmt.Sprintf("can't %s %s: %v", reason, user.Spec.Username, err)
+		if isUnsupportedFlavor(err) {
+			reason = ConditionReasonUnsupportedFlavor
+		}
+		return ctrl.Result{}, nil
+	}
+	if rotate {
+		user.Status.PasswordSecret = secret.Name
+		user.Status.PasswordVersion = version
+		statusMessage = fmt.Sprintf("Succeeded, password is set from secret %s", secret.Name)
+	}
+
+	return ctrl.Result{
+		RequeueAfter: RevisitIntervalSec * time.Second,
+	}, nil
+}
+
+// passwordSecret would get Secret with password of user and key of password in it.
+// Secret with generated password is created if user doesn't reference one.
+func (r *ElasticSearchUserReconciler) passwordSecret(ctx context.Context, user *xov1alpha1.ElasticSearchUser) (*corev1.Secret, string, error) {
+	secret := &corev1.Secret{}
+	if ref := user.Spec.PasswordSecretRef; ref != nil {
+		err := r.Get(ctx, types.NamespacedName{Namespace: user.Namespace, Name: ref.Name}, secret)
+		if err != nil {
+			return nil, "", fmt.Errorf("can't get password secret %s: %w", ref.Name, err)
+		}
+		return secret, ref.Key, nil
+	}
+	name := userPasswordSecretName(user)
+	err := r.Get(ctx, types.NamespacedName{Namespace: user.Namespace, Name: name}, secret)
+	if err == nil {
+		// Secret made outside of operator is never taken over, its password may be used elsewhere
+		if !metav1.IsControlledBy(secret, user) {
+			return nil, "", fmt.Errorf("secret %s is not owned by user object", name)
+		}
+		return secret, corev1.BasicAuthPasswordKey, nil
+	}
+	if !kerrors.IsNotFound(err) {
+		return nil, "", fmt.Errorf("can't get password secret %s: %w", name, err)
+	}
+	password, err := generatePassword()
+	if err != nil {
+		return nil, "", err
+	}
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: user.Namespace,
+		},
+		Type: corev1.SecretTypeBasicAuth,
+		Data: map[string][]byte{
+			corev1.BasicAuthUsernameKey: []byte(user.Spec.Username),
+			corev1.BasicAuthPasswordKey: []byte(password),
+		},
+	}
+	// Secret is garbage collected together with object
+	err = controllerutil.SetControllerReference(user, secret, r.Scheme)
+	if err != nil {
+		return nil, "", fmt.Errorf("can't set owner of password secret %s: %w", name, err)
+	}
+	err = r.Create(ctx, secret)
+	if err != nil {
+		return nil, "", fmt.Errorf("can't create password secret %s: %w", name, err)
+	}
+	return secret, corev1.BasicAuthPasswordKey, nil
+}
+
+// usersOfSecret would map Secret to users in its namespace which take password from it
+func (r *ElasticSearchUserReconciler) usersOfSecret(ctx context.Context, secret client.Object) []reconcile.Request {
+	users := &xov1alpha1.ElasticSearchUserList{}
+	err := r.List(ctx, users, client.InNamespace(secret.GetNamespace()),
+		client.MatchingFields{userPasswordSecretField: secret.GetName()})
+	if err != nil {
+		log.FromContext(ctx).V(0).Info(fmt.Sprintf("can't list users of secret %s: %v", secret.GetName(), err))
+		return nil
+	}
+	requests := make([]reconcile.Request, 0, len(users.Items))
+	for _, user := range users.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: user.Namespace, Name: user.Name},
+		})
+	}
+	return requests
+}
+
+// userPasswordSecretName would return name of Secret with password of user
+func userPasswordSecretName(user *xov1alpha1.ElasticSearchUser) string {
+	if user.Spec.PasswordSecretRef != nil {
+		return user.Spec.PasswordSecretRef.Name
+	}
+	return user.Name + userSecretSuffix
+}
+
+// passwordVersion would make hash of password salted with UID of user, so password can't be looked up by its hash
+func passwordVersion(user *xov1alpha1.ElasticSearchUser, password []byte) string {
+	sum := sha256.Sum256(append([]byte(user.UID), password...))
+	return hex.EncodeToString(sum[:8])
+}
+
+// generatePassword would generate random password of userPasswordLength characters
+func generatePassword() (string, error) {
+	password := make([]byte, userPasswordLength)
+	max := big.NewInt(int64(len(userPasswordChars)))
+	for i := range password {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("can't generate password: %w", err)
+		}
+		password[i] = userPasswordChars[n.Int64()]
+	}
+	return string(password), nil
+}
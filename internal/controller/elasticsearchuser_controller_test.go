@@ -0,0 +1,63 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+func TestPasswordSecretOwnership(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, xov1alpha1.AddToScheme(scheme))
+	user := &xov1alpha1.ElasticSearchUser{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "orders",
+			Namespace: "search",
+			UID:       "5d1e2c3b-8a7f-4e6d-9c0b-1a2b3c4d5e6f",
+		},
+		Spec: xov1alpha1.ElasticSearchUserSpec{
+			Username: "orders-service",
+		},
+	}
+	r := &ElasticSearchUserReconciler{Client: newTestClient(), Scheme: scheme}
+
+	// Password is generated once and kept in Secret owned by user
+	secret, key, err := r.passwordSecret(context.Background(), user)
+	require.NoError(t, err)
+	assert.True(t, metav1.IsControlledBy(secret, user))
+	password := string(secret.Data[key])
+	secret, key, err = r.passwordSecret(context.Background(), user)
+	require.NoError(t, err)
+	assert.Equal(t, password, string(secret.Data[key]))
+
+	// Secret with the same name made outside of operator is not used
+	r.Client = newTestClient(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders-elasticsearch-user", Namespace: "search"},
+		Data:       map[string][]byte{corev1.BasicAuthPasswordKey: []byte("shared-password")},
+	})
+	_, _, err = r.passwordSecret(context.Background(), user)
+	assert.EqualError(t, err, "secret orders-elasticsearch-user is not owned by user object")
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/reporter"
+)
+
+//+kubebuilder:rbac:groups=xo.90poe.io,resources=elasticsearchnotificationroutes,verbs=get;list;watch
+
+// notify would send message about object to notifier targets of all matching ElasticSearchNotificationRoute objects.
+// If no route matches - message is sent to default notifier target. Messenger deduplicates messages per object.
+func notify(ctx context.Context, c client.Reader, messenger *reporter.Messenger, obj client.Object,
+	msg string, msgType reporter.MessageType) {
+	destinations, err := notificationDestinations(ctx, c, obj, msgType)
+	if err != nil {
+		// We still want message to be delivered
+		log.FromContext(ctx).V(0).Info(fmt.Sprintf("can't resolve notification routes: %v", err))
+	}
+	object := fmt.Sprintf("%T/%s/%s", obj, obj.GetNamespace(), obj.GetName())
+	if len(destinations) == 0 {
+		messenger.SendTo("", object, msg, msgType)
+		return
+	}
+	for _, destination := range destinations {
+		messenger.SendTo(destination, object, msg, msgType)
+	}
+}
+
+// notificationDestinations would return unique slack channels of routes matching object and message type
+func notificationDestinations(ctx context.Context, c client.Reader, obj client.Object,
+	msgType reporter.MessageType) ([]string, error) {
+	routes := &xov1alpha1.ElasticSearchNotificationRouteList{}
+	err := c.List(ctx, routes)
+	if err != nil {
+		return nil, fmt.Errorf("can't list notification routes: %w", err)
+	}
+	destinations := []string{}
+	seen := map[string]bool{}
+	for i := range routes.Items {
+		route := &routes.Items[i]
+		matches, err := routeMatches(&route.Spec, obj, msgType)
+		if err != nil {
+			return nil, fmt.Errorf("invalid notification route %s: %w", route.Name, err)
+		}
+		if !matches || seen[route.Spec.SlackChannel] {
+			continue
+		}
+		seen[route.Spec.SlackChannel] = true
+		destinations = append(destinations, route.Spec.SlackChannel)
+	}
+	return destinations, nil
+}
+
+// routeMatches would check if route applies to object and message type
+func routeMatches(route *xov1alpha1.ElasticSearchNotificationRouteSpec, obj client.Object,
+	msgType reporter.MessageType) (bool, error) {
+	if len(route.Namespaces) != 0 && !contains(route.Namespaces, obj.GetNamespace()) {
+		return false, nil
+	}
+	if len(route.MessageTypes) != 0 {
+		found := false
+		for _, routeType := range route.MessageTypes {
+			if string(routeType) == msgType.String() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false, nil
+		}
+	}
+	if route.Selector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(route.Selector)
+	if err != nil {
+		return false, fmt.Errorf("can't parse label selector: %w", err)
+	}
+	return selector.Matches(labels.Set(obj.GetLabels())), nil
+}
+
+// contains would check if slice has value
+func contains(slice []string, value string) bool {
+	for _, item := range slice {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/reporter"
+)
+
+func TestRouteMatches(t *testing.T) {
+	index := &xov1alpha1.ElasticSearchIndex{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-index",
+			Namespace: "search",
+			Labels: map[string]string{
+				"team": "search",
+			},
+		},
+	}
+	tests := []struct {
+		name    string
+		route   xov1alpha1.ElasticSearchNotificationRouteSpec
+		msgType reporter.MessageType
+		matches bool
+	}{
+		{
+			name:    "empty route matches everything",
+			route:   xov1alpha1.ElasticSearchNotificationRouteSpec{SlackChannel: "all"},
+			msgType: reporter.ErrorMessage,
+			matches: true,
+		},
+		{
+			name: "namespace matches",
+			route: xov1alpha1.ElasticSearchNotificationRouteSpec{
+				Namespaces:   []string{"other", "search"},
+				SlackChannel: "search",
+			},
+			msgType: reporter.ErrorMessage,
+			matches: true,
+		},
+		{
+			name: "namespace doesn't match",
+			route: xov1alpha1.ElasticSearchNotificationRouteSpec{
+				Namespaces:   []string{"other"},
+				SlackChannel: "other",
+			},
+			msgType: reporter.ErrorMessage,
+			matches: false,
+		},
+		{
+			name: "message type doesn't match",
+			route: xov1alpha1.ElasticSearchNotificationRouteSpec{
+				MessageTypes: []xov1alpha1.NotificationMessageType{"Warning"},
+				SlackChannel: "warnings",
+			},
+			msgType: reporter.ErrorMessage,
+			matches: false,
+		},
+		{
+			name: "selector matches",
+			route: xov1alpha1.ElasticSearchNotificationRouteSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": "search"},
+				},
+				MessageTypes: []xov1alpha1.NotificationMessageType{"Error"},
+				SlackChannel: "search",
+			},
+			msgType: reporter.ErrorMessage,
+			matches: true,
+		},
+		{
+			name: "selector doesn't match",
+			route: xov1alpha1.ElasticSearchNotificationRouteSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"team": "ingest"},
+				},
+				SlackChannel: "ingest",
+			},
+			msgType: reporter.ErrorMessage,
+			matches: false,
+		},
+	}
+	for _, test := range tests {
+		matches, err := routeMatches(&test.route, index, test.msgType)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.matches, matches, test.name)
+	}
+}
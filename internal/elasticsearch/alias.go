@@ -0,0 +1,251 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+// AliasAction is one action of ES update aliases API
+type AliasAction struct {
+	Add    *AliasAdd    `json:"add,omitempty"`
+	Remove *AliasRemove `json:"remove,omitempty"`
+}
+
+// AliasAdd is action which adds alias to index or replaces it if alias already exists on index
+type AliasAdd struct {
+	Index         string      `json:"index"`
+	Alias         string      `json:"alias"`
+	Filter        interface{} `json:"filter,omitempty"`
+	IsWriteIndex  bool        `json:"is_write_index,omitempty"`
+	IndexRouting  string      `json:"index_routing,omitempty"`
+	SearchRouting string      `json:"search_routing,omitempty"`
+}
+
+// AliasRemove is action which removes alias from index
+type AliasRemove struct {
+	Index string `json:"index"`
+	Alias string `json:"alias"`
+}
+
+// aliasActions is body of ES update aliases API
+type aliasActions struct {
+	Actions []AliasAction `json:"actions"`
+}
+
+// aliasDetails is alias on one index as ES get alias API returns it
+type aliasDetails struct {
+	Filter        interface{} `json:"filter,omitempty"`
+	IsWriteIndex  *bool       `json:"is_write_index,omitempty"`
+	IndexRouting  string      `json:"index_routing,omitempty"`
+	SearchRouting string      `json:"search_routing,omitempty"`
+}
+
+// aliasGetResponse is answer of ES get alias API, keys are index names
+type aliasGetResponse map[string]struct {
+	Aliases map[string]aliasDetails `json:"aliases"`
+}
+
+func aliasPath(name string) string {
+	return fmt.Sprintf("/_alias/%s", url.PathEscape(name))
+}
+
+// AliasExists would check if alias exists
+func (c *Client) AliasExists(ctx context.Context, name string) (bool, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	exists, err := c.exists(ctx, aliasPath(name))
+	if err != nil {
+		return false, fmt.Errorf("can't check if alias exists: %w", err)
+	}
+	return exists, nil
+}
+
+// CreateUpdateAlias would point alias to indices from spec and remove it from all other indices.
+// All changes are applied with one atomic update aliases request. Alias which already exists is only
+// changed if it is managed by operator, which is the case when object status has indices of alias.
+func (c *Client) CreateUpdateAlias(ctx context.Context, object *xov1alpha1.ElasticSearchAlias) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	name := object.Spec.Name
+	desired, err := newAliasDetails(object)
+	if err != nil {
+		return "", err
+	}
+	servAliases, err := c.getServerAlias(ctx, name)
+	if err != nil && !errors.Is(err, errObjectNotFound) {
+		return "", fmt.Errorf("can't get alias: %w", err)
+	}
+	retMsg := "successfully created alias %s"
+	if len(servAliases) > 0 {
+		if len(object.Status.Indices) == 0 {
+			return "", fmt.Errorf("alias '%s' is not managed by this operator", name)
+		}
+		retMsg = "successfully updated alias %s"
+	}
+	actions := aliasActions{}
+	// Sorted indices make actions order stable
+	indices := make([]string, 0, len(desired))
+	for index := range desired {
+		indices = append(indices, index)
+	}
+	sort.Strings(indices)
+	for _, index := range indices {
+		details := desired[index]
+		servDetails, ok := servAliases[index]
+		if ok && reflect.DeepEqual(details, servDetails) {
+			continue
+		}
+		actions.Actions = append(actions.Actions, AliasAction{
+			Add: &AliasAdd{
+				Index:         index,
+				Alias:         name,
+				Filter:        details.Filter,
+				IsWriteIndex:  details.IsWriteIndex != nil && *details.IsWriteIndex,
+				IndexRouting:  details.IndexRouting,
+				SearchRouting: details.SearchRouting,
+			},
+		})
+	}
+	indices = indices[:0]
+	for index := range servAliases {
+		if _, ok := desired[index]; !ok {
+			indices = append(indices, index)
+		}
+	}
+	sort.Strings(indices)
+	for _, index := range indices {
+		actions.Actions = append(actions.Actions, AliasAction{
+			Remove: &AliasRemove{
+				Index: index,
+				Alias: name,
+			},
+		})
+	}
+	if len(actions.Actions) == 0 {
+		return fmt.Sprintf("no changes on alias named %s", name), nil
+	}
+	updateAliases := acknowledgedResponse{}
+	err = c.perform(ctx, http.MethodPost, "/_aliases", actions, &updateAliases)
+	if err != nil {
+		return "", fmt.Errorf("can't update aliases: %w", err)
+	}
+	if !updateAliases.Acknowledged {
+		return "", fmt.Errorf("can't acknowledge alias %s update", name)
+	}
+	return fmt.Sprintf(retMsg, name), nil
+}
+
+// DeleteAlias would remove alias from indices it was pointed to by operator with one atomic update aliases request.
+// Indices of alias are taken from object status, so alias is kept on indices it was added to outside of operator
+// and alias which is not managed by operator is not changed at all. Alias which doesn't exist is not an error.
+func (c *Client) DeleteAlias(ctx context.Context, object *xov1alpha1.ElasticSearchAlias) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	name := object.Spec.Name
+	if len(object.Status.Indices) == 0 {
+		return nil
+	}
+	servAliases, err := c.getServerAlias(ctx, name)
+	if errors.Is(err, errObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("can't get alias %s: %w", name, err)
+	}
+	actions := aliasActions{}
+	// Alias is only removed from indices it still points to, as removing missing alias fails whole request
+	for _, index := range object.Status.Indices {
+		if _, ok := servAliases[index]; !ok {
+			continue
+		}
+		actions.Actions = append(actions.Actions, AliasAction{
+			Remove: &AliasRemove{
+				Index: index,
+				Alias: name,
+			},
+		})
+	}
+	if len(actions.Actions) == 0 {
+		return nil
+	}
+	updateAliases := acknowledgedResponse{}
+	err = c.perform(ctx, http.MethodPost, "/_aliases", actions, &updateAliases)
+	if err != nil {
+		return fmt.Errorf("can't delete alias %s: %w", name, err)
+	}
+	if !updateAliases.Acknowledged {
+		return fmt.Errorf("can't acknowledge alias %s deletion", name)
+	}
+	return nil
+}
+
+// getServerAlias would get alias details on every index it points to
+func (c *Client) getServerAlias(ctx context.Context, name string) (map[string]aliasDetails, error) {
+	answer := aliasGetResponse{}
+	err := c.perform(ctx, http.MethodGet, aliasPath(name), nil, &answer)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errObjectNotFound
+		}
+		return nil, err
+	}
+	aliases := make(map[string]aliasDetails, len(answer))
+	for index, indexAliases := range answer {
+		details, ok := indexAliases.Aliases[name]
+		if !ok {
+			continue
+		}
+		if details.IsWriteIndex != nil && !*details.IsWriteIndex {
+			// Not write index is same as not set
+			details.IsWriteIndex = nil
+		}
+		aliases[index] = details
+	}
+	return aliases, nil
+}
+
+// newAliasDetails would make alias details on every index from spec the same way ES get alias API returns them
+func newAliasDetails(object *xov1alpha1.ElasticSearchAlias) (map[string]aliasDetails, error) {
+	aliases := make(map[string]aliasDetails, len(object.Spec.Indices))
+	writeIndex := ""
+	for _, index := range object.Spec.Indices {
+		if _, ok := aliases[index.Index]; ok {
+			return nil, fmt.Errorf("index %s is listed more than once in alias %s", index.Index, object.Spec.Name)
+		}
+		details := aliasDetails{
+			IndexRouting:  index.Routing,
+			SearchRouting: index.Routing,
+		}
+		if len(index.IndexRouting) > 0 {
+			details.IndexRouting = index.IndexRouting
+		}
+		if len(index.SearchRouting) > 0 {
+			details.SearchRouting = index.SearchRouting
+		}
+		if index.IsWriteIndex {
+			if len(writeIndex) > 0 {
+				return nil, fmt.Errorf("alias %s can't have more than one write index, got %s and %s",
+					object.Spec.Name, writeIndex, index.Index)
+			}
+			writeIndex = index.Index
+			isWriteIndex := true
+			details.IsWriteIndex = &isWriteIndex
+		}
+		if len(index.Filter) > 0 {
+			err := json.Unmarshal([]byte(index.Filter), &details.Filter)
+			if err != nil {
+				return nil, fmt.Errorf("can't unmarhsal Filter string: %w", err)
+			}
+		}
+		aliases[index.Index] = details
+	}
+	return aliases, nil
+}
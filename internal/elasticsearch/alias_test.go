@@ -0,0 +1,208 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+const (
+	// Used by Doer to mock ES answer on get alias request
+	AliasGETanswer = `{"logs-000001":{"aliases":{"logs":{"filter":{"term":{"user":"kimchy"}},"index_routing":"1","search_routing":"1"}}},"logs-000002":{"aliases":{"logs":{"is_write_index":true}}}}`
+)
+
+type TestAlias struct {
+	Alias *xov1alpha1.ElasticSearchAlias
+	R2R   []Responce2Req
+	Err   error
+	Msg   string
+}
+
+func newTestAlias(managed bool, indices ...xov1alpha1.ESAliasIndex) *xov1alpha1.ElasticSearchAlias {
+	alias := &xov1alpha1.ElasticSearchAlias{
+		Spec: xov1alpha1.ElasticSearchAliasSpec{
+			Name:    "logs",
+			Indices: indices,
+		},
+	}
+	if managed {
+		alias.Status.Indices = []string{"logs-000001", "logs-000002"}
+	}
+	return alias
+}
+
+func TestCreateUpdateAlias(t *testing.T) {
+	first := xov1alpha1.ESAliasIndex{
+		Index:   "logs-000001",
+		Filter:  `{"term":{"user":"kimchy"}}`,
+		Routing: "1",
+	}
+	second := xov1alpha1.ESAliasIndex{
+		Index:        "logs-000002",
+		IsWriteIndex: true,
+	}
+	tests := []TestAlias{
+		{
+			Alias: newTestAlias(false, first, second),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_alias/logs",
+					ResponceCode: 404,
+					Responce:     `{"error":"alias [logs] missing","status":404}`,
+				},
+				{
+					RequestURI:   "/_aliases",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+					RequestBody: `{"actions":[
+						{"add":{"index":"logs-000001","alias":"logs","filter":{"term":{"user":"kimchy"}},"index_routing":"1","search_routing":"1"}},
+						{"add":{"index":"logs-000002","alias":"logs","is_write_index":true}}]}`,
+				},
+			},
+			Msg: "successfully created alias logs",
+		},
+		{
+			Alias: newTestAlias(true, first, second),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_alias/logs",
+					ResponceCode: 200,
+					Responce:     AliasGETanswer,
+				},
+			},
+			Msg: "no changes on alias named logs",
+		},
+		{
+			// Write index is moved to new index and old one is removed in one request
+			Alias: newTestAlias(true, xov1alpha1.ESAliasIndex{
+				Index: "logs-000002",
+			}, xov1alpha1.ESAliasIndex{
+				Index:        "logs-000003",
+				IsWriteIndex: true,
+			}),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_alias/logs",
+					ResponceCode: 200,
+					Responce:     AliasGETanswer,
+				},
+				{
+					RequestURI:   "/_aliases",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+					RequestBody: `{"actions":[
+						{"add":{"index":"logs-000002","alias":"logs"}},
+						{"add":{"index":"logs-000003","alias":"logs","is_write_index":true}},
+						{"remove":{"index":"logs-000001","alias":"logs"}}]}`,
+				},
+			},
+			Msg: "successfully updated alias logs",
+		},
+		{
+			Alias: newTestAlias(false, first, second),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_alias/logs",
+					ResponceCode: 200,
+					Responce:     AliasGETanswer,
+				},
+			},
+			Err: fmt.Errorf("alias 'logs' is not managed by this operator"),
+		},
+		{
+			Alias: newTestAlias(true, xov1alpha1.ESAliasIndex{
+				Index:        "logs-000001",
+				IsWriteIndex: true,
+			}, second),
+			Err: fmt.Errorf("alias logs can't have more than one write index, got logs-000001 and logs-000002"),
+		},
+	}
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		for _, test := range tests {
+			for _, r2r := range test.R2R {
+				testDoer.R2rChan <- r2r
+			}
+			msg, err := client.CreateUpdateAlias(context.Background(), test.Alias)
+			if test.Err != nil {
+				assert.EqualError(t, err, fmt.Sprintf("%s", test.Err))
+				continue
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.Msg, msg)
+		}
+	})
+}
+
+func TestDeleteAlias(t *testing.T) {
+	tests := []TestAlias{
+		{
+			// Alias is only removed from indices operator pointed it to
+			Alias: &xov1alpha1.ElasticSearchAlias{
+				Spec:   xov1alpha1.ElasticSearchAliasSpec{Name: "logs"},
+				Status: xov1alpha1.ElasticSearchAliasStatus{Indices: []string{"logs-000002", "logs-000003"}},
+			},
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_alias/logs",
+					ResponceCode: 200,
+					Responce:     AliasGETanswer,
+				},
+				{
+					RequestURI:   "/_aliases",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+					RequestBody:  `{"actions":[{"remove":{"index":"logs-000002","alias":"logs"}}]}`,
+				},
+			},
+		},
+		{
+			// Already deleted alias is fine
+			Alias: newTestAlias(true),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_alias/logs",
+					ResponceCode: 404,
+					Responce:     `{"error":"alias [logs] missing","status":404}`,
+				},
+			},
+		},
+		{
+			// Alias which is not managed by operator is not touched
+			Alias: newTestAlias(false),
+		},
+		{
+			Alias: newTestAlias(true),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_alias/logs",
+					ResponceCode: 200,
+					Responce:     AliasGETanswer,
+				},
+				{
+					RequestURI:   "/_aliases",
+					ResponceCode: 500,
+					RequestBody: `{"actions":[{"remove":{"index":"logs-000001","alias":"logs"}},` +
+						`{"remove":{"index":"logs-000002","alias":"logs"}}]}`,
+				},
+			},
+			Err: fmt.Errorf("can't delete alias logs: elastic: Error 500 (Internal Server Error)"),
+		},
+	}
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		for _, test := range tests {
+			for _, r2r := range test.R2R {
+				testDoer.R2rChan <- r2r
+			}
+			err := client.DeleteAlias(context.Background(), test.Alias)
+			if test.Err != nil {
+				assert.EqualError(t, err, fmt.Sprintf("%s", test.Err))
+				continue
+			}
+			assert.NoError(t, err)
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/consts"
+)
+
+// APIKey is API key as ES creates it, it has credentials which can't be read later
+type APIKey struct {
+	ID         string
+	Name       string
+	APIKey     string
+	Encoded    string
+	Expiration time.Time
+}
+
+// APIKeyInfo is state of existing API key
+type APIKeyInfo struct {
+	ID          string
+	Name        string
+	Creation    time.Time
+	Expiration  time.Time
+	Invalidated bool
+}
+
+// apiKeyRequest is body of ES create API key API
+type apiKeyRequest struct {
+	Name            string                                       `json:"name"`
+	Expiration      string                                       `json:"expiration,omitempty"`
+	RoleDescriptors map[string]xov1alpha1.ESAPIKeyRoleDescriptor `json:"role_descriptors,omitempty"`
+	Metadata        map[string]interface{}                       `json:"metadata"`
+}
+
+// apiKeyResponse is answer of ES create API key API
+type apiKeyResponse struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Expiration int64  `json:"expiration"`
+	APIKey     string `json:"api_key"`
+	Encoded    string `json:"encoded"`
+}
+
+// apiKeyInfoResponse is answer of ES get API key API
+type apiKeyInfoResponse struct {
+	APIKeys []struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Creation    int64  `json:"creation"`
+		Expiration  int64  `json:"expiration"`
+		Invalidated bool   `json:"invalidated"`
+	} `json:"api_keys"`
+}
+
+const apiKeyPath = "/_security/api_key"
+
+// CreateAPIKey would create new API key, every call creates new key
+func (c *Client) CreateAPIKey(ctx context.Context, object *xov1alpha1.ElasticSearchAPIKey) (*APIKey, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "API key", FlavorElasticsearch)
+	if err != nil {
+		return nil, err
+	}
+	request := apiKeyRequest{
+		Name:            object.Spec.Name,
+		Expiration:      object.Spec.Expiration,
+		RoleDescriptors: object.Spec.RoleDescriptors,
+		// Metadata marks API key as managed by operator
+		Metadata: map[string]interface{}{
+			consts.ESManagedByField: consts.ESManagedByValue,
+		},
+	}
+	answer := apiKeyResponse{}
+	err = c.perform(ctx, http.MethodPost, apiKeyPath, request, &answer)
+	if err != nil {
+		return nil, fmt.Errorf("can't create API key %s: %w", object.Spec.Name, err)
+	}
+	key := &APIKey{
+		ID:      answer.ID,
+		Name:    answer.Name,
+		APIKey:  answer.APIKey,
+		Encoded: answer.Encoded,
+	}
+	if len(key.Encoded) == 0 {
+		// Older ES versions don't return encoded credentials
+		key.Encoded = base64.StdEncoding.EncodeToString([]byte(key.ID + ":" + key.APIKey))
+	}
+	if answer.Expiration > 0 {
+		key.Expiration = time.UnixMilli(answer.Expiration).UTC()
+	}
+	return key, nil
+}
+
+// GetAPIKey would get state of API key by its id, nil is returned if API key doesn't exist
+func (c *Client) GetAPIKey(ctx context.Context, id string) (*APIKeyInfo, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	answer := apiKeyInfoResponse{}
+	err := c.perform(ctx, http.MethodGet, apiKeyPath+"?id="+url.QueryEscape(id), nil, &answer)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("can't get API key %s: %w", id, err)
+	}
+	for _, key := range answer.APIKeys {
+		if key.ID != id {
+			continue
+		}
+		info := &APIKeyInfo{
+			ID:          key.ID,
+			Name:        key.Name,
+			Creation:    time.UnixMilli(key.Creation).UTC(),
+			Invalidated: key.Invalidated,
+		}
+		if key.Expiration > 0 {
+			info.Expiration = time.UnixMilli(key.Expiration).UTC()
+		}
+		return info, nil
+	}
+	return nil, nil
+}
+
+// InvalidateAPIKey would invalidate API key by its id, key which doesn't exist is not an error
+func (c *Client) InvalidateAPIKey(ctx context.Context, id string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	request := map[string]interface{}{
+		"ids": []string{id},
+	}
+	err := c.perform(ctx, http.MethodDelete, apiKeyPath, request, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("can't invalidate API key %s: %w", id, err)
+	}
+	return nil
+}
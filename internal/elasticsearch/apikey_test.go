@@ -0,0 +1,138 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+func newTestAPIKey() *xov1alpha1.ElasticSearchAPIKey {
+	return &xov1alpha1.ElasticSearchAPIKey{
+		Spec: xov1alpha1.ElasticSearchAPIKeySpec{
+			Name:       "orders-service",
+			Expiration: "30d",
+			RoleDescriptors: map[string]xov1alpha1.ESAPIKeyRoleDescriptor{
+				"orders-reader": {
+					Indices: []xov1alpha1.ESRoleIndexPrivileges{
+						{
+							Names:      []string{"orders-*"},
+							Privileges: []string{"read"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	tests := []requestTest[*xov1alpha1.ElasticSearchAPIKey, *APIKey]{
+		{
+			Object: newTestAPIKey(),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_security/api_key",
+					ResponceCode: 200,
+					Responce: `{"id":"VuaCfGcBCdbkQm-e5aOx","name":"orders-service","expiration":1544068612110,
+						"api_key":"ui2lp2axTNmsyakw9tvNnw","encoded":"VnVhQ2ZHY0JDZGJrUW0tZTVhT3g6dWkybHAyYXhUTm1zeWFrdzl0dk5udw=="}`,
+					RequestBody: `{"name":"orders-service","expiration":"30d",
+						"role_descriptors":{"orders-reader":{"indices":[{"names":["orders-*"],"privileges":["read"]}]}},
+						"metadata":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}`,
+				},
+			},
+			Want: &APIKey{
+				ID:         "VuaCfGcBCdbkQm-e5aOx",
+				Name:       "orders-service",
+				APIKey:     "ui2lp2axTNmsyakw9tvNnw",
+				Encoded:    "VnVhQ2ZHY0JDZGJrUW0tZTVhT3g6dWkybHAyYXhUTm1zeWFrdzl0dk5udw==",
+				Expiration: time.UnixMilli(1544068612110).UTC(),
+			},
+		},
+		{
+			// Encoded credentials are computed if ES doesn't return them
+			Object: newTestAPIKey(),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_security/api_key",
+					ResponceCode: 200,
+					Responce:     `{"id":"VuaCfGcBCdbkQm-e5aOx","name":"orders-service","api_key":"ui2lp2axTNmsyakw9tvNnw"}`,
+				},
+			},
+			Want: &APIKey{
+				ID:      "VuaCfGcBCdbkQm-e5aOx",
+				Name:    "orders-service",
+				APIKey:  "ui2lp2axTNmsyakw9tvNnw",
+				Encoded: "VnVhQ2ZHY0JDZGJrUW0tZTVhT3g6dWkybHAyYXhUTm1zeWFrdzl0dk5udw==",
+			},
+		},
+	}
+	runRequestTests(t, tests, (*Client).CreateAPIKey)
+}
+
+func TestGetAPIKey(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_security/api_key?id=VuaCfGcBCdbkQm-e5aOx",
+			ResponceCode: 200,
+			Responce: `{"api_keys":[{"id":"VuaCfGcBCdbkQm-e5aOx","name":"orders-service","creation":1544068612110,
+				"expiration":1546660612110,"invalidated":false}]}`,
+		}
+		key, err := client.GetAPIKey(context.Background(), "VuaCfGcBCdbkQm-e5aOx")
+		assert.NoError(t, err)
+		assert.Equal(t, &APIKeyInfo{
+			ID:         "VuaCfGcBCdbkQm-e5aOx",
+			Name:       "orders-service",
+			Creation:   time.UnixMilli(1544068612110).UTC(),
+			Expiration: time.UnixMilli(1546660612110).UTC(),
+		}, key)
+
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_security/api_key?id=VuaCfGcBCdbkQm-e5aOx",
+			ResponceCode: 200,
+			Responce: `{"api_keys":[{"id":"VuaCfGcBCdbkQm-e5aOx","name":"orders-service","creation":1544068612110,
+				"invalidated":true}]}`,
+		}
+		key, err = client.GetAPIKey(context.Background(), "VuaCfGcBCdbkQm-e5aOx")
+		assert.NoError(t, err)
+		assert.True(t, key.Invalidated)
+
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_security/api_key?id=VuaCfGcBCdbkQm-e5aOx",
+			ResponceCode: 404,
+			Responce:     `{}`,
+		}
+		key, err = client.GetAPIKey(context.Background(), "VuaCfGcBCdbkQm-e5aOx")
+		assert.NoError(t, err)
+		assert.Nil(t, key)
+	})
+}
+
+func TestInvalidateAPIKey(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_security/api_key",
+			ResponceCode: 200,
+			Responce:     `{"invalidated_api_keys":["VuaCfGcBCdbkQm-e5aOx"],"previously_invalidated_api_keys":[],"error_count":0}`,
+			RequestBody:  `{"ids":["VuaCfGcBCdbkQm-e5aOx"]}`,
+		}
+		assert.NoError(t, client.InvalidateAPIKey(context.Background(), "VuaCfGcBCdbkQm-e5aOx"))
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_security/api_key",
+			ResponceCode: 404,
+			Responce:     `{}`,
+		}
+		assert.NoError(t, client.InvalidateAPIKey(context.Background(), "VuaCfGcBCdbkQm-e5aOx"))
+	})
+}
+
+func TestAPIKeyOpenSearch(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		client.flavor = FlavorOpenSearch
+		_, err := client.CreateAPIKey(context.Background(), newTestAPIKey())
+		assert.EqualError(t, err, "API key is not supported by opensearch cluster")
+	})
+}
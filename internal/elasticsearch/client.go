@@ -1,18 +1,39 @@
 package elasticsearch
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"sync"
+	"time"
 
 	"github.com/olivere/elastic/v7"
 )
 
+const (
+	// DefaultTimeout is default timeout of single ES operation
+	DefaultTimeout = 30 * time.Second
+	// DefaultHealthcheckInterval is default interval of ES nodes health checks
+	DefaultHealthcheckInterval = 60 * time.Second
+)
+
 // Option is a type of options for Executor
 type Option func(*Client) error
 
 // Client is structure of ES client for XO
 type Client struct {
-	esURL string
-	es    *elastic.Client
+	esURL               string
+	driver              driver
+	timeout             time.Duration
+	retrier             *retrier
+	sniff               bool
+	healthcheckInterval time.Duration
+	// mu guards driver, flavor and version which are detected on first use
+	mu        sync.Mutex
+	flavor    Flavor
+	version   string
+	sigV4     *awsSigV4
+	transport http.RoundTripper
 }
 
 // URL is option function to set ES URL for Client
@@ -32,31 +53,171 @@ func ESclient(es *elastic.Client) Option {
 		if es == nil {
 			return fmt.Errorf("es cluster must not be nil")
 		}
-		c.es = es
+		c.driver = newV7Driver(es)
+		return nil
+	}
+}
+
+// Timeout is option function to set timeout of single ES operation
+func Timeout(timeout time.Duration) Option {
+	return func(c *Client) error {
+		if timeout <= 0 {
+			return fmt.Errorf("timeout for ES operations must be positive")
+		}
+		c.timeout = timeout
+		return nil
+	}
+}
+
+// Retries is option function to retry failed ES requests up to maxRetries times
+// with exponential backoff starting from initialBackoff and capped by maxBackoff
+func Retries(maxRetries int, initialBackoff, maxBackoff time.Duration) Option {
+	return func(c *Client) error {
+		if maxRetries < 0 {
+			return fmt.Errorf("number of ES retries can't be negative")
+		}
+		if initialBackoff <= 0 || maxBackoff < initialBackoff {
+			return fmt.Errorf("invalid ES retry backoff %v-%v", initialBackoff, maxBackoff)
+		}
+		c.retrier = newRetrier(maxRetries, initialBackoff, maxBackoff)
 		return nil
 	}
 }
 
-// New would create ES Client
+// Sniff is option function to enable discovery of ES cluster nodes
+func Sniff(enabled bool) Option {
+	return func(c *Client) error {
+		c.sniff = enabled
+		return nil
+	}
+}
+
+// HealthcheckInterval is option function to set interval of ES nodes health checks
+func HealthcheckInterval(interval time.Duration) Option {
+	return func(c *Client) error {
+		if interval <= 0 {
+			return fmt.Errorf("ES health check interval must be positive")
+		}
+		c.healthcheckInterval = interval
+		return nil
+	}
+}
+
+// New would create ES Client. Client doesn't connect to ES cluster until it is used,
+// so operator starts and reports not ready while ES cluster is unreachable.
+// Client is safe for concurrent use and must be shared between controllers.
 func New(options ...Option) (*Client, error) {
-	c := Client{}
-	var err error
+	c := Client{
+		timeout:             DefaultTimeout,
+		healthcheckInterval: DefaultHealthcheckInterval,
+	}
 	for _, option := range options {
-		err = option(&c)
+		err := option(&c)
 		if err != nil {
 			return nil, fmt.Errorf("can't make new ES Client: %w", err)
 		}
 	}
-	if c.es != nil {
-		return &c, nil
+	if c.sigV4 != nil && len(c.sigV4.service) == 0 {
+		return nil, fmt.Errorf("can't make new ES Client: AWS credentials are set, but AWS SigV4 signing is not enabled")
+	}
+	return &c, nil
+}
+
+// clusterDriver would return driver of ES cluster. Driver is made on first successful use
+// and selected by server major version, failed attempts are repeated on next use.
+// Lock isn't held while connecting, so unreachable cluster doesn't block other callers.
+func (c *Client) clusterDriver(ctx context.Context) (driver, error) {
+	c.mu.Lock()
+	d, transport := c.driver, c.transport
+	c.mu.Unlock()
+	if d != nil {
+		return d, nil
+	}
+	var err error
+	if transport == nil {
+		transport, err = c.newTransport(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("can't connect to ES cluster: %w", err)
+		}
+	}
+	d, info, err := c.dial(ctx, transport)
+	if err != nil {
+		return nil, fmt.Errorf("can't connect to ES cluster: %w", err)
 	}
-	// ES cluster client not provided - create one
-	c.es, err = elastic.NewClient(
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.driver != nil {
+		// Concurrent caller has connected first, healthchecks of v7 client are stopped
+		if v7, ok := d.(*v7Driver); ok {
+			v7.es.Stop()
+		}
+		return c.driver, nil
+	}
+	c.driver, c.transport = d, transport
+	if len(c.flavor) == 0 {
+		c.flavor = info.flavor()
+	}
+	c.version = info.Version.Number
+	return c.driver, nil
+}
+
+// dial would connect to ES cluster and make driver for its version
+func (c *Client) dial(ctx context.Context, transport http.RoundTripper) (driver, *clusterInfo, error) {
+	esOptions := []elastic.ClientOptionFunc{
 		elastic.SetURL(c.esURL),
-		elastic.SetSniff(false),
-	)
+		elastic.SetHttpClient(&http.Client{Transport: transport}),
+		elastic.SetSniff(c.sniff),
+		elastic.SetHealthcheck(true),
+		elastic.SetHealthcheckInterval(c.healthcheckInterval),
+	}
+	if c.retrier != nil {
+		esOptions = append(esOptions,
+			elastic.SetRetrier(c.retrier),
+			elastic.SetRetryStatusCodes(retryStatusCodes...),
+		)
+	}
+	es, err := elastic.DialContext(ctx, esOptions...)
 	if err != nil {
-		return nil, fmt.Errorf("can't make new ES Client: %w", err)
+		return nil, nil, err
 	}
-	return &c, nil
+	v7 := newV7Driver(es)
+	info, err := getClusterInfo(ctx, v7)
+	if err != nil {
+		es.Stop()
+		return nil, nil, err
+	}
+	if info.flavor() != FlavorElasticsearch || info.majorVersion() < 8 {
+		return v7, info, nil
+	}
+	es.Stop()
+	v8, err := newV8Driver(c.v8Config(transport), c.retrier)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v8, info, nil
+}
+
+// Ping would check if ES cluster is reachable
+func (c *Client) Ping(ctx context.Context) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	// Request goes through connection pool, so it fails when all ES nodes are marked dead
+	err := c.perform(ctx, http.MethodHead, "/", nil, nil)
+	if err != nil {
+		return fmt.Errorf("can't ping ES cluster: %w", err)
+	}
+	return nil
+}
+
+// ReadyzCheck is healthz.Checker which fails while ES cluster is unreachable
+func (c *Client) ReadyzCheck(req *http.Request) error {
+	return c.Ping(req.Context())
+}
+
+// withTimeout would limit context of one ES operation with operation timeout
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.timeout)
 }
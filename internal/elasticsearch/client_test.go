@@ -0,0 +1,223 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/olivere/elastic/v7"
+	"github.com/stretchr/testify/assert"
+)
+
+// BlockingDoer never answers until request context is done
+type BlockingDoer struct{}
+
+func (BlockingDoer) Do(req *http.Request) (*http.Response, error) {
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestOperationTimeout(t *testing.T) {
+	es, err := elastic.NewClient(
+		elastic.SetHttpClient(BlockingDoer{}),
+		elastic.SetSniff(false),
+		elastic.SetHealthcheck(false),
+	)
+	assert.NoError(t, err)
+	client, err := New(ESclient(es), Timeout(50*time.Millisecond))
+	assert.NoError(t, err)
+
+	start := time.Now()
+	_, err = client.IndexExists(context.Background(), "some_index")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 5*time.Second)
+
+	// Cancelled parent context cancels operation as well
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = client.DeleteIndex(ctx, "some_index")
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+// OKDoer answers every request with empty 200 response
+type OKDoer struct{}
+
+func (OKDoer) Do(req *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader("")),
+	}, nil
+}
+
+// FailingDoer always fails to connect
+type FailingDoer struct{}
+
+func (FailingDoer) Do(req *http.Request) (*http.Response, error) {
+	return nil, fmt.Errorf("connection refused")
+}
+
+func TestReadyzCheck(t *testing.T) {
+	es, err := elastic.NewClient(
+		elastic.SetHttpClient(OKDoer{}),
+		elastic.SetSniff(false),
+		elastic.SetHealthcheck(false),
+	)
+	assert.NoError(t, err)
+	client, err := New(ESclient(es))
+	assert.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	assert.NoError(t, client.ReadyzCheck(req))
+
+	es, err = elastic.NewClient(
+		elastic.SetHttpClient(FailingDoer{}),
+		elastic.SetSniff(false),
+		elastic.SetHealthcheck(false),
+	)
+	assert.NoError(t, err)
+	client, err = New(ESclient(es))
+	assert.NoError(t, err)
+	assert.Error(t, client.ReadyzCheck(req))
+}
+
+func TestRetrier(t *testing.T) {
+	r := newRetrier(3, 100*time.Millisecond, 300*time.Millisecond)
+	ctx := context.Background()
+	get := httptest.NewRequest(http.MethodGet, "/some_index", nil)
+	expected := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 300 * time.Millisecond}
+	for i, wait := range expected {
+		got, retry, err := r.Retry(ctx, i+1, get, nil, nil)
+		assert.NoError(t, err)
+		assert.True(t, retry)
+		assert.Equal(t, wait, got)
+	}
+	// Retries are exhausted
+	_, retry, _ := r.Retry(ctx, 4, get, nil, nil)
+	assert.False(t, retry)
+
+	// POST requests are not idempotent, e.g. API key creation, so they are not retried
+	post := httptest.NewRequest(http.MethodPost, "/_security/api_key", nil)
+	_, retry, _ = r.Retry(ctx, 1, post, nil, nil)
+	assert.False(t, retry)
+
+	// No retries when operation would time out before next attempt
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, retry, _ = r.Retry(ctx, 1, get, nil, nil)
+	assert.False(t, retry)
+
+	_, err := New(Retries(-1, time.Second, time.Second))
+	assert.Error(t, err)
+	_, err = New(Retries(1, time.Second, time.Millisecond))
+	assert.Error(t, err)
+}
+
+func TestDriverSelection(t *testing.T) {
+	tests := []struct {
+		Root   string
+		V8     bool
+		Flavor Flavor
+	}{
+		{
+			Root:   ElasticsearchRootGETanswer,
+			Flavor: FlavorElasticsearch,
+		},
+		{
+			Root:   OpenSearchRootGETanswer,
+			Flavor: FlavorOpenSearch,
+		},
+		{
+			Root:   `{"name":"es01","cluster_name":"docker-cluster","version":{"number":"8.12.2","build_flavor":"default"},"tagline":"You Know, for Search"}`,
+			V8:     true,
+			Flavor: FlavorElasticsearch,
+		},
+	}
+	for _, test := range tests {
+		accept := make(chan string, 10)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			accept <- r.Header.Get("Accept")
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+			_, _ = w.Write([]byte(test.Root))
+		}))
+		client, err := New(URL(server.URL))
+		assert.NoError(t, err)
+		// Driver is selected on first use
+		assert.Nil(t, client.driver)
+		flavor, err := client.Flavor(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, test.Flavor, flavor)
+		_, isV8 := client.driver.(*v8Driver)
+		assert.Equal(t, test.V8, isV8)
+		if test.V8 {
+			// Drain requests made by v7 client while server version was detected
+			for len(accept) > 0 {
+				<-accept
+			}
+			assert.NoError(t, client.Ping(context.Background()))
+			assert.Equal(t, compatibleWith8, <-accept)
+		}
+		server.Close()
+	}
+}
+
+func TestUnreachableCluster(t *testing.T) {
+	// Client is made while ES cluster is down, so operator starts and reports not ready
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(ElasticsearchRootGETanswer))
+	}))
+	client, err := New(URL("http://"+server.Listener.Addr().String()), Timeout(500*time.Millisecond))
+	assert.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	assert.Error(t, client.ReadyzCheck(req))
+	_, err = client.Flavor(context.Background())
+	assert.Error(t, err)
+
+	// Client connects once ES cluster is up
+	server.Start()
+	defer server.Close()
+	assert.NoError(t, client.ReadyzCheck(req))
+	flavor, err := client.Flavor(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, FlavorElasticsearch, flavor)
+}
+
+func TestV8DriverRetries(t *testing.T) {
+	attempts := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts[r.Method]++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	driver, err := newV8Driver(elasticsearch.Config{
+		Addresses:    []string{server.URL},
+		DisableRetry: true,
+	}, newRetrier(2, time.Millisecond, time.Millisecond))
+	assert.NoError(t, err)
+	_, err = driver.perform(context.Background(), http.MethodGet, "/_cluster/health", nil)
+	assert.Error(t, err)
+	_, err = driver.perform(context.Background(), http.MethodPost, "/_security/api_key", map[string]string{"name": "key"})
+	assert.Error(t, err)
+	assert.Equal(t, map[string]int{http.MethodGet: 3, http.MethodPost: 1}, attempts)
+}
+
+func TestV8DriverProductCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	driver, err := newV8Driver(elasticsearch.Config{Addresses: []string{server.URL}, DisableRetry: true}, nil)
+	assert.NoError(t, err)
+	// Official client refuses to talk to server which doesn't identify itself as Elasticsearch
+	_, err = driver.perform(context.Background(), http.MethodGet, "/", nil)
+	assert.ErrorContains(t, err, "not Elasticsearch")
+}
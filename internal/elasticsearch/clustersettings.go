@@ -0,0 +1,103 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+const clusterSettingsPath = "/_cluster/settings"
+
+// clusterSettings is answer of ES get cluster settings API with flat settings
+type clusterSettings struct {
+	Persistent map[string]interface{} `json:"persistent"`
+	Transient  map[string]interface{} `json:"transient"`
+}
+
+// UpdateClusterSettings would set persistent cluster settings declared in spec.
+// Settings which were applied before and are removed from spec are reset to their defaults, other settings are not touched.
+func (c *Client) UpdateClusterSettings(ctx context.Context, object *xov1alpha1.ElasticSearchClusterSettings) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	servSettings, err := c.getClusterSettings(ctx)
+	if err != nil {
+		return "", fmt.Errorf("can't get cluster settings: %w", err)
+	}
+	changes := map[string]interface{}{}
+	for key, value := range object.Spec.Persistent {
+		servValue, ok := servSettings.Persistent[key]
+		if !ok || clusterSettingString(servValue) != value {
+			changes[key] = value
+		}
+	}
+	for _, key := range object.Status.AppliedKeys {
+		if _, ok := object.Spec.Persistent[key]; ok {
+			continue
+		}
+		if _, ok := servSettings.Persistent[key]; ok {
+			// null resets setting to its default
+			changes[key] = nil
+		}
+	}
+	if len(changes) == 0 {
+		return "no changes on cluster settings", nil
+	}
+	err = c.putClusterSettings(ctx, changes)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("successfully updated %d cluster settings", len(changes)), nil
+}
+
+// ResetClusterSettings would reset persistent cluster settings to their defaults
+func (c *Client) ResetClusterSettings(ctx context.Context, keys []string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	if len(keys) == 0 {
+		return nil
+	}
+	changes := map[string]interface{}{}
+	for _, key := range keys {
+		changes[key] = nil
+	}
+	return c.putClusterSettings(ctx, changes)
+}
+
+func (c *Client) getClusterSettings(ctx context.Context) (*clusterSettings, error) {
+	settings := &clusterSettings{}
+	err := c.perform(ctx, http.MethodGet, clusterSettingsPath+"?flat_settings=true", nil, settings)
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (c *Client) putClusterSettings(ctx context.Context, changes map[string]interface{}) error {
+	request := map[string]interface{}{
+		"persistent": changes,
+	}
+	err := c.perform(ctx, http.MethodPut, clusterSettingsPath, request, nil)
+	if err != nil {
+		return fmt.Errorf("can't put cluster settings: %w", err)
+	}
+	return nil
+}
+
+// clusterSettingString would make string of setting value ES returns, lists are comma separated
+func clusterSettingString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, clusterSettingString(item))
+		}
+		return strings.Join(items, ",")
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,83 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+const (
+	// Used by Doer to mock ES answer on get cluster settings request
+	ClusterSettingsGETanswer = `{"persistent":{"search.max_buckets":"20000","action.auto_create_index":"false",
+		"cluster.routing.allocation.awareness.attributes":["zone","rack"],"indices.recovery.max_bytes_per_sec":"100mb"},"transient":{}}`
+)
+
+func TestUpdateClusterSettings(t *testing.T) {
+	tests := []requestTest[*xov1alpha1.ElasticSearchClusterSettings, string]{
+		{
+			Object: &xov1alpha1.ElasticSearchClusterSettings{
+				Spec: xov1alpha1.ElasticSearchClusterSettingsSpec{
+					Persistent: map[string]string{
+						"search.max_buckets":                              "20000",
+						"cluster.routing.allocation.awareness.attributes": "zone,rack",
+					},
+				},
+			},
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_cluster/settings?flat_settings=true",
+					ResponceCode: 200,
+					Responce:     ClusterSettingsGETanswer,
+				},
+			},
+			Want: "no changes on cluster settings",
+		},
+		{
+			// Changed setting is updated, removed one is reset and settings not applied by operator are not touched
+			Object: &xov1alpha1.ElasticSearchClusterSettings{
+				Spec: xov1alpha1.ElasticSearchClusterSettingsSpec{
+					Persistent: map[string]string{
+						"search.max_buckets":                             "30000",
+						"cluster.routing.allocation.disk.watermark.high": "90%",
+					},
+				},
+				Status: xov1alpha1.ElasticSearchClusterSettingsStatus{
+					AppliedKeys: []string{"action.auto_create_index", "search.max_buckets"},
+				},
+			},
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_cluster/settings?flat_settings=true",
+					ResponceCode: 200,
+					Responce:     ClusterSettingsGETanswer,
+				},
+				{
+					RequestURI:   "/_cluster/settings",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+					RequestBody: `{"persistent":{"search.max_buckets":"30000","cluster.routing.allocation.disk.watermark.high":"90%",
+						"action.auto_create_index":null}}`,
+				},
+			},
+			Want: "successfully updated 3 cluster settings",
+		},
+	}
+	runRequestTests(t, tests, (*Client).UpdateClusterSettings)
+}
+
+func TestResetClusterSettings(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_cluster/settings",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true}`,
+			RequestBody:  `{"persistent":{"search.max_buckets":null}}`,
+		}
+		assert.NoError(t, client.ResetClusterSettings(context.Background(), []string{"search.max_buckets"}))
+		// Nothing is sent if no settings were applied
+		assert.NoError(t, client.ResetClusterSettings(context.Background(), nil))
+	})
+}
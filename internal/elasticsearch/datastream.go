@@ -0,0 +1,205 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/consts"
+)
+
+// DataStream is state of ES data stream
+type DataStream struct {
+	Name       string `json:"name"`
+	Generation int64  `json:"generation"`
+	Indices    []struct {
+		IndexName string `json:"index_name"`
+	} `json:"indices"`
+	Template  string                 `json:"template"`
+	ILMPolicy string                 `json:"ilm_policy"`
+	Status    string                 `json:"status"`
+	Meta      map[string]interface{} `json:"_meta"`
+}
+
+// BackingIndices would return names of data stream backing indices, the last one is write index
+func (d *DataStream) BackingIndices() []string {
+	indices := make([]string, 0, len(d.Indices))
+	for _, index := range d.Indices {
+		indices = append(indices, index.IndexName)
+	}
+	return indices
+}
+
+// dataStreamsResponse is answer of ES get data stream API
+type dataStreamsResponse struct {
+	DataStreams []DataStream `json:"data_streams"`
+}
+
+func dataStreamPath(name string) string {
+	return fmt.Sprintf("/_data_stream/%s", url.PathEscape(name))
+}
+
+// DataStreamExists would check if data stream exists
+func (c *Client) DataStreamExists(ctx context.Context, name string) (bool, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	_, err := c.getDataStream(ctx, name)
+	if errors.Is(err, errObjectNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("can't check if data stream exists: %w", err)
+	}
+	return true, nil
+}
+
+// CreateDataStream would create data stream if it doesn't exist.
+// Data stream must match composable index template with data_stream enabled.
+func (c *Client) CreateDataStream(ctx context.Context, object *xov1alpha1.ElasticSearchDataStream) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	_, err := c.getDataStream(ctx, object.Spec.Name)
+	if err == nil {
+		return fmt.Sprintf("no changes on data stream named %s", object.Spec.Name), nil
+	}
+	if !errors.Is(err, errObjectNotFound) {
+		return "", fmt.Errorf("can't get data stream: %w", err)
+	}
+	err = c.checkDataStreamTemplate(ctx, object.Spec.Name)
+	if err != nil {
+		return "", err
+	}
+	createDataStream := acknowledgedResponse{}
+	err = c.perform(ctx, http.MethodPut, dataStreamPath(object.Spec.Name), nil, &createDataStream)
+	if err != nil {
+		return "", fmt.Errorf("can't create data stream: %w", err)
+	}
+	if !createDataStream.Acknowledged {
+		return "", fmt.Errorf("can't acknowledge data stream creation")
+	}
+	return fmt.Sprintf("successfully created data stream %s", object.Spec.Name), nil
+}
+
+// GetDataStream would get state of data stream
+func (c *Client) GetDataStream(ctx context.Context, name string) (*DataStream, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	dataStream, err := c.getDataStream(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("can't get data stream %s: %w", name, err)
+	}
+	return dataStream, nil
+}
+
+// RolloverDataStream would create new write index of data stream
+func (c *Client) RolloverDataStream(ctx context.Context, name string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	rollover := acknowledgedResponse{}
+	err := c.perform(ctx, http.MethodPost, fmt.Sprintf("/%s/_rollover", url.PathEscape(name)), nil, &rollover)
+	if err != nil {
+		return fmt.Errorf("can't rollover data stream %s: %w", name, err)
+	}
+	if !rollover.Acknowledged {
+		return fmt.Errorf("can't acknowledge data stream %s rollover", name)
+	}
+	return nil
+}
+
+// DeleteDataStream would delete data stream with all its backing indices,
+// data stream which doesn't exist or is not managed by operator is not deleted.
+// Data stream gets its metadata from index template it was created with.
+func (c *Client) DeleteDataStream(ctx context.Context, name string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	dataStream, err := c.getDataStream(ctx, name)
+	if errors.Is(err, errObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("can't get data stream %s: %w", name, err)
+	}
+	managedBy, _ := getStringValueFromSettings(dataStream.Meta, consts.ESManagedByField)
+	if managedBy != consts.ESManagedByValue {
+		return fmt.Errorf("data stream '%s' is %w", name, ErrNotManaged)
+	}
+	err = c.perform(ctx, http.MethodDelete, dataStreamPath(name), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("can't delete data stream %s: %w", name, err)
+	}
+	return nil
+}
+
+func (c *Client) getDataStream(ctx context.Context, name string) (*DataStream, error) {
+	dataStreams := dataStreamsResponse{}
+	err := c.perform(ctx, http.MethodGet, dataStreamPath(name), nil, &dataStreams)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errObjectNotFound
+		}
+		return nil, err
+	}
+	for i := range dataStreams.DataStreams {
+		if dataStreams.DataStreams[i].Name == name {
+			return &dataStreams.DataStreams[i], nil
+		}
+	}
+	return nil, errObjectNotFound
+}
+
+// checkDataStreamTemplate would check that composable index template with highest priority
+// matching data stream name has data_stream enabled, same as ES does on data stream creation
+func (c *Client) checkDataStreamTemplate(ctx context.Context, name string) error {
+	templates := composableTemplates{}
+	err := c.perform(ctx, http.MethodGet, "/_index_template", nil, &templates)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("can't get index templates: %w", err)
+	}
+	matched := ""
+	var priority int64
+	dataStream := false
+	for _, tmpl := range templates.IndexTemplates {
+		for _, pattern := range tmpl.IndexTemplate.IndexPatterns {
+			if !matchPattern(pattern, name) {
+				continue
+			}
+			if len(matched) == 0 || tmpl.IndexTemplate.Priority > priority {
+				matched = tmpl.Name
+				priority = tmpl.IndexTemplate.Priority
+				dataStream = tmpl.IndexTemplate.DataStream != nil
+			}
+			break
+		}
+	}
+	if len(matched) == 0 {
+		return fmt.Errorf("no composable index template matches data stream %s", name)
+	}
+	if !dataStream {
+		return fmt.Errorf("index template %s matching data stream %s doesn't have data_stream enabled", matched, name)
+	}
+	return nil
+}
+
+// matchPattern would match name with ES wildcard pattern, where only * is special
+func matchPattern(pattern, name string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return strings.HasSuffix(name, parts[len(parts)-1])
+}
@@ -0,0 +1,213 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+const (
+	// Used by Doer to mock ES answer on get data stream request
+	DataStreamGETanswer = `{"data_streams":[{"name":"logs-app-default","timestamp_field":{"name":"@timestamp"},"indices":[{"index_name":".ds-logs-app-default-2024.01.01-000001","index_uuid":"Fx1sF7gFQ4aRlMJmRTMa2w"},{"index_name":".ds-logs-app-default-2024.01.02-000002","index_uuid":"7gFQ4aRlMJmRTMa2wFx1sF"}],"generation":2,"status":"GREEN","template":"logs-app","ilm_policy":"logs","_meta":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"},"hidden":false,"system":false}]}`
+	// Used by Doer to mock ES answer on get all composable index templates request
+	IndexTemplatesGETanswer = `{"index_templates":[{"name":"logs","index_template":{"index_patterns":["logs-*"],"priority":100,"template":{},"data_stream":{"hidden":false}}},{"name":"logs-plain","index_template":{"index_patterns":["logs-plain-*"],"priority":200,"template":{}}},{"name":"other","index_template":{"index_patterns":["other"],"template":{},"data_stream":{}}}]}`
+)
+
+type TestDataStream struct {
+	Name string
+	R2R  []Responce2Req
+	Err  error
+	Msg  string
+}
+
+func TestCreateDataStream(t *testing.T) {
+	notFound := Responce2Req{
+		ResponceCode: 404,
+		Responce:     `{"error":{"type":"index_not_found_exception","reason":"no such index"},"status":404}`,
+	}
+	tests := []TestDataStream{
+		{
+			Name: "logs-app-default",
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_data_stream/logs-app-default",
+					ResponceCode: notFound.ResponceCode,
+					Responce:     notFound.Responce,
+				},
+				{
+					RequestURI:   "/_index_template",
+					ResponceCode: 200,
+					Responce:     IndexTemplatesGETanswer,
+				},
+				{
+					RequestURI:   "/_data_stream/logs-app-default",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+				},
+			},
+			Msg: "successfully created data stream logs-app-default",
+		},
+		{
+			Name: "logs-app-default",
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_data_stream/logs-app-default",
+					ResponceCode: 200,
+					Responce:     DataStreamGETanswer,
+				},
+			},
+			Msg: "no changes on data stream named logs-app-default",
+		},
+		{
+			// Template with higher priority has no data_stream
+			Name: "logs-plain-default",
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_data_stream/logs-plain-default",
+					ResponceCode: notFound.ResponceCode,
+					Responce:     notFound.Responce,
+				},
+				{
+					RequestURI:   "/_index_template",
+					ResponceCode: 200,
+					Responce:     IndexTemplatesGETanswer,
+				},
+			},
+			Err: fmt.Errorf("index template logs-plain matching data stream logs-plain-default doesn't have data_stream enabled"),
+		},
+		{
+			Name: "metrics-app",
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_data_stream/metrics-app",
+					ResponceCode: notFound.ResponceCode,
+					Responce:     notFound.Responce,
+				},
+				{
+					RequestURI:   "/_index_template",
+					ResponceCode: 200,
+					Responce:     IndexTemplatesGETanswer,
+				},
+			},
+			Err: fmt.Errorf("no composable index template matches data stream metrics-app"),
+		},
+	}
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		for _, test := range tests {
+			for _, r2r := range test.R2R {
+				testDoer.R2rChan <- r2r
+			}
+			msg, err := client.CreateDataStream(context.Background(), &xov1alpha1.ElasticSearchDataStream{
+				Spec: xov1alpha1.ElasticSearchDataStreamSpec{
+					Name: test.Name,
+				},
+			})
+			if test.Err != nil {
+				assert.EqualError(t, err, fmt.Sprintf("%s", test.Err))
+				continue
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.Msg, msg)
+		}
+	})
+}
+
+func TestGetDataStream(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_data_stream/logs-app-default",
+			ResponceCode: 200,
+			Responce:     DataStreamGETanswer,
+		}
+		dataStream, err := client.GetDataStream(context.Background(), "logs-app-default")
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, int64(2), dataStream.Generation)
+		assert.Equal(t, []string{
+			".ds-logs-app-default-2024.01.01-000001",
+			".ds-logs-app-default-2024.01.02-000002",
+		}, dataStream.BackingIndices())
+		assert.Equal(t, "logs-app", dataStream.Template)
+		assert.Equal(t, "logs", dataStream.ILMPolicy)
+		assert.Equal(t, "GREEN", dataStream.Status)
+
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_data_stream/logs-app-default",
+			ResponceCode: 404,
+		}
+		exists, err := client.DataStreamExists(context.Background(), "logs-app-default")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestRolloverDataStream(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/logs-app-default/_rollover",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true,"shards_acknowledged":true,"old_index":".ds-logs-app-default-2024.01.02-000002","new_index":".ds-logs-app-default-2024.01.03-000003","rolled_over":true,"dry_run":false,"conditions":{}}`,
+		}
+		assert.NoError(t, client.RolloverDataStream(context.Background(), "logs-app-default"))
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/logs-app-default/_rollover",
+			ResponceCode: 500,
+		}
+		assert.EqualError(t, client.RolloverDataStream(context.Background(), "logs-app-default"),
+			"can't rollover data stream logs-app-default: elastic: Error 500 (Internal Server Error)")
+	})
+}
+
+func TestDeleteDataStream(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_data_stream/logs-app-default",
+			ResponceCode: 200,
+			Responce:     DataStreamGETanswer,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_data_stream/logs-app-default",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true}`,
+		}
+		assert.NoError(t, client.DeleteDataStream(context.Background(), "logs-app-default"))
+		// Already deleted data stream is fine
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_data_stream/logs-app-default",
+			ResponceCode: 404,
+		}
+		assert.NoError(t, client.DeleteDataStream(context.Background(), "logs-app-default"))
+		// Data stream created from template outside of operator is kept
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_data_stream/logs-app-default",
+			ResponceCode: 200,
+			Responce:     `{"data_streams":[{"name":"logs-app-default","indices":[],"generation":1,"template":"logs-app"}]}`,
+		}
+		assert.ErrorIs(t, client.DeleteDataStream(context.Background(), "logs-app-default"), ErrNotManaged)
+	})
+}
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		match   bool
+	}{
+		{pattern: "logs-*", name: "logs-app", match: true},
+		{pattern: "logs-*", name: "metrics-app", match: false},
+		{pattern: "*-app-*", name: "logs-app-default", match: true},
+		{pattern: "logs-*-default", name: "logs-app-prod", match: false},
+		{pattern: "logs", name: "logs", match: true},
+		{pattern: "logs", name: "logs-app", match: false},
+		{pattern: "*", name: "anything", match: true},
+		{pattern: "a*a", name: "a", match: false},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.match, matchPattern(test.pattern, test.name), "%s ~ %s", test.pattern, test.name)
+	}
+}
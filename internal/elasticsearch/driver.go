@@ -0,0 +1,97 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// driver is low level transport to ES cluster of one major version
+type driver interface {
+	// perform would run request against ES cluster and return answer body.
+	// Answers with non 2xx status are returned as *Error.
+	perform(ctx context.Context, method, path string, body interface{}) ([]byte, error)
+}
+
+// Error is error answer of ES cluster, it is same for all drivers
+type Error struct {
+	Status  int           `json:"status"`
+	Details *ErrorDetails `json:"error,omitempty"`
+}
+
+// ErrorDetails holds details of ES error answer
+type ErrorDetails struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+}
+
+func (e *Error) Error() string {
+	if e.Details != nil && len(e.Details.Reason) != 0 {
+		return fmt.Sprintf("elastic: Error %d (%s): %s [type=%s]", e.Status, http.StatusText(e.Status),
+			e.Details.Reason, e.Details.Type)
+	}
+	return fmt.Sprintf("elastic: Error %d (%s)", e.Status, http.StatusText(e.Status))
+}
+
+// newError would make Error from ES answer status and body
+func newError(status int, body []byte) *Error {
+	esErr := &Error{}
+	err := json.Unmarshal(body, esErr)
+	if err != nil {
+		// Body is not ES error
+		return &Error{Status: status}
+	}
+	esErr.Status = status
+	return esErr
+}
+
+// isNotFound would check if error is ES answer with 404 status
+func isNotFound(err error) bool {
+	esErr := &Error{}
+	return errors.As(err, &esErr) && esErr.Status == http.StatusNotFound
+}
+
+// acknowledgedResponse is answer of ES APIs which change cluster state
+type acknowledgedResponse struct {
+	Acknowledged bool `json:"acknowledged"`
+}
+
+// isConflict would check if error is ES answer with 409 status, e.g. optimistic concurrency control failure
+func isConflict(err error) bool {
+	esErr := &Error{}
+	return errors.As(err, &esErr) && esErr.Status == http.StatusConflict
+}
+
+// perform would run raw request against ES cluster and unmarshal answer into result if it is not nil
+func (c *Client) perform(ctx context.Context, method, path string, body, result interface{}) error {
+	d, err := c.clusterDriver(ctx)
+	if err != nil {
+		return err
+	}
+	resp, err := d.perform(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	if result == nil || len(resp) == 0 {
+		return nil
+	}
+	err = json.Unmarshal(resp, result)
+	if err != nil {
+		return fmt.Errorf("can't unmarshal ES answer: %w", err)
+	}
+	return nil
+}
+
+// exists would check if object at path exists
+func (c *Client) exists(ctx context.Context, path string) (bool, error) {
+	err := c.perform(ctx, http.MethodHead, path, nil, nil)
+	if isNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,41 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+
+	"github.com/olivere/elastic/v7"
+)
+
+// v7Driver is driver for ES 7.x and OpenSearch clusters built on olivere client
+type v7Driver struct {
+	es *elastic.Client
+}
+
+func newV7Driver(es *elastic.Client) *v7Driver {
+	return &v7Driver{es: es}
+}
+
+func (d *v7Driver) perform(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	resp, err := d.es.PerformRequest(ctx, elastic.PerformRequestOptions{
+		Method: method,
+		Path:   path,
+		Body:   body,
+	})
+	if err != nil {
+		esErr := &elastic.Error{}
+		if errors.As(err, &esErr) {
+			// Drivers return same errors
+			ret := &Error{Status: esErr.Status}
+			if esErr.Details != nil {
+				ret.Details = &ErrorDetails{
+					Type:   esErr.Details.Type,
+					Reason: esErr.Details.Reason,
+				}
+			}
+			return nil, ret
+		}
+		return nil, err
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,115 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v8"
+)
+
+// compatibleWith8 is media type which makes ES 8.x answer in 8.x format
+const compatibleWith8 = "application/vnd.elasticsearch+json; compatible-with=8"
+
+// v8Driver is driver for ES 8.x clusters built on official go-elasticsearch client.
+// Requests are built from same paths and bodies as v7 driver uses, so API wrappers are shared between drivers.
+// Client checks that server is genuine Elasticsearch and traces requests.
+type v8Driver struct {
+	es *elasticsearch.Client
+	// retrier is same as v7 driver uses, client retries are disabled as they don't tell idempotent requests apart
+	retrier *retrier
+}
+
+func newV8Driver(cfg elasticsearch.Config, r *retrier) (*v8Driver, error) {
+	es, err := elasticsearch.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("can't make ES 8 client: %w", err)
+	}
+	return &v8Driver{es: es, retrier: r}, nil
+}
+
+// v8Config would make ES 8 client config from Client options
+func (c *Client) v8Config(transport http.RoundTripper) elasticsearch.Config {
+	cfg := elasticsearch.Config{
+		Addresses:    []string{c.esURL},
+		Transport:    transport,
+		DisableRetry: true,
+	}
+	if c.sniff {
+		cfg.DiscoverNodesInterval = c.healthcheckInterval
+	}
+	return cfg
+}
+
+func (d *v8Driver) perform(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
+	var bodyJSON []byte
+	if body != nil {
+		var err error
+		bodyJSON, err = json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("can't marshal ES request: %w", err)
+		}
+	}
+	for retry := 1; ; retry++ {
+		respBody, err := d.performOnce(ctx, method, path, bodyJSON)
+		if d.retrier == nil || !retriable(err) {
+			return respBody, err
+		}
+		req := &http.Request{Method: method}
+		wait, ok, _ := d.retrier.Retry(ctx, retry, req, nil, err)
+		if !ok {
+			return respBody, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(wait):
+		}
+	}
+}
+
+// retriable would check if request failed with error which may go away on retry
+func retriable(err error) bool {
+	if err == nil {
+		return false
+	}
+	esErr := &Error{}
+	if errors.As(err, &esErr) {
+		return slices.Contains(retryStatusCodes, esErr.Status)
+	}
+	return true
+}
+
+func (d *v8Driver) performOnce(ctx context.Context, method, path string, bodyJSON []byte) ([]byte, error) {
+	var reqBody io.Reader
+	if bodyJSON != nil {
+		reqBody = bytes.NewReader(bodyJSON)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, path, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("can't make ES request: %w", err)
+	}
+	req.Header.Set("Accept", compatibleWith8)
+	if bodyJSON != nil {
+		req.Header.Set("Content-Type", compatibleWith8)
+	}
+	resp, err := d.es.Perform(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("can't read ES answer: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, newError(resp.StatusCode, respBody)
+	}
+	return respBody, nil
+}
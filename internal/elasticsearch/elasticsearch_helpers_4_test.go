@@ -2,22 +2,33 @@ package elasticsearch
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"io"
 	"net/http"
+	"reflect"
 	"testing"
 
+	"github.com/elastic/go-elasticsearch/v8"
 	"github.com/olivere/elastic/v7"
+	"github.com/stretchr/testify/assert"
 )
 
 const (
 	// Used by Doer to mock ES clusters answer on "/_nodes/http" request
 	ClusterNodesGETanswer = `{"_nodes":{"total":1,"successful":1,"failed":0},"cluster_name":"docker-cluster","nodes":{"9PuzCdHhT6CdQMVEQeNYsg":{"name":"8d9407c2fa07","transport_address":"172.18.0.2:9300","host":"172.18.0.2","ip":"172.18.0.2","version":"7.5.2","build_flavor":"default","build_type":"docker","build_hash":"8bec50e1e0ad29dad5653712cf3bb580cd1afcdf","roles":["ingest","master","data","ml"],"attributes":{"ml.machine_memory":"2086154240","xpack.installed":"true","ml.max_open_jobs":"20"},"http":{"bound_address":["0.0.0.0:9200"],"publish_address":"172.18.0.2:9200","max_content_length_in_bytes":104857600}}}}`
+	// Used by Doer to mock ES cluster answer on "/" request
+	ElasticsearchRootGETanswer = `{"name":"8d9407c2fa07","cluster_name":"docker-cluster","version":{"number":"7.17.9","build_flavor":"default","build_type":"docker","lucene_version":"8.11.1"},"tagline":"You Know, for Search"}`
+	// Used by Doer to mock OpenSearch cluster answer on "/" request
+	OpenSearchRootGETanswer = `{"name":"opensearch-node1","cluster_name":"opensearch-cluster","version":{"distribution":"opensearch","number":"2.11.0","build_type":"tar","lucene_version":"9.7.0"},"tagline":"The OpenSearch Project: https://opensearch.org/"}`
 )
 
 type Responce2Req struct {
 	RequestURI   string
 	ResponceCode int
 	Responce     string
+	// RequestBody is checked if it is set, JSON bodies are compared ignoring formatting
+	RequestBody string
 }
 
 type TestDoer struct {
@@ -40,11 +51,14 @@ func (t *TestDoer) Do(req *http.Request) (*http.Response, error) {
 	resp.Header = make(http.Header, 2)
 	resp.Header.Add("content-type", "application/json")
 	resp.Header.Add("charset", "UTF-8")
+	// ES 8 client checks that server is genuine Elasticsearch
+	resp.Header.Add("X-Elastic-Product", "Elasticsearch")
 	var err error
 	switch req.Method {
 	case "HEAD":
 		resp.StatusCode = http.StatusOK
-	case "GET", "PUT", "DELETE":
+		resp.Body = io.NopCloser(bytes.NewBufferString(""))
+	case "GET", "PUT", "POST", "DELETE":
 		err = t.httpCall(req, resp)
 	}
 	return resp, err
@@ -57,6 +71,11 @@ func (t *TestDoer) httpCall(req *http.Request, resp *http.Response) error {
 		resp.StatusCode = http.StatusNotFound
 		return nil
 	}
+	if len(r2r.RequestBody) > 0 && !sameJSONBody(req, r2r.RequestBody) {
+		resp.StatusCode = http.StatusBadRequest
+		resp.Body = io.NopCloser(bytes.NewBufferString(""))
+		return nil
+	}
 	// FOR INTERNAL REVIEW PURPOSES
 	// if req.Method == "PUT" {
 	// 	bytes, err := os.ReadAll(req.Body)
@@ -71,6 +90,22 @@ func (t *TestDoer) httpCall(req *http.Request, resp *http.Response) error {
 	return nil
 }
 
+// sameJSONBody would check if request body is same JSON as expected one
+func sameJSONBody(req *http.Request, expected string) bool {
+	if req.Body == nil {
+		return false
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return false
+	}
+	var actualJSON, expectedJSON interface{}
+	if json.Unmarshal(body, &actualJSON) != nil || json.Unmarshal([]byte(expected), &expectedJSON) != nil {
+		return false
+	}
+	return reflect.DeepEqual(actualJSON, expectedJSON)
+}
+
 func setupCreateTestClient(t *testing.T) (*elastic.Client, *TestDoer) {
 	var err error
 	testCreateDoer := NewTestDoer(5)
@@ -87,3 +122,73 @@ func setupCreateTestClient(t *testing.T) (*elastic.Client, *TestDoer) {
 
 	return client, testCreateDoer
 }
+
+// DoerTransport makes http.RoundTripper of TestDoer for ES 8 transport
+type DoerTransport struct {
+	Doer *TestDoer
+}
+
+func (d DoerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return d.Doer.Do(req)
+}
+
+// forEachDriver would run test against Client with every driver on top of TestDoer
+func forEachDriver(t *testing.T, test func(t *testing.T, client *Client, testDoer *TestDoer)) {
+	t.Run("v7", func(t *testing.T) {
+		es, testDoer := setupCreateTestClient(t)
+		defer testDoer.Close()
+		client := &Client{
+			esURL:  "http://localhost:80",
+			driver: newV7Driver(es),
+			flavor: FlavorElasticsearch,
+		}
+		test(t, client, testDoer)
+	})
+	t.Run("v8", func(t *testing.T) {
+		testDoer := NewTestDoer(5)
+		defer testDoer.Close()
+		esURL := "http://localhost:80"
+		driver, err := newV8Driver(elasticsearch.Config{
+			Addresses:    []string{esURL},
+			Transport:    DoerTransport{Doer: testDoer},
+			DisableRetry: true,
+		}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client := &Client{
+			esURL:  esURL,
+			driver: driver,
+			flavor: FlavorElasticsearch,
+		}
+		test(t, client, testDoer)
+	})
+}
+
+// requestTest is case of table test of Client function: R2R answers are queued, function is called with Object
+// and must return Want, or error containing Err if it is set
+type requestTest[T, R any] struct {
+	Object T
+	R2R    []Responce2Req
+	Err    string
+	Want   R
+}
+
+// runRequestTests would run table test cases one after another with every driver
+func runRequestTests[T, R any](t *testing.T, tests []requestTest[T, R],
+	call func(client *Client, ctx context.Context, object T) (R, error)) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		for _, test := range tests {
+			for _, r2r := range test.R2R {
+				testDoer.R2rChan <- r2r
+			}
+			got, err := call(client, context.Background(), test.Object)
+			if len(test.Err) > 0 {
+				assert.ErrorContains(t, err, test.Err)
+				continue
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.Want, got)
+		}
+	})
+}
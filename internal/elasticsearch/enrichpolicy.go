@@ -0,0 +1,206 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+// EnrichPolicyPhaseFailed is phase of enrich policy execution which didn't create enrich index
+const EnrichPolicyPhaseFailed = "FAILED"
+
+// EnrichPolicy is configuration struct for ES enrich policy
+type EnrichPolicy struct {
+	Indices      []string    `json:"indices"`
+	MatchField   string      `json:"match_field"`
+	EnrichFields []string    `json:"enrich_fields"`
+	Query        interface{} `json:"query,omitempty"`
+}
+
+// EnrichPolicyExecution is state of execute enrich policy task
+type EnrichPolicyExecution struct {
+	Completed bool
+	Error     string
+}
+
+// enrichPolicyResponse is answer of ES get enrich policy API, config is keyed by policy type
+type enrichPolicyResponse struct {
+	Policies []struct {
+		Config map[string]*EnrichPolicy `json:"config"`
+	} `json:"policies"`
+}
+
+// taskResponse is answer of ES get task API for execute enrich policy task
+type taskResponse struct {
+	Completed bool `json:"completed"`
+	Error     *struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+	} `json:"error"`
+	Response *struct {
+		Status struct {
+			Phase string `json:"phase"`
+		} `json:"status"`
+	} `json:"response"`
+}
+
+func enrichPolicyPath(name string) string {
+	return fmt.Sprintf("/_enrich/policy/%s", url.PathEscape(name))
+}
+
+// EnrichPolicyExists would check if enrich policy exists
+func (c *Client) EnrichPolicyExists(ctx context.Context, name string) (bool, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "enrich policy", FlavorElasticsearch)
+	if err != nil {
+		return false, err
+	}
+	_, _, err = c.getEnrichPolicy(ctx, name)
+	if errors.Is(err, errObjectNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("can't check if enrich policy exists: %w", err)
+	}
+	return true, nil
+}
+
+// CreateUpdateEnrichPolicy would create enrich policy if it doesn't exist.
+// Enrich policies can't be updated, so policy which differs from spec is deleted and created again,
+// that fails while policy is used by ingest pipeline. Enrich policies have no metadata,
+// so policy is only recreated if status of object records it was created by operator.
+func (c *Client) CreateUpdateEnrichPolicy(ctx context.Context, object *xov1alpha1.ElasticSearchEnrichPolicy) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "enrich policy", FlavorElasticsearch)
+	if err != nil {
+		return "", err
+	}
+	policyType, policy, err := newEnrichPolicy(object)
+	if err != nil {
+		return "", err
+	}
+	retMsg := "successfully created enrich policy %s"
+	servType, servPolicy, err := c.getEnrichPolicy(ctx, object.Spec.Name)
+	if err != nil && !errors.Is(err, errObjectNotFound) {
+		return "", fmt.Errorf("can't get enrich policy: %w", err)
+	}
+	if servPolicy != nil {
+		if servType == policyType && reflect.DeepEqual(*servPolicy, *policy) {
+			return fmt.Sprintf("no changes on enrich policy named %s", object.Spec.Name), nil
+		}
+		if object.Status.Name != object.Spec.Name {
+			return "", fmt.Errorf("enrich policy '%s' is not managed by this operator", object.Spec.Name)
+		}
+		err = c.perform(ctx, http.MethodDelete, enrichPolicyPath(object.Spec.Name), nil, nil)
+		if err != nil {
+			return "", fmt.Errorf("can't delete enrich policy %s to recreate it: %w", object.Spec.Name, err)
+		}
+		retMsg = "successfully recreated enrich policy %s"
+	}
+	request := map[string]interface{}{
+		policyType: policy,
+	}
+	err = c.perform(ctx, http.MethodPut, enrichPolicyPath(object.Spec.Name), request, nil)
+	if err != nil {
+		return "", fmt.Errorf("can't put enrich policy: %w", err)
+	}
+	return fmt.Sprintf(retMsg, object.Spec.Name), nil
+}
+
+// ExecuteEnrichPolicy would start creating enrich index of policy and return id of task doing it
+func (c *Client) ExecuteEnrichPolicy(ctx context.Context, name string) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	answer := struct {
+		Task string `json:"task"`
+	}{}
+	err := c.perform(ctx, http.MethodPost, enrichPolicyPath(name)+"/_execute?wait_for_completion=false", nil, &answer)
+	if err != nil {
+		return "", fmt.Errorf("can't execute enrich policy %s: %w", name, err)
+	}
+	if len(answer.Task) == 0 {
+		return "", fmt.Errorf("execution of enrich policy %s is not accepted", name)
+	}
+	return answer.Task, nil
+}
+
+// GetEnrichPolicyExecution would get state of execute enrich policy task
+func (c *Client) GetEnrichPolicyExecution(ctx context.Context, task string) (*EnrichPolicyExecution, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	answer := taskResponse{}
+	err := c.perform(ctx, http.MethodGet, fmt.Sprintf("/_tasks/%s", url.PathEscape(task)), nil, &answer)
+	if err != nil {
+		return nil, fmt.Errorf("can't get enrich policy execution task %s: %w", task, err)
+	}
+	execution := &EnrichPolicyExecution{
+		Completed: answer.Completed,
+	}
+	switch {
+	case answer.Error != nil:
+		execution.Error = fmt.Sprintf("%s: %s", answer.Error.Type, answer.Error.Reason)
+	case answer.Response != nil && answer.Response.Status.Phase == EnrichPolicyPhaseFailed:
+		execution.Error = "enrich policy execution failed"
+	}
+	return execution, nil
+}
+
+// DeleteEnrichPolicy would delete enrich policy, policy which doesn't exist is not an error
+func (c *Client) DeleteEnrichPolicy(ctx context.Context, name string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.perform(ctx, http.MethodDelete, enrichPolicyPath(name), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("can't delete enrich policy %s: %w", name, err)
+	}
+	return nil
+}
+
+// getEnrichPolicy would return type and configuration of enrich policy
+func (c *Client) getEnrichPolicy(ctx context.Context, name string) (string, *EnrichPolicy, error) {
+	answer := enrichPolicyResponse{}
+	err := c.perform(ctx, http.MethodGet, enrichPolicyPath(name), nil, &answer)
+	if err != nil {
+		if isNotFound(err) {
+			return "", nil, errObjectNotFound
+		}
+		return "", nil, err
+	}
+	// Older versions answer with empty list instead of 404
+	for _, policy := range answer.Policies {
+		for policyType, config := range policy.Config {
+			if config != nil {
+				return policyType, config, nil
+			}
+		}
+	}
+	return "", nil, errObjectNotFound
+}
+
+// newEnrichPolicy would make enrich policy ES understands from K8S object
+func newEnrichPolicy(object *xov1alpha1.ElasticSearchEnrichPolicy) (string, *EnrichPolicy, error) {
+	policy := &EnrichPolicy{
+		Indices:      object.Spec.Indices,
+		MatchField:   object.Spec.MatchField,
+		EnrichFields: object.Spec.EnrichFields,
+	}
+	if len(object.Spec.Query) > 0 {
+		err := json.Unmarshal([]byte(object.Spec.Query), &policy.Query)
+		if err != nil {
+			return "", nil, fmt.Errorf("can't unmarhsal Query string: %w", err)
+		}
+	}
+	policyType := object.Spec.Type
+	if len(policyType) == 0 {
+		policyType = "match"
+	}
+	return policyType, policy, nil
+}
@@ -0,0 +1,186 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+const (
+	// Used by Doer to mock ES answer on get enrich policy request
+	EnrichPolicyGETanswer = `{"policies":[{"config":{"match":{"name":"users-policy","indices":["users"],
+		"match_field":"email","enrich_fields":["first_name","last_name"]}}}]}`
+	// Used by Doer to mock ES answer on enrich policy which doesn't exist
+	EnrichPolicyNotFoundAnswer = `{"policies":[]}`
+)
+
+func newTestEnrichPolicy(fields ...string) *xov1alpha1.ElasticSearchEnrichPolicy {
+	return &xov1alpha1.ElasticSearchEnrichPolicy{
+		Spec: xov1alpha1.ElasticSearchEnrichPolicySpec{
+			Name:         "users-policy",
+			Type:         "match",
+			Indices:      []string{"users"},
+			MatchField:   "email",
+			EnrichFields: fields,
+		},
+	}
+}
+
+func TestCreateUpdateEnrichPolicy(t *testing.T) {
+	managed := newTestEnrichPolicy("first_name", "last_name", "city")
+	managed.Status.Name = "users-policy"
+	withQuery := newTestEnrichPolicy("first_name", "last_name")
+	withQuery.Spec.Query = `{"term":{"active":true}}`
+	tests := []requestTest[*xov1alpha1.ElasticSearchEnrichPolicy, string]{
+		{
+			Object: withQuery,
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_enrich/policy/users-policy",
+					ResponceCode: 200,
+					Responce:     EnrichPolicyNotFoundAnswer,
+				},
+				{
+					RequestURI:   "/_enrich/policy/users-policy",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+					RequestBody: `{"match":{"indices":["users"],"match_field":"email","enrich_fields":["first_name","last_name"],` +
+						`"query":{"term":{"active":true}}}}`,
+				},
+			},
+			Want: "successfully created enrich policy users-policy",
+		},
+		{
+			Object: newTestEnrichPolicy("first_name", "last_name"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_enrich/policy/users-policy",
+					ResponceCode: 200,
+					Responce:     EnrichPolicyGETanswer,
+				},
+			},
+			Want: "no changes on enrich policy named users-policy",
+		},
+		{
+			// Policy created outside of operator is not recreated
+			Object: newTestEnrichPolicy("first_name", "last_name", "city"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_enrich/policy/users-policy",
+					ResponceCode: 200,
+					Responce:     EnrichPolicyGETanswer,
+				},
+			},
+			Err: "enrich policy 'users-policy' is not managed by this operator",
+		},
+		{
+			Object: managed,
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_enrich/policy/users-policy",
+					ResponceCode: 200,
+					Responce:     EnrichPolicyGETanswer,
+				},
+				{
+					RequestURI:   "/_enrich/policy/users-policy",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+				},
+				{
+					RequestURI:   "/_enrich/policy/users-policy",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+					RequestBody:  `{"match":{"indices":["users"],"match_field":"email","enrich_fields":["first_name","last_name","city"]}}`,
+				},
+			},
+			Want: "successfully recreated enrich policy users-policy",
+		},
+		{
+			// Policy used by ingest pipeline can't be deleted
+			Object: managed,
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_enrich/policy/users-policy",
+					ResponceCode: 200,
+					Responce:     EnrichPolicyGETanswer,
+				},
+				{
+					RequestURI:   "/_enrich/policy/users-policy",
+					ResponceCode: 400,
+					Responce: `{"error":{"type":"illegal_argument_exception",` +
+						`"reason":"Could not delete policy [users-policy] because a pipeline is referencing it [users-lookup]"},"status":400}`,
+				},
+			},
+			Err: "can't delete enrich policy users-policy to recreate it",
+		},
+	}
+	runRequestTests(t, tests, (*Client).CreateUpdateEnrichPolicy)
+}
+
+func TestExecuteEnrichPolicy(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_enrich/policy/users-policy/_execute?wait_for_completion=false",
+			ResponceCode: 200,
+			Responce:     `{"task":"oTUltX4IQMOUUVeiohTt8A:124"}`,
+		}
+		task, err := client.ExecuteEnrichPolicy(context.Background(), "users-policy")
+		assert.NoError(t, err)
+		assert.Equal(t, "oTUltX4IQMOUUVeiohTt8A:124", task)
+
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_tasks/oTUltX4IQMOUUVeiohTt8A:124",
+			ResponceCode: 200,
+			Responce:     `{"completed":false,"task":{"action":"cluster:admin/xpack/enrich/execute"}}`,
+		}
+		execution, err := client.GetEnrichPolicyExecution(context.Background(), task)
+		assert.NoError(t, err)
+		assert.Equal(t, &EnrichPolicyExecution{}, execution)
+
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_tasks/oTUltX4IQMOUUVeiohTt8A:124",
+			ResponceCode: 200,
+			Responce:     `{"completed":true,"response":{"status":{"phase":"COMPLETE"}}}`,
+		}
+		execution, err = client.GetEnrichPolicyExecution(context.Background(), task)
+		assert.NoError(t, err)
+		assert.Equal(t, &EnrichPolicyExecution{Completed: true}, execution)
+
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_tasks/oTUltX4IQMOUUVeiohTt8A:124",
+			ResponceCode: 200,
+			Responce:     `{"completed":true,"error":{"type":"index_not_found_exception","reason":"no such index [users]"}}`,
+		}
+		execution, err = client.GetEnrichPolicyExecution(context.Background(), task)
+		assert.NoError(t, err)
+		assert.Equal(t, &EnrichPolicyExecution{Completed: true, Error: "index_not_found_exception: no such index [users]"}, execution)
+	})
+}
+
+func TestDeleteEnrichPolicy(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_enrich/policy/users-policy",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true}`,
+		}
+		assert.NoError(t, client.DeleteEnrichPolicy(context.Background(), "users-policy"))
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_enrich/policy/users-policy",
+			ResponceCode: 404,
+			Responce:     `{"error":{"type":"resource_not_found_exception","reason":"policy [users-policy] not found"},"status":404}`,
+		}
+		assert.NoError(t, client.DeleteEnrichPolicy(context.Background(), "users-policy"))
+	})
+}
+
+func TestEnrichPolicyOpenSearch(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		client.flavor = FlavorOpenSearch
+		_, err := client.CreateUpdateEnrichPolicy(context.Background(), newTestEnrichPolicy("first_name"))
+		assert.EqualError(t, err, "enrich policy is not supported by opensearch cluster")
+	})
+}
@@ -2,8 +2,23 @@ package elasticsearch
 
 import (
 	"errors"
+	"fmt"
 )
 
 var (
 	errObjectNotFound = errors.New("es object not found")
+	// ErrConcurrentModification is returned when ES object was changed between read and write
+	ErrConcurrentModification = errors.New("object was changed concurrently")
+	// ErrNotManaged is returned when ES object to delete is not managed by this operator, object is left as is
+	ErrNotManaged = errors.New("not managed by this operator")
 )
+
+// UnsupportedFlavorError is returned when object uses feature which cluster flavor doesn't have
+type UnsupportedFlavorError struct {
+	Feature string
+	Flavor  Flavor
+}
+
+func (e *UnsupportedFlavorError) Error() string {
+	return fmt.Sprintf("%s is not supported by %s cluster", e.Feature, e.Flavor)
+}
@@ -0,0 +1,189 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+// Flavor is distribution of search cluster operator talks to
+type Flavor string
+
+const (
+	// FlavorElasticsearch is Elasticsearch cluster
+	FlavorElasticsearch Flavor = "elasticsearch"
+	// FlavorOpenSearch is OpenSearch cluster
+	FlavorOpenSearch Flavor = "opensearch"
+)
+
+// clusterInfo is answer of cluster root endpoint
+type clusterInfo struct {
+	Version struct {
+		Number       string `json:"number"`
+		Distribution string `json:"distribution"`
+	} `json:"version"`
+}
+
+// flavor would return flavor of cluster, only OpenSearch reports distribution
+func (i *clusterInfo) flavor() Flavor {
+	if strings.EqualFold(i.Version.Distribution, string(FlavorOpenSearch)) {
+		return FlavorOpenSearch
+	}
+	return FlavorElasticsearch
+}
+
+// majorVersion would return major version of cluster or 0 if version is unknown
+func (i *clusterInfo) majorVersion() int {
+	major, _ := parseVersion(i.Version.Number)
+	return major
+}
+
+// parseVersion would return major and minor version from version number, unknown parts are 0
+func parseVersion(number string) (int, int) {
+	majorStr, rest, _ := strings.Cut(number, ".")
+	minorStr, _, _ := strings.Cut(rest, ".")
+	major, err := strconv.Atoi(majorStr)
+	if err != nil {
+		return 0, 0
+	}
+	minor, err := strconv.Atoi(minorStr)
+	if err != nil {
+		return major, 0
+	}
+	return major, minor
+}
+
+// getClusterInfo would get cluster info from root endpoint with given driver
+func getClusterInfo(ctx context.Context, d driver) (*clusterInfo, error) {
+	resp, err := d.perform(ctx, http.MethodGet, "/", nil)
+	if err != nil {
+		return nil, fmt.Errorf("can't get cluster info: %w", err)
+	}
+	info := &clusterInfo{}
+	err = json.Unmarshal(resp, info)
+	if err != nil {
+		return nil, fmt.Errorf("can't get cluster info: %w", err)
+	}
+	return info, nil
+}
+
+// detectCluster would detect flavor and version of cluster unless they are known
+func (c *Client) detectCluster(ctx context.Context) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	d, err := c.clusterDriver(ctx)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	known := len(c.flavor) != 0 && len(c.version) != 0
+	c.mu.Unlock()
+	if known {
+		return nil
+	}
+	// Driver was set up without detection, e.g. with ESclient option
+	info, err := getClusterInfo(ctx, d)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.flavor) == 0 {
+		c.flavor = info.flavor()
+	}
+	c.version = info.Version.Number
+	return nil
+}
+
+// ParseFlavor would parse flavor name, empty name means flavor must be detected
+func ParseFlavor(name string) (Flavor, error) {
+	switch flavor := Flavor(strings.ToLower(name)); flavor {
+	case "", FlavorElasticsearch, FlavorOpenSearch:
+		return flavor, nil
+	default:
+		return "", fmt.Errorf("unknown cluster flavor '%s'", name)
+	}
+}
+
+// ClusterFlavor is option function to set cluster flavor instead of detecting it
+func ClusterFlavor(flavor Flavor) Option {
+	return func(c *Client) error {
+		parsed, err := ParseFlavor(string(flavor))
+		if err != nil {
+			return err
+		}
+		c.flavor = parsed
+		return nil
+	}
+}
+
+// Flavor would return flavor of cluster. It is detected from root endpoint once and cached.
+func (c *Client) Flavor(ctx context.Context) (Flavor, error) {
+	c.mu.Lock()
+	flavor := c.flavor
+	c.mu.Unlock()
+	if len(flavor) != 0 {
+		return flavor, nil
+	}
+	err := c.detectCluster(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.flavor, nil
+}
+
+// Version would return version number of cluster. It is detected from root endpoint once and cached.
+func (c *Client) Version(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	version := c.version
+	c.mu.Unlock()
+	if len(version) != 0 {
+		return version, nil
+	}
+	err := c.detectCluster(ctx)
+	if err != nil {
+		return "", err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.version, nil
+}
+
+// requireFlavor would return UnsupportedFlavorError if feature is used with cluster of other flavor
+func (c *Client) requireFlavor(ctx context.Context, feature string, flavor Flavor) error {
+	current, err := c.Flavor(ctx)
+	if err != nil {
+		return err
+	}
+	if current != flavor {
+		return &UnsupportedFlavorError{
+			Feature: feature,
+			Flavor:  current,
+		}
+	}
+	return nil
+}
+
+// checkSettingsFlavor would reject index settings which cluster flavor doesn't support
+func (c *Client) checkSettingsFlavor(ctx context.Context, settings *xov1alpha1.ESIndexSettings) error {
+	if settings.Lifecycle != nil {
+		err := c.requireFlavor(ctx, "ILM lifecycle setting", FlavorElasticsearch)
+		if err != nil {
+			return err
+		}
+	}
+	if settings.Plugins != nil {
+		err := c.requireFlavor(ctx, "ISM plugin setting", FlavorOpenSearch)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
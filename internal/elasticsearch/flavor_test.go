@@ -0,0 +1,141 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+func TestFlavor(t *testing.T) {
+	tests := []struct {
+		R2R    Responce2Req
+		Flavor Flavor
+	}{
+		{
+			R2R: Responce2Req{
+				RequestURI:   "/",
+				ResponceCode: 200,
+				Responce:     ElasticsearchRootGETanswer,
+			},
+			Flavor: FlavorElasticsearch,
+		},
+		{
+			R2R: Responce2Req{
+				RequestURI:   "/",
+				ResponceCode: 200,
+				Responce:     OpenSearchRootGETanswer,
+			},
+			Flavor: FlavorOpenSearch,
+		},
+	}
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		for _, test := range tests {
+			client.flavor = ""
+			testDoer.R2rChan <- test.R2R
+			flavor, err := client.Flavor(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, test.Flavor, flavor)
+			// Flavor is cached - no more requests
+			flavor, err = client.Flavor(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, test.Flavor, flavor)
+		}
+	})
+
+	_, err := ParseFlavor("solr")
+	assert.Error(t, err)
+	flavor, err := ParseFlavor("OpenSearch")
+	assert.NoError(t, err)
+	assert.Equal(t, FlavorOpenSearch, flavor)
+}
+
+func TestUnsupportedFlavor(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		client.flavor = FlavorOpenSearch
+		_, err := client.CreateUpdateIndex(context.Background(), &xov1alpha1.ElasticSearchIndex{
+			Spec: xov1alpha1.ElasticSearchIndexSpec{
+				Name: "some_index",
+				Settings: xov1alpha1.ESIndexSettings{
+					Lifecycle: &xov1alpha1.ESIndexLifecycle{
+						Name: "some_policy",
+					},
+				},
+				Mappings: `{}`,
+			},
+		}, nil)
+		flavorErr := &UnsupportedFlavorError{}
+		assert.True(t, errors.As(err, &flavorErr))
+		assert.EqualError(t, err, "ILM lifecycle setting is not supported by opensearch cluster")
+
+		client.flavor = FlavorElasticsearch
+		_, err = client.CreateUpdateTemplate(context.Background(), &xov1alpha1.ElasticSearchTemplate{
+			Spec: xov1alpha1.ElasticSearchTemplateSpec{
+				Name:          "some_templ",
+				IndexPatterns: []string{"some_index"},
+				Settings: xov1alpha1.ESIndexSettings{
+					Plugins: &xov1alpha1.ESIndexPlugins{
+						IndexStateManagement: xov1alpha1.ESIndexStateManagement{
+							RolloverAlias: "some_alias",
+						},
+					},
+				},
+				Mappings: `{}`,
+			},
+		})
+		assert.EqualError(t, err, "ISM plugin setting is not supported by elasticsearch cluster")
+	})
+}
+
+func TestComposableTemplate(t *testing.T) {
+	tmpl := &xov1alpha1.ElasticSearchTemplate{
+		Spec: xov1alpha1.ElasticSearchTemplateSpec{
+			Name:          "some_templ",
+			IndexPatterns: []string{"some_index"},
+			Settings: xov1alpha1.ESIndexSettings{
+				NumOfReplicas: 2,
+			},
+			Mappings: `{}`,
+		},
+	}
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		// Templates are always composable on OpenSearch
+		client.flavor = FlavorOpenSearch
+		composable, err := client.isComposable(context.Background(), tmpl)
+		assert.NoError(t, err)
+		assert.True(t, composable)
+
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_index_template/some_templ",
+			ResponceCode: 404,
+			Responce:     `{"error":{"type":"resource_not_found_exception","reason":"index template matching [some_templ] not found"},"status":404}`,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_index_template/some_templ",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true}`,
+		}
+		msg, err := client.CreateUpdateTemplate(context.Background(), tmpl)
+		assert.NoError(t, err)
+		assert.Equal(t, "successfully created ES template some_templ", msg)
+
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_index_template/some_templ",
+			ResponceCode: 200,
+			Responce:     `{"index_templates":[{"name":"some_templ","index_template":{"index_patterns":["some_index"],"template":{"settings":{"index":{"number_of_replicas":"2"}}}}}]}`,
+		}
+		msg, err = client.CreateUpdateTemplate(context.Background(), tmpl)
+		assert.NoError(t, err)
+		assert.Equal(t, "no changes on template named some_templ", msg)
+
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_index_template/some_templ",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true}`,
+		}
+		assert.NoError(t, client.DeleteTemplate(context.Background(), tmpl))
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
 	"github.com/90poe/elasticsearch-objects-operator/internal/consts"
@@ -172,3 +173,88 @@ func isManagedByESOperator(settings map[string]interface{}) bool {
 	}
 	return true
 }
+
+// addManagedBy2Description would mark description of ES object which has no metadata as managed by us
+func addManagedBy2Description(description string) string {
+	if len(description) == 0 {
+		return consts.ESManagedByDescription
+	}
+	return fmt.Sprintf("%s %s", description, consts.ESManagedByDescription)
+}
+
+func isManagedByDescription(description string) bool {
+	return strings.HasSuffix(description, consts.ESManagedByDescription)
+}
+
+// parseTimeUnits would parse ES time units value, e.g. 7d or 30m
+func parseTimeUnits(value string) (time.Duration, error) {
+	// Longer suffixes go first, as they end with shorter ones
+	for _, unit := range []struct {
+		suffix   string
+		duration time.Duration
+	}{
+		{"nanos", time.Nanosecond},
+		{"micros", time.Microsecond},
+		{"ms", time.Millisecond},
+		{"s", time.Second},
+		{"m", time.Minute},
+		{"h", time.Hour},
+		{"d", 24 * time.Hour},
+	} {
+		if !strings.HasSuffix(value, unit.suffix) {
+			continue
+		}
+		amount, err := strconv.ParseInt(strings.TrimSuffix(value, unit.suffix), 10, 64)
+		if err != nil || amount < 0 {
+			return 0, fmt.Errorf("invalid time value %s", value)
+		}
+		return time.Duration(amount) * unit.duration, nil
+	}
+	return 0, fmt.Errorf("unknown time unit of %s", value)
+}
+
+// toInterface would turn struct into generic JSON values
+func toInterface(src interface{}) (interface{}, error) {
+	srcJSON, err := json.Marshal(src)
+	if err != nil {
+		return nil, fmt.Errorf("can't marshal %T: %w", src, err)
+	}
+	var ret interface{}
+	err = json.Unmarshal(srcJSON, &ret)
+	if err != nil {
+		return nil, fmt.Errorf("can't unmarshal %T: %w", src, err)
+	}
+	return ret, nil
+}
+
+// isSubset would check if every value of desired is present in actual. Maps in actual may have extra keys
+// ES adds on its own, lists must have same length. Values are compared as strings, as ES may return numbers as strings.
+func isSubset(desired, actual interface{}) bool {
+	switch desiredVal := desired.(type) {
+	case map[string]interface{}:
+		actualMap, ok := actual.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, val := range desiredVal {
+			actualVal, ok := actualMap[key]
+			if !ok || !isSubset(val, actualVal) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		actualList, ok := actual.([]interface{})
+		if !ok || len(actualList) != len(desiredVal) {
+			return false
+		}
+		for i := range desiredVal {
+			if !isSubset(desiredVal[i], actualList[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return fmt.Sprint(desired) == fmt.Sprint(actual)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
 )
@@ -389,3 +390,77 @@ func TestDiffMappings(t *testing.T) {
 		}
 	}
 }
+
+func TestIsSubset(t *testing.T) {
+	tests := []struct {
+		desired  string
+		actual   string
+		expected bool
+	}{
+		{
+			desired:  `{"a": 1, "b": {"c": "d"}}`,
+			actual:   `{"a": 1, "b": {"c": "d", "e": true}, "f": null}`,
+			expected: true,
+		},
+		{
+			desired:  `{"a": 1, "b": {"c": "d"}}`,
+			actual:   `{"a": 2, "b": {"c": "d"}}`,
+			expected: false,
+		},
+		{
+			desired:  `{"a": [{"b": 1}, {"c": 2}]}`,
+			actual:   `{"a": [{"b": 1, "x": 0}, {"c": 2}]}`,
+			expected: true,
+		},
+		{
+			desired:  `{"a": [{"b": 1}]}`,
+			actual:   `{"a": [{"b": 1}, {"c": 2}]}`,
+			expected: false,
+		},
+		{
+			desired:  `{"a": {"b": 1}}`,
+			actual:   `{"b": 1}`,
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		var desired, actual interface{}
+		err := json.Unmarshal([]byte(test.desired), &desired)
+		if err != nil {
+			t.Fatalf("could not unmarshal '%s'", test.desired)
+		}
+		err = json.Unmarshal([]byte(test.actual), &actual)
+		if err != nil {
+			t.Fatalf("could not unmarshal '%s'", test.actual)
+		}
+		if res := isSubset(desired, actual); res != test.expected {
+			t.Fatalf("isSubset(%s, %s) expected '%v', got '%v'", test.desired, test.actual, test.expected, res)
+		}
+	}
+}
+
+func TestParseTimeUnits(t *testing.T) {
+	for value, expected := range map[string]time.Duration{
+		"7d":       7 * 24 * time.Hour,
+		"12h":      12 * time.Hour,
+		"30m":      30 * time.Minute,
+		"45s":      45 * time.Second,
+		"500ms":    500 * time.Millisecond,
+		"10micros": 10 * time.Microsecond,
+		"10nanos":  10 * time.Nanosecond,
+	} {
+		duration, err := parseTimeUnits(value)
+		if err != nil {
+			t.Fatalf("could not parse '%s': %v", value, err)
+		}
+		if duration != expected {
+			t.Fatalf("values don't match %s != %s", duration, expected)
+		}
+	}
+	for _, value := range []string{"", "7", "d", "-1d", "1.5h", "2w"} {
+		_, err := parseTimeUnits(value)
+		if err == nil {
+			t.Fatalf("invalid value '%s' is parsed", value)
+		}
+	}
+}
@@ -4,8 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
-
-	"github.com/olivere/elastic/v7"
+	"net/http"
+	"net/url"
+	"strings"
 
 	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
 	"github.com/90poe/elasticsearch-objects-operator/internal/consts"
@@ -13,35 +14,90 @@ import (
 
 // Settings is required to create ES Index
 type Settings struct {
-	Index xov1alpha1.ESIndexSettings `json:"index"`
+	Index    xov1alpha1.ESIndexSettings `json:"index"`
+	Analysis map[string]interface{}     `json:"analysis,omitempty"`
 }
 
 // Index is configuration struct for ES index creation
 type Index struct {
 	Settings Settings               `json:"settings"`
 	Mappings map[string]interface{} `json:"mappings"`
+	Aliases  map[string]ESAlias     `json:"aliases,omitempty"`
+}
+
+// indexGetResponse is answer of ES get index API for one index
+type indexGetResponse struct {
+	Settings map[string]interface{} `json:"settings"`
+	Mappings map[string]interface{} `json:"mappings"`
 }
 
 // IndexExists would check if index exists
-func (c *Client) IndexExists(name string) (bool, error) {
-	exists, err := c.es.IndexExists(name).Do(context.Background())
+func (c *Client) IndexExists(ctx context.Context, name string) (bool, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	exists, err := c.exists(ctx, indexPath(name))
 	if err != nil {
 		return false, fmt.Errorf("can't check if index exists: %w", err)
 	}
 	return exists, nil
 }
 
-// CreateUpdateIndex would update index if it exists or create if not
-func (c *Client) CreateUpdateIndex(object *xov1alpha1.ElasticSearchIndex) (string, error) {
+// CreateUpdateIndex would update index if it exists or create if not.
+// Index in rollover mode is managed through its write alias. Synonyms are rules of synonym filters from spec.
+func (c *Client) CreateUpdateIndex(ctx context.Context, object *xov1alpha1.ElasticSearchIndex, synonyms Synonyms) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.checkSettingsFlavor(ctx, &object.Spec.Settings)
+	if err != nil {
+		return "", err
+	}
+	useSets := false
+	updatedSets := []string{}
+	if len(object.Spec.Synonyms) > 0 {
+		useSets, err = c.SynonymsSetsSupported(ctx)
+		if err != nil {
+			return "", err
+		}
+	}
+	if useSets {
+		// Synonyms sets must exist before index which refers to them
+		updatedSets, err = c.putSynonymsSets(ctx, object, synonyms)
+		if err != nil {
+			return "", err
+		}
+	}
+	analysis, err := indexAnalysis(object, synonyms, useSets)
+	if err != nil {
+		return "", err
+	}
+	var msg string
+	if object.Spec.Rollover != nil {
+		msg, err = c.createUpdateRolloverIndex(ctx, object, analysis)
+	} else {
+		msg, err = c.createUpdateIndex(ctx, object, analysis)
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(updatedSets) > 0 {
+		msg = fmt.Sprintf("%s, updated synonyms of filters %s", msg, strings.Join(updatedSets, ","))
+	}
+	return msg, nil
+}
+
+// createUpdateIndex would update index with name from spec if it exists or create if not.
+// Analysis is only applied on creation, except for inline synonyms which are updated once they change.
+func (c *Client) createUpdateIndex(ctx context.Context, object *xov1alpha1.ElasticSearchIndex,
+	analysis map[string]interface{}) (string, error) {
 	// Get index settings and mappings from ES
-	servSettings, servMappings, err := c.getServerIndexSettingsAndMappings(object.Spec.Name)
+	servSettings, servMappings, err := c.getServerIndexSettingsAndMappings(ctx, object.Spec.Name)
 	if err != nil && !errors.Is(err, errObjectNotFound) {
 		// Error is not NotFound - report back
 		return "", fmt.Errorf("can't get index details: %w", err)
 	}
 	if servMappings == nil && servSettings == nil {
 		// Create index request
-		return c.createIndex(object)
+		return c.createIndex(ctx, object, nil, analysis)
 	}
 	// Update index
 	// Check if mappings are present
@@ -89,13 +145,22 @@ func (c *Client) CreateUpdateIndex(object *xov1alpha1.ElasticSearchIndex) (strin
 		return "", fmt.Errorf("%s: %w", object.Spec.Name, err)
 	}
 
-	if !changedMappins && !changedSettings {
+	// Check if inline synonyms changed, index is only closed to update them if it is allowed
+	changedSynonyms := changedInlineSynonyms(object, analysis, servSettings)
+	for _, filter := range object.Spec.Synonyms {
+		if !filter.CloseIndex {
+			delete(changedSynonyms, filter.Filter)
+		}
+	}
+
+	if !changedMappins && !changedSettings && len(changedSynonyms) == 0 {
 		// Neither mappings nor settings changed
 		return fmt.Sprintf("no changes on index named %s", object.Spec.Name), nil
 	}
 	// Put index settings
 	if changedSettings {
-		updateIndex, err := c.es.IndexPutSettings(object.Spec.Name).BodyJson(modIndex).Do(context.Background())
+		updateIndex := acknowledgedResponse{}
+		err = c.perform(ctx, http.MethodPut, indexPath(object.Spec.Name)+"/_settings", modIndex, &updateIndex)
 		if err != nil {
 			return "", fmt.Errorf("can't update ES index settings: %w", err)
 		}
@@ -106,8 +171,8 @@ func (c *Client) CreateUpdateIndex(object *xov1alpha1.ElasticSearchIndex) (strin
 	}
 	// Put index mappings
 	if changedMappins {
-		service := elastic.NewIndicesPutMappingService(c.es)
-		updateIndex, err := service.Index(object.Spec.Name).BodyJson(modIndex.Mappings).Do(context.Background())
+		updateIndex := acknowledgedResponse{}
+		err = c.perform(ctx, http.MethodPut, indexPath(object.Spec.Name)+"/_mapping", modIndex.Mappings, &updateIndex)
 		if err != nil {
 			return "", fmt.Errorf("can't update ES index mapping: %w", err)
 		}
@@ -116,23 +181,34 @@ func (c *Client) CreateUpdateIndex(object *xov1alpha1.ElasticSearchIndex) (strin
 			return "", fmt.Errorf("can't acknowledge ES index mapping update")
 		}
 	}
+	// Put synonyms
+	if len(changedSynonyms) > 0 {
+		err = c.updateInlineSynonyms(ctx, object, object.Spec.Name, changedSynonyms)
+		if err != nil {
+			return "", err
+		}
+	}
 	return fmt.Sprintf("successfully updated ES index %s", object.Spec.Name), nil
 }
 
-// createIndex is going to create index
-func (c *Client) createIndex(object *xov1alpha1.ElasticSearchIndex) (string, error) {
+// createIndex is going to create index with aliases
+func (c *Client) createIndex(ctx context.Context, object *xov1alpha1.ElasticSearchIndex,
+	aliases map[string]ESAlias, analysis map[string]interface{}) (string, error) {
 	sett := Settings{
-		Index: object.Spec.Settings,
+		Index:    object.Spec.Settings,
+		Analysis: analysis,
 	}
 	newIndex := Index{
 		Settings: sett,
+		Aliases:  aliases,
 	}
 	var err error
 	newIndex.Mappings, err = addManagedBy2Interface(object.Spec.Mappings)
 	if err != nil {
 		return "", fmt.Errorf("can't add %s 2 ES index: %w", consts.ESManagedByField, err)
 	}
-	createIndex, err := c.es.CreateIndex(object.Spec.Name).BodyJson(newIndex).Do(context.Background())
+	createIndex := acknowledgedResponse{}
+	err = c.perform(ctx, http.MethodPut, indexPath(object.Spec.Name), newIndex, &createIndex)
 	if err != nil {
 		// Handle error
 		return "", fmt.Errorf("can't create ES index: %w", err)
@@ -144,9 +220,33 @@ func (c *Client) createIndex(object *xov1alpha1.ElasticSearchIndex) (string, err
 	return fmt.Sprintf("successfully created ES index %s", object.Spec.Name), nil
 }
 
+// ManagedIndices would get indices of object managed by operator, these are indices which are dropped on delete.
+// Index in rollover mode has all its generations, newest first.
+func (c *Client) ManagedIndices(ctx context.Context, object *xov1alpha1.ElasticSearchIndex) ([]string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	if object.Spec.Rollover != nil {
+		return c.rolloverGenerations(ctx, object.Spec.Name)
+	}
+	_, servMappings, err := c.getServerIndexSettingsAndMappings(ctx, object.Spec.Name)
+	if errors.Is(err, errObjectNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get index details: %w", err)
+	}
+	if !isManagedByESOperator(servMappings) {
+		return nil, nil
+	}
+	return []string{object.Spec.Name}, nil
+}
+
 // DeleteIndex would delete ES index
-func (c *Client) DeleteIndex(name string) error {
-	delIndex, err := c.es.DeleteIndex(name).Do(context.Background())
+func (c *Client) DeleteIndex(ctx context.Context, name string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	delIndex := acknowledgedResponse{}
+	err := c.perform(ctx, http.MethodDelete, indexPath(name), nil, &delIndex)
 	if err != nil {
 		return fmt.Errorf("can't delete index %s: %w", name, err)
 	}
@@ -158,16 +258,13 @@ func (c *Client) DeleteIndex(name string) error {
 }
 
 // getServerIndexSettings would get settings from ES cluster for index with name indexName
-func (c *Client) getServerIndexSettingsAndMappings(indexName string) (settings map[string]interface{},
+func (c *Client) getServerIndexSettingsAndMappings(ctx context.Context, indexName string) (settings map[string]interface{},
 	mappings map[string]interface{}, _ error) {
-	service := elastic.NewIndicesGetService(c.es)
-	sett, err := service.Index(indexName).Do(context.Background())
+	sett := map[string]indexGetResponse{}
+	err := c.perform(ctx, http.MethodGet, indexPath(indexName), nil, &sett)
 	if err != nil {
-		if newErr, ok := err.(*elastic.Error); ok {
-			// Elastic error, we could check status
-			if newErr.Status == 404 {
-				return nil, nil, errObjectNotFound
-			}
+		if isNotFound(err) {
+			return nil, nil, errObjectNotFound
 		}
 		return nil, nil, fmt.Errorf("can't get settings and mappings: %w", err)
 	}
@@ -177,3 +274,7 @@ func (c *Client) getServerIndexSettingsAndMappings(indexName string) (settings m
 	}
 	return index.Settings, index.Mappings, nil
 }
+
+func indexPath(name string) string {
+	return fmt.Sprintf("/%s", url.PathEscape(name))
+}
@@ -1,6 +1,7 @@
 package elasticsearch
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"testing"
@@ -33,11 +34,6 @@ type TestDelete struct {
 }
 
 func TestCreateUpdateIndex(t *testing.T) {
-	var testDoer *TestDoer
-	client := Client{}
-	client.esURL = Localhost
-	client.es, testDoer = setupCreateTestClient(t)
-	defer testDoer.Close()
 	tests := []TestUpdateIndx{
 		{
 			// No changes
@@ -919,31 +915,28 @@ func TestCreateUpdateIndex(t *testing.T) {
 			Err: fmt.Errorf("can't add managed-by 2 ES index: can't json unmarshal mappings: invalid character 'd' looking for beginning of object key string"),
 		},
 	}
-	for _, test := range tests {
-		r2rKeys := make([]int, 0, len(test.R2R))
-		for key := range test.R2R {
-			r2rKeys = append(r2rKeys, key)
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		for _, test := range tests {
+			r2rKeys := make([]int, 0, len(test.R2R))
+			for key := range test.R2R {
+				r2rKeys = append(r2rKeys, key)
+			}
+			sort.Ints(r2rKeys)
+			for _, value := range r2rKeys {
+				testDoer.R2rChan <- test.R2R[value]
+			}
+			msg, err := client.CreateUpdateIndex(context.Background(), test.Index, nil)
+			if test.Err != nil {
+				assert.EqualError(t, err, fmt.Sprintf("%s", test.Err))
+				continue
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.Msg, msg)
 		}
-		sort.Ints(r2rKeys)
-		for _, value := range r2rKeys {
-			testDoer.R2rChan <- test.R2R[value]
-		}
-		msg, err := client.CreateUpdateIndex(test.Index)
-		if test.Err != nil {
-			assert.EqualError(t, err, fmt.Sprintf("%s", test.Err))
-			continue
-		}
-		assert.NoError(t, err)
-		assert.Equal(t, test.Msg, msg)
-	}
+	})
 }
 
 func TestDeleteIndex(t *testing.T) {
-	var testDoer *TestDoer
-	client := Client{}
-	client.esURL = Localhost
-	client.es, testDoer = setupCreateTestClient(t)
-	defer testDoer.Close()
 	tests := []TestDelete{
 		{
 			IndexName: "some_index",
@@ -972,13 +965,59 @@ func TestDeleteIndex(t *testing.T) {
 			Err: fmt.Errorf("can't acknowledge ES index deletion"),
 		},
 	}
-	for _, test := range tests {
-		testDoer.R2rChan <- test.R2R
-		err := client.DeleteIndex(test.IndexName)
-		if test.Err != nil {
-			assert.EqualError(t, err, fmt.Sprintf("%s", test.Err))
-			continue
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		for _, test := range tests {
+			testDoer.R2rChan <- test.R2R
+			err := client.DeleteIndex(context.Background(), test.IndexName)
+			if test.Err != nil {
+				assert.EqualError(t, err, fmt.Sprintf("%s", test.Err))
+				continue
+			}
+			assert.NoError(t, err)
 		}
-		assert.NoError(t, err)
+	})
+}
+
+func TestManagedIndices(t *testing.T) {
+	index := &xov1alpha1.ElasticSearchIndex{
+		Spec: xov1alpha1.ElasticSearchIndexSpec{
+			Name: "some_index",
+		},
+	}
+	tests := []struct {
+		R2R     Responce2Req
+		Indices []string
+	}{
+		{
+			R2R: Responce2Req{
+				RequestURI:   "/some_index",
+				ResponceCode: 200,
+				Responce:     `{"some_index":{"mappings":{"_meta":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}},"settings":{"index":{}}}}`,
+			},
+			Indices: []string{"some_index"},
+		},
+		{
+			// Index not managed by operator is never dropped
+			R2R: Responce2Req{
+				RequestURI:   "/some_index",
+				ResponceCode: 200,
+				Responce:     `{"some_index":{"mappings":{},"settings":{"index":{}}}}`,
+			},
+		},
+		{
+			R2R: Responce2Req{
+				RequestURI:   "/some_index",
+				ResponceCode: 404,
+				Responce:     `{"error":{"type":"index_not_found_exception","reason":"no such index [some_index]"},"status":404}`,
+			},
+		},
 	}
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		for _, test := range tests {
+			testDoer.R2rChan <- test.R2R
+			indices, err := client.ManagedIndices(context.Background(), index)
+			assert.NoError(t, err)
+			assert.Equal(t, test.Indices, indices)
+		}
+	})
 }
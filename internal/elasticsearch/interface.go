@@ -1,17 +1,97 @@
 package elasticsearch
 
 import (
+	"context"
+	"time"
+
 	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
 )
 
 // ES is interface for ElasticSearch
 type ES interface {
+	// Cluster
+	Flavor(ctx context.Context) (Flavor, error)
 	// Index
-	IndexExists(name string) (bool, error)
-	CreateUpdateIndex(index *xov1alpha1.ElasticSearchIndex) (string, error)
-	DeleteIndex(indexName string) error
+	IndexExists(ctx context.Context, name string) (bool, error)
+	CreateUpdateIndex(ctx context.Context, index *xov1alpha1.ElasticSearchIndex, synonyms Synonyms) (string, error)
+	DeleteIndex(ctx context.Context, indexName string) error
+	ManagedIndices(ctx context.Context, index *xov1alpha1.ElasticSearchIndex) ([]string, error)
+	ExpiredRolloverIndices(ctx context.Context, index *xov1alpha1.ElasticSearchIndex) ([]string, error)
+	NextRollover(ctx context.Context, index *xov1alpha1.ElasticSearchIndex) (time.Time, error)
+	SynonymsSetsSupported(ctx context.Context) (bool, error)
+	DeleteSynonymsSets(ctx context.Context, index *xov1alpha1.ElasticSearchIndex) error
+	InlineSynonymsChanges(ctx context.Context, index *xov1alpha1.ElasticSearchIndex, synonyms Synonyms) (*InlineSynonymsChange, error)
 	// Template
-	TemplateExists(name string) (bool, error)
-	CreateUpdateTemplate(tmpl *xov1alpha1.ElasticSearchTemplate) (string, error)
-	DeleteTemplate(tmplName string) error
+	TemplateExists(ctx context.Context, tmpl *xov1alpha1.ElasticSearchTemplate) (bool, error)
+	CreateUpdateTemplate(ctx context.Context, tmpl *xov1alpha1.ElasticSearchTemplate) (string, error)
+	DeleteTemplate(ctx context.Context, tmpl *xov1alpha1.ElasticSearchTemplate) error
+	// Alias
+	AliasExists(ctx context.Context, name string) (bool, error)
+	CreateUpdateAlias(ctx context.Context, alias *xov1alpha1.ElasticSearchAlias) (string, error)
+	DeleteAlias(ctx context.Context, alias *xov1alpha1.ElasticSearchAlias) error
+	// Data stream
+	DataStreamExists(ctx context.Context, name string) (bool, error)
+	CreateDataStream(ctx context.Context, dataStream *xov1alpha1.ElasticSearchDataStream) (string, error)
+	GetDataStream(ctx context.Context, name string) (*DataStream, error)
+	RolloverDataStream(ctx context.Context, name string) error
+	DeleteDataStream(ctx context.Context, name string) error
+	// Snapshot repository
+	SnapshotRepositoryExists(ctx context.Context, name string) (bool, error)
+	CreateUpdateSnapshotRepository(ctx context.Context, repository *xov1alpha1.ElasticSearchSnapshotRepository,
+		secretSettings map[string]string) (string, error)
+	VerifySnapshotRepository(ctx context.Context, name string) ([]string, error)
+	DeleteSnapshotRepository(ctx context.Context, name string) error
+	// Snapshot
+	CreateSnapshot(ctx context.Context, snapshot *xov1alpha1.ElasticSearchSnapshot) (string, error)
+	GetSnapshot(ctx context.Context, repository, name string) (*Snapshot, error)
+	DeleteSnapshot(ctx context.Context, repository, name string) error
+	// Restore
+	RestoreSnapshot(ctx context.Context, restore *xov1alpha1.ElasticSearchRestore) ([]string, error)
+	GetRestoreProgress(ctx context.Context, indices []string) (*RestoreProgress, error)
+	// SLM policy
+	SnapshotPolicyExists(ctx context.Context, name string) (bool, error)
+	CreateUpdateSnapshotPolicy(ctx context.Context, policy *xov1alpha1.ElasticSearchSnapshotPolicy) (string, error)
+	GetSnapshotPolicyState(ctx context.Context, name string) (*SnapshotPolicyState, error)
+	ExecuteSnapshotPolicy(ctx context.Context, name string) (string, error)
+	DeleteSnapshotPolicy(ctx context.Context, name string) error
+	// Security role
+	RoleExists(ctx context.Context, name string) (bool, error)
+	CreateUpdateRole(ctx context.Context, role *xov1alpha1.ElasticSearchRole) (string, error)
+	DeleteRole(ctx context.Context, name string) error
+	// Security user
+	UserExists(ctx context.Context, name string) (bool, error)
+	CreateUpdateUser(ctx context.Context, user *xov1alpha1.ElasticSearchUser, password string) (string, error)
+	DeleteUser(ctx context.Context, name string) error
+	// API key
+	CreateAPIKey(ctx context.Context, apiKey *xov1alpha1.ElasticSearchAPIKey) (*APIKey, error)
+	GetAPIKey(ctx context.Context, id string) (*APIKeyInfo, error)
+	InvalidateAPIKey(ctx context.Context, id string) error
+	// Role mapping
+	RoleMappingExists(ctx context.Context, name string) (bool, error)
+	CreateUpdateRoleMapping(ctx context.Context, mapping *xov1alpha1.ElasticSearchRoleMapping) (string, error)
+	DeleteRoleMapping(ctx context.Context, name string) error
+	// Cluster settings
+	UpdateClusterSettings(ctx context.Context, settings *xov1alpha1.ElasticSearchClusterSettings) (string, error)
+	ResetClusterSettings(ctx context.Context, keys []string) error
+	// Stored script
+	StoredScriptExists(ctx context.Context, id string) (bool, error)
+	CreateUpdateStoredScript(ctx context.Context, script *xov1alpha1.ElasticSearchStoredScript) (string, error)
+	DeleteStoredScript(ctx context.Context, id string) error
+	// Enrich policy
+	EnrichPolicyExists(ctx context.Context, name string) (bool, error)
+	CreateUpdateEnrichPolicy(ctx context.Context, policy *xov1alpha1.ElasticSearchEnrichPolicy) (string, error)
+	ExecuteEnrichPolicy(ctx context.Context, name string) (string, error)
+	GetEnrichPolicyExecution(ctx context.Context, task string) (*EnrichPolicyExecution, error)
+	DeleteEnrichPolicy(ctx context.Context, name string) error
+	// Transform
+	TransformExists(ctx context.Context, id string) (bool, error)
+	CreateUpdateTransform(ctx context.Context, transform *xov1alpha1.ElasticSearchTransform) (string, error)
+	GetTransformStats(ctx context.Context, id string) (*TransformStats, error)
+	StartTransform(ctx context.Context, id string) error
+	StopTransform(ctx context.Context, id string) error
+	DeleteTransform(ctx context.Context, id string) error
+	// ISM policy
+	ISMPolicyExists(ctx context.Context, name string) (bool, error)
+	CreateUpdateISMPolicy(ctx context.Context, policy *xov1alpha1.OpenSearchISMPolicy) (string, error)
+	DeleteISMPolicy(ctx context.Context, name string) error
 }
@@ -0,0 +1,172 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+// ISMPolicy is configuration struct for OpenSearch ISM policy creation
+type ISMPolicy struct {
+	Description  string                   `json:"description"`
+	DefaultState string                   `json:"default_state"`
+	States       []ISMState               `json:"states"`
+	ISMTemplate  []xov1alpha1.ISMTemplate `json:"ism_template,omitempty"`
+}
+
+// ISMState has struct same as xov1alpha1.ISMState, but replaces Actions with interfaces
+type ISMState struct {
+	Name        string                     `json:"name"`
+	Actions     []interface{}              `json:"actions"`
+	Transitions []xov1alpha1.ISMTransition `json:"transitions"`
+}
+
+// ismPolicyResponse is answer of OpenSearch get ISM policy API
+type ismPolicyResponse struct {
+	ID          string                 `json:"_id"`
+	SeqNo       int64                  `json:"_seq_no"`
+	PrimaryTerm int64                  `json:"_primary_term"`
+	Policy      map[string]interface{} `json:"policy"`
+}
+
+func ismPolicyPath(name string) string {
+	return fmt.Sprintf("/_plugins/_ism/policies/%s", url.PathEscape(name))
+}
+
+// ISMPolicyExists would check if ISM policy exists
+func (c *Client) ISMPolicyExists(ctx context.Context, name string) (bool, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "ISM policy", FlavorOpenSearch)
+	if err != nil {
+		return false, err
+	}
+	_, err = c.getServerISMPolicy(ctx, name)
+	if errors.Is(err, errObjectNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("can't check if ISM policy exists: %w", err)
+	}
+	return true, nil
+}
+
+// CreateUpdateISMPolicy would update ISM policy if it exists or create if not.
+// Update is done with optimistic concurrency control, so concurrent changes of policy are not overwritten.
+func (c *Client) CreateUpdateISMPolicy(ctx context.Context, object *xov1alpha1.OpenSearchISMPolicy) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "ISM policy", FlavorOpenSearch)
+	if err != nil {
+		return "", err
+	}
+	policy, err := newISMPolicy(object)
+	if err != nil {
+		return "", err
+	}
+	path := ismPolicyPath(object.Spec.Name)
+	servPolicy, err := c.getServerISMPolicy(ctx, object.Spec.Name)
+	if errors.Is(err, errObjectNotFound) {
+		err = c.perform(ctx, http.MethodPut, path, map[string]interface{}{"policy": policy}, nil)
+		if err != nil {
+			return "", fmt.Errorf("can't create ISM policy: %w", err)
+		}
+		return fmt.Sprintf("successfully created ISM policy %s", object.Spec.Name), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("can't get ISM policy: %w", err)
+	}
+	description, _ := getStringValueFromSettings(servPolicy.Policy, "description")
+	if !isManagedByDescription(description) {
+		return "", fmt.Errorf("ISM policy '%s' is not managed by this operator", object.Spec.Name)
+	}
+	desired, err := toInterface(policy)
+	if err != nil {
+		return "", err
+	}
+	if isSubset(desired, servPolicy.Policy) {
+		return fmt.Sprintf("no changes on ISM policy named %s", object.Spec.Name), nil
+	}
+	path = fmt.Sprintf("%s?if_seq_no=%d&if_primary_term=%d", path, servPolicy.SeqNo, servPolicy.PrimaryTerm)
+	err = c.perform(ctx, http.MethodPut, path, map[string]interface{}{"policy": policy}, nil)
+	if isConflict(err) {
+		return "", fmt.Errorf("can't update ISM policy %s: %w, will retry: %w", object.Spec.Name, ErrConcurrentModification, err)
+	}
+	if err != nil {
+		return "", fmt.Errorf("can't update ISM policy: %w", err)
+	}
+	return fmt.Sprintf("successfully updated ISM policy %s", object.Spec.Name), nil
+}
+
+// DeleteISMPolicy would delete ISM policy, policy which doesn't exist or is not managed by operator is not deleted
+func (c *Client) DeleteISMPolicy(ctx context.Context, name string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "ISM policy", FlavorOpenSearch)
+	if err != nil {
+		return err
+	}
+	servPolicy, err := c.getServerISMPolicy(ctx, name)
+	if errors.Is(err, errObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("can't get ISM policy %s: %w", name, err)
+	}
+	description, _ := getStringValueFromSettings(servPolicy.Policy, "description")
+	if !isManagedByDescription(description) {
+		return fmt.Errorf("ISM policy '%s' is %w", name, ErrNotManaged)
+	}
+	err = c.perform(ctx, http.MethodDelete, ismPolicyPath(name), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("can't delete ISM policy %s: %w", name, err)
+	}
+	return nil
+}
+
+func (c *Client) getServerISMPolicy(ctx context.Context, name string) (*ismPolicyResponse, error) {
+	policy := &ismPolicyResponse{}
+	err := c.perform(ctx, http.MethodGet, ismPolicyPath(name), nil, policy)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errObjectNotFound
+		}
+		return nil, err
+	}
+	return policy, nil
+}
+
+// newISMPolicy would make ISM policy OpenSearch understands from K8S object
+func newISMPolicy(object *xov1alpha1.OpenSearchISMPolicy) (*ISMPolicy, error) {
+	policy := &ISMPolicy{
+		Description:  addManagedBy2Description(object.Spec.Description),
+		DefaultState: object.Spec.DefaultState,
+		States:       make([]ISMState, 0, len(object.Spec.States)),
+		ISMTemplate:  object.Spec.ISMTemplate,
+	}
+	for _, state := range object.Spec.States {
+		newState := ISMState{
+			Name:        state.Name,
+			Actions:     make([]interface{}, 0, len(state.Actions)),
+			Transitions: state.Transitions,
+		}
+		if newState.Transitions == nil {
+			newState.Transitions = []xov1alpha1.ISMTransition{}
+		}
+		for _, action := range state.Actions {
+			var newAction interface{}
+			err := json.Unmarshal([]byte(action), &newAction)
+			if err != nil {
+				return nil, fmt.Errorf("can't unmarshal action of state %s: %w", state.Name, err)
+			}
+			newState.Actions = append(newState.Actions, newAction)
+		}
+		policy.States = append(policy.States, newState)
+	}
+	return policy, nil
+}
@@ -0,0 +1,196 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+const (
+	// Used by Doer to mock OpenSearch answer on get ISM policy request
+	ISMPolicyGETanswer = `{"_id":"some_policy","_version":2,"_seq_no":5,"_primary_term":1,"policy":{"policy_id":"some_policy","description":"hot delete %s","last_updated_time":1700000000000,"schema_version":19,"error_notification":null,"default_state":"hot","states":[{"name":"hot","actions":[{"retry":{"count":3,"backoff":"exponential","delay":"1m"},"rollover":{"min_size":"50gb"}}],"transitions":[{"state_name":"delete","conditions":{"min_index_age":"30d"}}]},{"name":"delete","actions":[{"retry":{"count":3,"backoff":"exponential","delay":"1m"},"delete":{}}],"transitions":[]}],"ism_template":[{"index_patterns":["logs-*"],"priority":100,"last_updated_time":1700000000000}]}}`
+)
+
+func newTestISMPolicy(minIndexAge string) *xov1alpha1.OpenSearchISMPolicy {
+	return &xov1alpha1.OpenSearchISMPolicy{
+		Spec: xov1alpha1.OpenSearchISMPolicySpec{
+			Name:         "some_policy",
+			Description:  "hot delete",
+			DefaultState: "hot",
+			States: []xov1alpha1.ISMState{
+				{
+					Name:    "hot",
+					Actions: []string{`{"rollover":{"min_size":"50gb"}}`},
+					Transitions: []xov1alpha1.ISMTransition{
+						{
+							StateName: "delete",
+							Conditions: &xov1alpha1.ISMConditions{
+								MinIndexAge: minIndexAge,
+							},
+						},
+					},
+				},
+				{
+					Name:    "delete",
+					Actions: []string{`{"delete":{}}`},
+				},
+			},
+			ISMTemplate: []xov1alpha1.ISMTemplate{
+				{
+					IndexPatterns: []string{"logs-*"},
+					Priority:      100,
+				},
+			},
+		},
+	}
+}
+
+func TestCreateUpdateISMPolicy(t *testing.T) {
+	managedAnswer := fmt.Sprintf(ISMPolicyGETanswer, "[managed-by: elasticsearch-objects-operator.xo.90poe.io]")
+	tests := []requestTest[*xov1alpha1.OpenSearchISMPolicy, string]{
+		{
+			Object: newTestISMPolicy("30d"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_plugins/_ism/policies/some_policy",
+					ResponceCode: 404,
+					Responce:     `{"error":{"root_cause":[{"type":"status_exception","reason":"Policy not found"}],"type":"status_exception","reason":"Policy not found"},"status":404}`,
+				},
+				{
+					RequestURI:   "/_plugins/_ism/policies/some_policy",
+					ResponceCode: 201,
+					Responce:     `{"_id":"some_policy","_version":1,"_primary_term":1,"_seq_no":0}`,
+				},
+			},
+			Want: "successfully created ISM policy some_policy",
+		},
+		{
+			Object: newTestISMPolicy("30d"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_plugins/_ism/policies/some_policy",
+					ResponceCode: 200,
+					Responce:     managedAnswer,
+				},
+			},
+			Want: "no changes on ISM policy named some_policy",
+		},
+		{
+			Object: newTestISMPolicy("7d"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_plugins/_ism/policies/some_policy",
+					ResponceCode: 200,
+					Responce:     managedAnswer,
+				},
+				{
+					RequestURI:   "/_plugins/_ism/policies/some_policy?if_seq_no=5&if_primary_term=1",
+					ResponceCode: 200,
+					Responce:     `{"_id":"some_policy","_version":3,"_primary_term":1,"_seq_no":6}`,
+				},
+			},
+			Want: "successfully updated ISM policy some_policy",
+		},
+		{
+			Object: newTestISMPolicy("7d"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_plugins/_ism/policies/some_policy",
+					ResponceCode: 200,
+					Responce:     managedAnswer,
+				},
+				{
+					RequestURI:   "/_plugins/_ism/policies/some_policy?if_seq_no=5&if_primary_term=1",
+					ResponceCode: 409,
+					Responce:     `{"error":{"type":"version_conflict_engine_exception","reason":"[some_policy]: version conflict, required seqNo [5], primary term [1]. current document has seqNo [7] and primary term [1]"},"status":409}`,
+				},
+			},
+			Err: "can't update ISM policy some_policy: object was changed concurrently, will retry: elastic: Error 409 (Conflict): [some_policy]: version conflict, required seqNo [5], primary term [1]. current document has seqNo [7] and primary term [1] [type=version_conflict_engine_exception]",
+		},
+		{
+			Object: newTestISMPolicy("7d"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_plugins/_ism/policies/some_policy",
+					ResponceCode: 200,
+					Responce:     fmt.Sprintf(ISMPolicyGETanswer, "created by hand"),
+				},
+			},
+			Err: "ISM policy 'some_policy' is not managed by this operator",
+		},
+	}
+	runRequestTests(t, tests, func(client *Client, ctx context.Context, policy *xov1alpha1.OpenSearchISMPolicy) (string, error) {
+		client.flavor = FlavorOpenSearch
+		return client.CreateUpdateISMPolicy(ctx, policy)
+	})
+}
+
+func TestISMPolicyOnElasticsearch(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		_, err := client.CreateUpdateISMPolicy(context.Background(), newTestISMPolicy("30d"))
+		assert.EqualError(t, err, "ISM policy is not supported by elasticsearch cluster")
+	})
+}
+
+func TestDeleteISMPolicy(t *testing.T) {
+	managedAnswer := fmt.Sprintf(ISMPolicyGETanswer, "[managed-by: elasticsearch-objects-operator.xo.90poe.io]")
+	tests := []requestTest[*xov1alpha1.OpenSearchISMPolicy, string]{
+		{
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_plugins/_ism/policies/some_policy",
+					ResponceCode: 200,
+					Responce:     managedAnswer,
+				},
+				{
+					RequestURI:   "/_plugins/_ism/policies/some_policy",
+					ResponceCode: 200,
+					Responce:     `{"_index":".opendistro-ism-config","_id":"some_policy","_version":4,"result":"deleted"}`,
+				},
+			},
+		},
+		{
+			// Already deleted policy is fine
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_plugins/_ism/policies/some_policy",
+					ResponceCode: 404,
+					Responce:     `{"error":{"type":"status_exception","reason":"Policy not found"},"status":404}`,
+				},
+			},
+		},
+		{
+			// Policy created outside of operator is kept
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_plugins/_ism/policies/some_policy",
+					ResponceCode: 200,
+					Responce:     fmt.Sprintf(ISMPolicyGETanswer, "created by hand"),
+				},
+			},
+			Err: "ISM policy 'some_policy' is not managed by this operator",
+		},
+		{
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_plugins/_ism/policies/some_policy",
+					ResponceCode: 200,
+					Responce:     managedAnswer,
+				},
+				{
+					RequestURI:   "/_plugins/_ism/policies/some_policy",
+					ResponceCode: 500,
+				},
+			},
+			Err: "can't delete ISM policy some_policy: elastic: Error 500 (Internal Server Error)",
+		},
+	}
+	runRequestTests(t, tests, func(client *Client, ctx context.Context, _ *xov1alpha1.OpenSearchISMPolicy) (string, error) {
+		client.flavor = FlavorOpenSearch
+		return "", client.DeleteISMPolicy(ctx, "some_policy")
+	})
+}
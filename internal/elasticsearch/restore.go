@@ -0,0 +1,185 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+// RestoreProgress is recovery progress of restored indices
+type RestoreProgress struct {
+	ShardsTotal int64
+	ShardsDone  int64
+	// Done is true once primary shards of all restored indices are recovered
+	Done bool
+}
+
+// restoreRequest is body of ES restore snapshot API
+type restoreRequest struct {
+	Indices             []string    `json:"indices,omitempty"`
+	IgnoreUnavailable   bool        `json:"ignore_unavailable,omitempty"`
+	IncludeGlobalState  bool        `json:"include_global_state,omitempty"`
+	Partial             bool        `json:"partial,omitempty"`
+	IncludeAliases      *bool       `json:"include_aliases,omitempty"`
+	RenamePattern       string      `json:"rename_pattern,omitempty"`
+	RenameReplacement   string      `json:"rename_replacement,omitempty"`
+	IndexSettings       interface{} `json:"index_settings,omitempty"`
+	IgnoreIndexSettings []string    `json:"ignore_index_settings,omitempty"`
+}
+
+// recoveryResponse is answer of ES index recovery API, keys are index names
+type recoveryResponse map[string]struct {
+	Shards []struct {
+		ID      int    `json:"id"`
+		Type    string `json:"type"`
+		Stage   string `json:"stage"`
+		Primary bool   `json:"primary"`
+	} `json:"shards"`
+}
+
+// RestoreSnapshot would start restore of snapshot without waiting for its completion,
+// it returns names of restored indices after rename, use GetRestoreProgress to track their recovery
+func (c *Client) RestoreSnapshot(ctx context.Context, object *xov1alpha1.ElasticSearchRestore) ([]string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	snapshot, err := c.getSnapshotInfo(ctx, object.Spec.Repository, object.Spec.Snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("can't get snapshot %s: %w", object.Spec.Snapshot, err)
+	}
+	if snapshot.State != SnapshotStateSuccess && snapshot.State != SnapshotStatePartial {
+		return nil, fmt.Errorf("snapshot %s can't be restored in state %s", object.Spec.Snapshot, snapshot.State)
+	}
+	indices, err := restoredIndices(object, snapshot.Indices)
+	if err != nil {
+		return nil, err
+	}
+	request := restoreRequest{
+		Indices:             object.Spec.Indices,
+		IgnoreUnavailable:   object.Spec.IgnoreUnavailable,
+		IncludeGlobalState:  object.Spec.IncludeGlobalState,
+		Partial:             object.Spec.Partial,
+		IncludeAliases:      object.Spec.IncludeAliases,
+		RenamePattern:       object.Spec.RenamePattern,
+		RenameReplacement:   object.Spec.RenameReplacement,
+		IgnoreIndexSettings: object.Spec.IgnoreIndexSettings,
+	}
+	if len(object.Spec.IndexSettings) > 0 {
+		err = json.Unmarshal([]byte(object.Spec.IndexSettings), &request.IndexSettings)
+		if err != nil {
+			return nil, fmt.Errorf("can't unmarhsal IndexSettings string: %w", err)
+		}
+	}
+	answer := struct {
+		Accepted bool `json:"accepted"`
+	}{}
+	path := snapshotPath(object.Spec.Repository, object.Spec.Snapshot) + "/_restore?wait_for_completion=false"
+	err = c.perform(ctx, http.MethodPost, path, request, &answer)
+	if err != nil {
+		return nil, fmt.Errorf("can't restore snapshot %s: %w", object.Spec.Snapshot, err)
+	}
+	if !answer.Accepted {
+		return nil, fmt.Errorf("restore of snapshot %s is not accepted", object.Spec.Snapshot)
+	}
+	return indices, nil
+}
+
+// GetRestoreProgress would get recovery progress of primary shards of restored indices
+func (c *Client) GetRestoreProgress(ctx context.Context, indices []string) (*RestoreProgress, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	progress := &RestoreProgress{}
+	if len(indices) == 0 {
+		progress.Done = true
+		return progress, nil
+	}
+	escaped := make([]string, 0, len(indices))
+	for _, index := range indices {
+		escaped = append(escaped, url.PathEscape(index))
+	}
+	recovery := recoveryResponse{}
+	err := c.perform(ctx, http.MethodGet, fmt.Sprintf("/%s/_recovery", strings.Join(escaped, ",")), nil, &recovery)
+	if err != nil {
+		if isNotFound(err) {
+			// Restored indices are not yet created
+			return progress, nil
+		}
+		return nil, fmt.Errorf("can't get recovery of restored indices: %w", err)
+	}
+	progress.Done = true
+	for _, index := range indices {
+		shards, ok := recovery[index]
+		if !ok || len(shards.Shards) == 0 {
+			progress.Done = false
+			continue
+		}
+		for _, shard := range shards.Shards {
+			if !shard.Primary {
+				continue
+			}
+			progress.ShardsTotal++
+			if shard.Stage == "DONE" {
+				progress.ShardsDone++
+			}
+		}
+	}
+	if progress.ShardsDone < progress.ShardsTotal {
+		progress.Done = false
+	}
+	return progress, nil
+}
+
+// restoredIndices would select indices of snapshot the same way ES restore does and apply rename to them
+func restoredIndices(object *xov1alpha1.ElasticSearchRestore, snapshotIndices []string) ([]string, error) {
+	var rename *regexp.Regexp
+	if len(object.Spec.RenamePattern) > 0 {
+		var err error
+		rename, err = regexp.Compile(object.Spec.RenamePattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rename_pattern: %w", err)
+		}
+	}
+	indices := []string{}
+	for _, index := range snapshotIndices {
+		if !restoreSelects(object.Spec.Indices, index) {
+			continue
+		}
+		if rename != nil {
+			index = rename.ReplaceAllString(index, object.Spec.RenameReplacement)
+		}
+		indices = append(indices, index)
+	}
+	if len(indices) == 0 {
+		return nil, errors.New("no indices of snapshot match restore")
+	}
+	sort.Strings(indices)
+	return indices, nil
+}
+
+// restoreSelects would check if index is selected by patterns, patterns starting with - exclude indices.
+// Without patterns all indices except hidden ones are selected.
+func restoreSelects(patterns []string, index string) bool {
+	if len(patterns) == 0 {
+		return !strings.HasPrefix(index, ".")
+	}
+	selected := false
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "-") {
+			if selected && matchPattern(pattern[1:], index) {
+				selected = false
+			}
+			continue
+		}
+		if matchPattern(pattern, index) {
+			selected = true
+		}
+	}
+	return selected
+}
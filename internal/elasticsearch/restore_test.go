@@ -0,0 +1,100 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+func newTestRestore() *xov1alpha1.ElasticSearchRestore {
+	return &xov1alpha1.ElasticSearchRestore{
+		Spec: xov1alpha1.ElasticSearchRestoreSpec{
+			Repository:        "backups",
+			Snapshot:          "orders-before-migration",
+			Indices:           []string{"orders-*"},
+			RenamePattern:     "orders-(.+)",
+			RenameReplacement: "restored-orders-$1",
+			IndexSettings:     `{"index.number_of_replicas":0}`,
+		},
+	}
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups/orders-before-migration",
+			ResponceCode: 200,
+			Responce:     SnapshotGETanswer,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups/orders-before-migration/_restore?wait_for_completion=false",
+			ResponceCode: 200,
+			Responce:     `{"accepted":true}`,
+			RequestBody: `{"indices":["orders-*"],"rename_pattern":"orders-(.+)","rename_replacement":"restored-orders-$1",
+				"index_settings":{"index.number_of_replicas":0}}`,
+		}
+		indices, err := client.RestoreSnapshot(context.Background(), newTestRestore())
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"restored-orders-2024"}, indices)
+		// Snapshot which is still being taken can't be restored
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups/orders-before-migration",
+			ResponceCode: 200,
+			Responce:     SnapshotInProgressGETanswer,
+		}
+		_, err = client.RestoreSnapshot(context.Background(), newTestRestore())
+		assert.EqualError(t, err, "snapshot orders-before-migration can't be restored in state IN_PROGRESS")
+	})
+}
+
+func TestRestoredIndices(t *testing.T) {
+	snapshotIndices := []string{".kibana", "orders-2023", "orders-2024", "users"}
+	restore := &xov1alpha1.ElasticSearchRestore{}
+	indices, err := restoredIndices(restore, snapshotIndices)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"orders-2023", "orders-2024", "users"}, indices)
+
+	restore.Spec.Indices = []string{"orders-*", "-orders-2023"}
+	indices, err = restoredIndices(restore, snapshotIndices)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"orders-2024"}, indices)
+
+	restore.Spec.Indices = []string{"missing-*"}
+	_, err = restoredIndices(restore, snapshotIndices)
+	assert.EqualError(t, err, "no indices of snapshot match restore")
+}
+
+func TestGetRestoreProgress(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/restored-orders-2023,restored-orders-2024/_recovery",
+			ResponceCode: 200,
+			Responce: `{"restored-orders-2023":{"shards":[{"id":0,"type":"SNAPSHOT","stage":"DONE","primary":true},
+				{"id":0,"type":"PEER","stage":"INDEX","primary":false}]},
+				"restored-orders-2024":{"shards":[{"id":0,"type":"SNAPSHOT","stage":"INDEX","primary":true}]}}`,
+		}
+		progress, err := client.GetRestoreProgress(context.Background(), []string{"restored-orders-2023", "restored-orders-2024"})
+		assert.NoError(t, err)
+		assert.Equal(t, &RestoreProgress{ShardsTotal: 2, ShardsDone: 1}, progress)
+		// Indices which are not yet created are not restored
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/restored-orders-2024/_recovery",
+			ResponceCode: 404,
+			Responce:     `{"error":{"type":"index_not_found_exception","reason":"no such index [restored-orders-2024]"},"status":404}`,
+		}
+		progress, err = client.GetRestoreProgress(context.Background(), []string{"restored-orders-2024"})
+		assert.NoError(t, err)
+		assert.False(t, progress.Done)
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/restored-orders-2024/_recovery",
+			ResponceCode: 200,
+			Responce:     `{"restored-orders-2024":{"shards":[{"id":0,"type":"SNAPSHOT","stage":"DONE","primary":true}]}}`,
+		}
+		progress, err = client.GetRestoreProgress(context.Background(), []string{"restored-orders-2024"})
+		assert.NoError(t, err)
+		assert.Equal(t, &RestoreProgress{ShardsTotal: 1, ShardsDone: 1, Done: true}, progress)
+	})
+}
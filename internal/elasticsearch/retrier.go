@@ -0,0 +1,61 @@
+package elasticsearch
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// retryStatusCodes are ES answer statuses on which request is retried
+var retryStatusCodes = []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+
+// isIdempotent would check if request with method is safe to repeat. POST requests like API key creation
+// or policy execution are not retried, their first attempt may have succeeded even though answer was lost.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retrier is elastic.Retrier which retries failed ES requests with capped exponential backoff
+type retrier struct {
+	maxRetries     int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+}
+
+func newRetrier(maxRetries int, initialBackoff, maxBackoff time.Duration) *retrier {
+	return &retrier{
+		maxRetries:     maxRetries,
+		initialBackoff: initialBackoff,
+		maxBackoff:     maxBackoff,
+	}
+}
+
+// Retry would decide if failed request must be retried and how long to wait before it
+func (r *retrier) Retry(ctx context.Context, retry int, req *http.Request, _ *http.Response,
+	_ error) (time.Duration, bool, error) {
+	if retry > r.maxRetries || ctx.Err() != nil || req == nil || !isIdempotent(req.Method) {
+		return 0, false, nil
+	}
+	wait := r.backoff(retry)
+	// Don't wait if operation would time out anyway
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+		return 0, false, nil
+	}
+	return wait, true, nil
+}
+
+// backoff would return wait time before retry number retry, first retry has number 1
+func (r *retrier) backoff(retry int) time.Duration {
+	wait := r.initialBackoff
+	for i := 1; i < retry; i++ {
+		wait *= 2
+		if wait >= r.maxBackoff {
+			return r.maxBackoff
+		}
+	}
+	return wait
+}
@@ -0,0 +1,148 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/consts"
+)
+
+// Role is configuration struct for ES native realm role
+type Role struct {
+	Cluster  []string                           `json:"cluster"`
+	Indices  []xov1alpha1.ESRoleIndexPrivileges `json:"indices"`
+	RunAs    []string                           `json:"run_as"`
+	Metadata map[string]interface{}             `json:"metadata,omitempty"`
+}
+
+func rolePath(name string) string {
+	return fmt.Sprintf("/_security/role/%s", url.PathEscape(name))
+}
+
+// RoleExists would check if role exists
+func (c *Client) RoleExists(ctx context.Context, name string) (bool, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "security role", FlavorElasticsearch)
+	if err != nil {
+		return false, err
+	}
+	_, err = c.getRole(ctx, name)
+	if errors.Is(err, errObjectNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("can't check if role exists: %w", err)
+	}
+	return true, nil
+}
+
+// CreateUpdateRole would update role if it exists or create if not.
+// Role is updated whenever it differs from spec, so privileges granted outside of operator are revoked.
+func (c *Client) CreateUpdateRole(ctx context.Context, object *xov1alpha1.ElasticSearchRole) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "security role", FlavorElasticsearch)
+	if err != nil {
+		return "", err
+	}
+	role := newRole(object)
+	retMsg := "successfully created role %s"
+	servRole, err := c.getRole(ctx, object.Spec.Name)
+	if err != nil && !errors.Is(err, errObjectNotFound) {
+		return "", fmt.Errorf("can't get role: %w", err)
+	}
+	if servRole != nil {
+		managedBy, _ := getStringValueFromSettings(servRole.Metadata, consts.ESManagedByField)
+		if managedBy != consts.ESManagedByValue {
+			return "", fmt.Errorf("role '%s' is not managed by this operator", object.Spec.Name)
+		}
+		// Metadata is only compared for managed-by
+		servRole.Metadata = role.Metadata
+		if reflect.DeepEqual(*servRole, *role) {
+			return fmt.Sprintf("no changes on role named %s", object.Spec.Name), nil
+		}
+		retMsg = "successfully updated role %s"
+	}
+	err = c.perform(ctx, http.MethodPut, rolePath(object.Spec.Name), role, nil)
+	if err != nil {
+		return "", fmt.Errorf("can't put role: %w", err)
+	}
+	return fmt.Sprintf(retMsg, object.Spec.Name), nil
+}
+
+// DeleteRole would delete role, role which doesn't exist or is not managed by operator is not deleted
+func (c *Client) DeleteRole(ctx context.Context, name string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "security role", FlavorElasticsearch)
+	if err != nil {
+		return err
+	}
+	servRole, err := c.getRole(ctx, name)
+	if errors.Is(err, errObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("can't get role %s: %w", name, err)
+	}
+	managedBy, _ := getStringValueFromSettings(servRole.Metadata, consts.ESManagedByField)
+	if managedBy != consts.ESManagedByValue {
+		return fmt.Errorf("role '%s' is %w", name, ErrNotManaged)
+	}
+	err = c.perform(ctx, http.MethodDelete, rolePath(name), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("can't delete role %s: %w", name, err)
+	}
+	return nil
+}
+
+func (c *Client) getRole(ctx context.Context, name string) (*Role, error) {
+	roles := map[string]Role{}
+	err := c.perform(ctx, http.MethodGet, rolePath(name), nil, &roles)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errObjectNotFound
+		}
+		return nil, err
+	}
+	role, ok := roles[name]
+	if !ok {
+		return nil, errObjectNotFound
+	}
+	normalizeRole(&role)
+	return &role, nil
+}
+
+// newRole would make role ES understands from K8S object
+func newRole(object *xov1alpha1.ElasticSearchRole) *Role {
+	role := &Role{
+		Cluster: object.Spec.Cluster,
+		Indices: object.Spec.Indices,
+		RunAs:   object.Spec.RunAs,
+		// Metadata marks role as managed by operator
+		Metadata: map[string]interface{}{
+			consts.ESManagedByField: consts.ESManagedByValue,
+		},
+	}
+	normalizeRole(role)
+	return role
+}
+
+// normalizeRole would replace missing lists with empty ones, so role from spec and role ES returns are comparable
+func normalizeRole(role *Role) {
+	if role.Cluster == nil {
+		role.Cluster = []string{}
+	}
+	if role.Indices == nil {
+		role.Indices = []xov1alpha1.ESRoleIndexPrivileges{}
+	}
+	if role.RunAs == nil {
+		role.RunAs = []string{}
+	}
+}
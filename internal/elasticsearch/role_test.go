@@ -0,0 +1,143 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+const (
+	// Used by Doer to mock ES answer on get role request
+	RoleGETanswer = `{"orders-reader":{"cluster":["monitor"],
+		"indices":[{"names":["orders-*"],"privileges":["read"],"field_security":{"grant":["*"],"except":["customer.email"]},"allow_restricted_indices":false}],
+		"applications":[],"run_as":[],"metadata":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"},"transient_metadata":{"enabled":true}}}`
+	// Used by Doer to mock ES answer on get role request for reserved role
+	RoleReservedGETanswer = `{"orders-reader":{"cluster":["all"],"indices":[],"applications":[],"run_as":[],"metadata":{"_reserved":true},"transient_metadata":{"enabled":true}}}`
+	// Used by Doer to mock ES answer on role which doesn't exist
+	RoleNotFoundAnswer = `{}`
+)
+
+func newTestRole(privileges ...string) *xov1alpha1.ElasticSearchRole {
+	return &xov1alpha1.ElasticSearchRole{
+		Spec: xov1alpha1.ElasticSearchRoleSpec{
+			Name:    "orders-reader",
+			Cluster: []string{"monitor"},
+			Indices: []xov1alpha1.ESRoleIndexPrivileges{
+				{
+					Names:      []string{"orders-*"},
+					Privileges: privileges,
+					FieldSecurity: &xov1alpha1.ESRoleFieldSecurity{
+						Grant:  []string{"*"},
+						Except: []string{"customer.email"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestCreateUpdateRole(t *testing.T) {
+	roleBody := `{"cluster":["monitor"],
+		"indices":[{"names":["orders-*"],"privileges":["read","view_index_metadata"],"field_security":{"grant":["*"],"except":["customer.email"]}}],
+		"run_as":[],"metadata":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}`
+	tests := []requestTest[*xov1alpha1.ElasticSearchRole, string]{
+		{
+			Object: newTestRole("read", "view_index_metadata"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_security/role/orders-reader",
+					ResponceCode: 404,
+					Responce:     RoleNotFoundAnswer,
+				},
+				{
+					RequestURI:   "/_security/role/orders-reader",
+					ResponceCode: 200,
+					Responce:     `{"role":{"created":true}}`,
+					RequestBody:  roleBody,
+				},
+			},
+			Want: "successfully created role orders-reader",
+		},
+		{
+			Object: newTestRole("read"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_security/role/orders-reader",
+					ResponceCode: 200,
+					Responce:     RoleGETanswer,
+				},
+			},
+			Want: "no changes on role named orders-reader",
+		},
+		{
+			// Privileges are drifted
+			Object: newTestRole("read", "view_index_metadata"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_security/role/orders-reader",
+					ResponceCode: 200,
+					Responce:     RoleGETanswer,
+				},
+				{
+					RequestURI:   "/_security/role/orders-reader",
+					ResponceCode: 200,
+					Responce:     `{"role":{"created":false}}`,
+					RequestBody:  roleBody,
+				},
+			},
+			Want: "successfully updated role orders-reader",
+		},
+		{
+			Object: newTestRole("read"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_security/role/orders-reader",
+					ResponceCode: 200,
+					Responce:     RoleReservedGETanswer,
+				},
+			},
+			Err: "role 'orders-reader' is not managed by this operator",
+		},
+	}
+	runRequestTests(t, tests, (*Client).CreateUpdateRole)
+}
+
+func TestDeleteRole(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_security/role/orders-reader",
+			ResponceCode: 200,
+			Responce:     RoleGETanswer,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_security/role/orders-reader",
+			ResponceCode: 200,
+			Responce:     `{"found":true}`,
+		}
+		assert.NoError(t, client.DeleteRole(context.Background(), "orders-reader"))
+		// Role which is not managed by operator is never deleted
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_security/role/orders-reader",
+			ResponceCode: 200,
+			Responce:     RoleReservedGETanswer,
+		}
+		assert.ErrorIs(t, client.DeleteRole(context.Background(), "orders-reader"), ErrNotManaged)
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_security/role/orders-reader",
+			ResponceCode: 404,
+			Responce:     RoleNotFoundAnswer,
+		}
+		assert.NoError(t, client.DeleteRole(context.Background(), "orders-reader"))
+	})
+}
+
+func TestRoleOpenSearch(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		client.flavor = FlavorOpenSearch
+		_, err := client.CreateUpdateRole(context.Background(), newTestRole("read"))
+		assert.EqualError(t, err, "security role is not supported by opensearch cluster")
+	})
+}
@@ -0,0 +1,178 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/consts"
+)
+
+// RoleMapping is configuration struct for ES role mapping
+type RoleMapping struct {
+	Enabled       bool                   `json:"enabled"`
+	Roles         []string               `json:"roles,omitempty"`
+	RoleTemplates []RoleMappingTemplate  `json:"role_templates,omitempty"`
+	Rules         interface{}            `json:"rules"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// RoleMappingTemplate is role template of role mapping
+type RoleMappingTemplate struct {
+	Template interface{} `json:"template"`
+	Format   string      `json:"format"`
+}
+
+func roleMappingPath(name string) string {
+	return fmt.Sprintf("/_security/role_mapping/%s", url.PathEscape(name))
+}
+
+// RoleMappingExists would check if role mapping exists
+func (c *Client) RoleMappingExists(ctx context.Context, name string) (bool, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "role mapping", FlavorElasticsearch)
+	if err != nil {
+		return false, err
+	}
+	_, err = c.getRoleMapping(ctx, name)
+	if errors.Is(err, errObjectNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("can't check if role mapping exists: %w", err)
+	}
+	return true, nil
+}
+
+// CreateUpdateRoleMapping would update role mapping if it exists or create if not.
+// Role mapping is updated whenever it differs from spec, so changes made outside of operator are reverted.
+func (c *Client) CreateUpdateRoleMapping(ctx context.Context, object *xov1alpha1.ElasticSearchRoleMapping) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "role mapping", FlavorElasticsearch)
+	if err != nil {
+		return "", err
+	}
+	mapping, err := newRoleMapping(object)
+	if err != nil {
+		return "", err
+	}
+	retMsg := "successfully created role mapping %s"
+	servMapping, err := c.getRoleMapping(ctx, object.Spec.Name)
+	if err != nil && !errors.Is(err, errObjectNotFound) {
+		return "", fmt.Errorf("can't get role mapping: %w", err)
+	}
+	if servMapping != nil {
+		managedBy, _ := getStringValueFromSettings(servMapping.Metadata, consts.ESManagedByField)
+		if managedBy != consts.ESManagedByValue {
+			return "", fmt.Errorf("role mapping '%s' is not managed by this operator", object.Spec.Name)
+		}
+		// Metadata is only compared for managed-by
+		servMapping.Metadata = mapping.Metadata
+		if reflect.DeepEqual(*servMapping, *mapping) {
+			return fmt.Sprintf("no changes on role mapping named %s", object.Spec.Name), nil
+		}
+		retMsg = "successfully updated role mapping %s"
+	}
+	err = c.perform(ctx, http.MethodPut, roleMappingPath(object.Spec.Name), mapping, nil)
+	if err != nil {
+		return "", fmt.Errorf("can't put role mapping: %w", err)
+	}
+	return fmt.Sprintf(retMsg, object.Spec.Name), nil
+}
+
+// DeleteRoleMapping would delete role mapping, role mapping which doesn't exist or is not managed by operator is not deleted
+func (c *Client) DeleteRoleMapping(ctx context.Context, name string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "role mapping", FlavorElasticsearch)
+	if err != nil {
+		return err
+	}
+	servMapping, err := c.getRoleMapping(ctx, name)
+	if errors.Is(err, errObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("can't get role mapping %s: %w", name, err)
+	}
+	managedBy, _ := getStringValueFromSettings(servMapping.Metadata, consts.ESManagedByField)
+	if managedBy != consts.ESManagedByValue {
+		return fmt.Errorf("role mapping '%s' is %w", name, ErrNotManaged)
+	}
+	err = c.perform(ctx, http.MethodDelete, roleMappingPath(name), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("can't delete role mapping %s: %w", name, err)
+	}
+	return nil
+}
+
+func (c *Client) getRoleMapping(ctx context.Context, name string) (*RoleMapping, error) {
+	mappings := map[string]RoleMapping{}
+	err := c.perform(ctx, http.MethodGet, roleMappingPath(name), nil, &mappings)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errObjectNotFound
+		}
+		return nil, err
+	}
+	mapping, ok := mappings[name]
+	if !ok {
+		return nil, errObjectNotFound
+	}
+	normalizeRoleMapping(&mapping)
+	return &mapping, nil
+}
+
+// newRoleMapping would make role mapping ES understands from K8S object
+func newRoleMapping(object *xov1alpha1.ElasticSearchRoleMapping) (*RoleMapping, error) {
+	if len(object.Spec.Roles) == 0 && len(object.Spec.RoleTemplates) == 0 {
+		return nil, fmt.Errorf("either roles or role_templates must be set for role mapping %s", object.Spec.Name)
+	}
+	mapping := &RoleMapping{
+		Enabled: object.Spec.Enabled == nil || *object.Spec.Enabled,
+		Roles:   object.Spec.Roles,
+		// Metadata marks role mapping as managed by operator
+		Metadata: map[string]interface{}{
+			consts.ESManagedByField: consts.ESManagedByValue,
+		},
+	}
+	err := json.Unmarshal([]byte(object.Spec.Rules), &mapping.Rules)
+	if err != nil {
+		return nil, fmt.Errorf("can't unmarhsal Rules string: %w", err)
+	}
+	for _, template := range object.Spec.RoleTemplates {
+		roleTemplate := RoleMappingTemplate{
+			Format: template.Format,
+		}
+		err = json.Unmarshal([]byte(template.Template), &roleTemplate.Template)
+		if err != nil {
+			return nil, fmt.Errorf("can't unmarhsal Template string: %w", err)
+		}
+		mapping.RoleTemplates = append(mapping.RoleTemplates, roleTemplate)
+	}
+	normalizeRoleMapping(mapping)
+	return mapping, nil
+}
+
+// normalizeRoleMapping would replace missing lists and defaults with values ES returns, so role mapping from spec
+// and role mapping ES returns are comparable
+func normalizeRoleMapping(mapping *RoleMapping) {
+	if mapping.Roles == nil {
+		mapping.Roles = []string{}
+	}
+	if mapping.RoleTemplates == nil {
+		mapping.RoleTemplates = []RoleMappingTemplate{}
+	}
+	for i := range mapping.RoleTemplates {
+		if len(mapping.RoleTemplates[i].Format) == 0 {
+			mapping.RoleTemplates[i].Format = "string"
+		}
+	}
+}
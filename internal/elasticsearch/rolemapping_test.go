@@ -0,0 +1,137 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+const (
+	// Used by Doer to mock ES answer on get role mapping request
+	RoleMappingGETanswer = `{"saml-admins":{"enabled":true,"roles":["superuser"],
+		"rules":{"all":[{"field":{"realm.name":"saml1"}},{"field":{"groups":"admins"}}]},
+		"metadata":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}}`
+	// Used by Doer to mock ES answer on get role mapping request for role mapping created outside of operator
+	RoleMappingNotManagedGETanswer = `{"saml-admins":{"enabled":true,"roles":["superuser"],"rules":{"field":{"username":"*"}},"metadata":{}}}`
+)
+
+func newTestRoleMapping(roles ...string) *xov1alpha1.ElasticSearchRoleMapping {
+	return &xov1alpha1.ElasticSearchRoleMapping{
+		Spec: xov1alpha1.ElasticSearchRoleMappingSpec{
+			Name:  "saml-admins",
+			Rules: `{"all":[{"field":{"realm.name":"saml1"}},{"field":{"groups":"admins"}}]}`,
+			Roles: roles,
+		},
+	}
+}
+
+func TestCreateUpdateRoleMapping(t *testing.T) {
+	templates := newTestRoleMapping()
+	templates.Spec.RoleTemplates = []xov1alpha1.ESRoleMappingTemplate{
+		{
+			Template: `{"source":"{{#tojson}}groups{{/tojson}}"}`,
+			Format:   "json",
+		},
+	}
+	tests := []requestTest[*xov1alpha1.ElasticSearchRoleMapping, string]{
+		{
+			Object: newTestRoleMapping("superuser"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_security/role_mapping/saml-admins",
+					ResponceCode: 404,
+					Responce:     `{}`,
+				},
+				{
+					RequestURI:   "/_security/role_mapping/saml-admins",
+					ResponceCode: 200,
+					Responce:     `{"role_mapping":{"created":true}}`,
+					RequestBody: `{"enabled":true,"roles":["superuser"],
+						"rules":{"all":[{"field":{"realm.name":"saml1"}},{"field":{"groups":"admins"}}]},
+						"metadata":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}`,
+				},
+			},
+			Want: "successfully created role mapping saml-admins",
+		},
+		{
+			Object: newTestRoleMapping("superuser"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_security/role_mapping/saml-admins",
+					ResponceCode: 200,
+					Responce:     RoleMappingGETanswer,
+				},
+			},
+			Want: "no changes on role mapping named saml-admins",
+		},
+		{
+			// Roles are replaced with role templates
+			Object: templates,
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_security/role_mapping/saml-admins",
+					ResponceCode: 200,
+					Responce:     RoleMappingGETanswer,
+				},
+				{
+					RequestURI:   "/_security/role_mapping/saml-admins",
+					ResponceCode: 200,
+					Responce:     `{"role_mapping":{"created":false}}`,
+					RequestBody: `{"enabled":true,"role_templates":[{"template":{"source":"{{#tojson}}groups{{/tojson}}"},"format":"json"}],
+						"rules":{"all":[{"field":{"realm.name":"saml1"}},{"field":{"groups":"admins"}}]},
+						"metadata":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}`,
+				},
+			},
+			Want: "successfully updated role mapping saml-admins",
+		},
+		{
+			Object: newTestRoleMapping("superuser"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_security/role_mapping/saml-admins",
+					ResponceCode: 200,
+					Responce:     RoleMappingNotManagedGETanswer,
+				},
+			},
+			Err: "role mapping 'saml-admins' is not managed by this operator",
+		},
+		{
+			Object: newTestRoleMapping(),
+			Err:    "either roles or role_templates must be set for role mapping saml-admins",
+		},
+	}
+	runRequestTests(t, tests, (*Client).CreateUpdateRoleMapping)
+}
+
+func TestDeleteRoleMapping(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_security/role_mapping/saml-admins",
+			ResponceCode: 200,
+			Responce:     RoleMappingNotManagedGETanswer,
+		}
+		assert.ErrorIs(t, client.DeleteRoleMapping(context.Background(), "saml-admins"), ErrNotManaged)
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_security/role_mapping/saml-admins",
+			ResponceCode: 200,
+			Responce:     RoleMappingGETanswer,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_security/role_mapping/saml-admins",
+			ResponceCode: 200,
+			Responce:     `{"found":true}`,
+		}
+		assert.NoError(t, client.DeleteRoleMapping(context.Background(), "saml-admins"))
+	})
+}
+
+func TestRoleMappingOpenSearch(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		client.flavor = FlavorOpenSearch
+		_, err := client.CreateUpdateRoleMapping(context.Background(), newTestRoleMapping("superuser"))
+		assert.EqualError(t, err, "role mapping is not supported by opensearch cluster")
+	})
+}
@@ -0,0 +1,287 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+// rolloverRequest is body of ES rollover API, new index gets settings and mappings from spec
+type rolloverRequest struct {
+	Conditions map[string]interface{} `json:"conditions"`
+	Settings   Settings               `json:"settings"`
+	Mappings   map[string]interface{} `json:"mappings"`
+}
+
+// rolloverResponse is answer of ES rollover API
+type rolloverResponse struct {
+	Acknowledged bool   `json:"acknowledged"`
+	OldIndex     string `json:"old_index"`
+	NewIndex     string `json:"new_index"`
+	RolledOver   bool   `json:"rolled_over"`
+}
+
+// rolloverIndexName would return name of index generation
+func rolloverIndexName(prefix string, generation int) string {
+	return fmt.Sprintf("%s-%06d", prefix, generation)
+}
+
+// rolloverGenerationRegexps keeps compiled regexp matching generations of every prefix
+var rolloverGenerationRegexps sync.Map
+
+// rolloverGenerationRegexp would return regexp matching generations of prefix, it is compiled once per prefix
+func rolloverGenerationRegexp(prefix string) *regexp.Regexp {
+	re, ok := rolloverGenerationRegexps.Load(prefix)
+	if !ok {
+		re, _ = rolloverGenerationRegexps.LoadOrStore(prefix,
+			regexp.MustCompile("^"+regexp.QuoteMeta(prefix)+`-(\d+)$`))
+	}
+	return re.(*regexp.Regexp)
+}
+
+// rolloverGeneration would return generation of index with prefix or false if index is not generation of prefix
+func rolloverGeneration(prefix, index string) (int, bool) {
+	match := rolloverGenerationRegexp(prefix).FindStringSubmatch(index)
+	if match == nil {
+		return 0, false
+	}
+	generation, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, false
+	}
+	return generation, true
+}
+
+// createUpdateRolloverIndex would bootstrap first index generation with write alias or update current
+// write index, rollover it when conditions are met and prune old generations
+func (c *Client) createUpdateRolloverIndex(ctx context.Context, object *xov1alpha1.ElasticSearchIndex,
+	analysis map[string]interface{}) (string, error) {
+	alias := object.Spec.Name
+	writeIndex, err := c.getWriteIndex(ctx, alias)
+	if errors.Is(err, errObjectNotFound) {
+		// Bootstrap first generation
+		first := object.DeepCopy()
+		first.Spec.Name = rolloverIndexName(alias, 1)
+		_, err = c.createIndex(ctx, first, map[string]ESAlias{
+			alias: {IsWriteIndex: true},
+		}, analysis)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("successfully created ES index %s with write alias %s", first.Spec.Name, alias), nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("can't get write index of alias %s: %w", alias, err)
+	}
+	if _, ok := rolloverGeneration(alias, writeIndex); !ok {
+		return "", fmt.Errorf("write index %s of alias %s is not generation of %s-NNNNNN", writeIndex, alias, alias)
+	}
+	// Settings and mappings are applied to current write index
+	current := object.DeepCopy()
+	current.Spec.Name = writeIndex
+	msg, err := c.createUpdateIndex(ctx, current, analysis)
+	if err != nil {
+		return "", err
+	}
+	newIndex, err := c.rollover(ctx, object, analysis)
+	if err != nil {
+		return "", err
+	}
+	if len(newIndex) > 0 {
+		msg = fmt.Sprintf("successfully rolled over ES index %s from %s to %s", alias, writeIndex, newIndex)
+		writeIndex = newIndex
+	}
+	if object.Spec.Backup != nil {
+		// Old generations are deleted by controller once backup snapshot of them is taken
+		return msg, nil
+	}
+	pruned, err := c.pruneRolloverIndices(ctx, alias, writeIndex, object.Spec.Rollover.Retention)
+	if err != nil {
+		return "", err
+	}
+	if len(pruned) > 0 {
+		msg = fmt.Sprintf("%s, deleted old ES indices %s", msg, strings.Join(pruned, ","))
+	}
+	return msg, nil
+}
+
+// getWriteIndex would get write index of alias, it is index with is_write_index or the only index of alias
+func (c *Client) getWriteIndex(ctx context.Context, alias string) (string, error) {
+	indices, err := c.getServerAlias(ctx, alias)
+	if err != nil {
+		return "", err
+	}
+	for index, details := range indices {
+		if details.IsWriteIndex != nil && *details.IsWriteIndex {
+			return index, nil
+		}
+	}
+	if len(indices) == 1 {
+		for index := range indices {
+			return index, nil
+		}
+	}
+	return "", fmt.Errorf("alias %s has no write index", alias)
+}
+
+// rollover would rollover write alias if any of conditions is met, it returns name of new write index
+// or empty string if conditions are not met
+func (c *Client) rollover(ctx context.Context, object *xov1alpha1.ElasticSearchIndex,
+	analysis map[string]interface{}) (string, error) {
+	spec := object.Spec.Rollover
+	conditions := map[string]interface{}{}
+	if len(spec.MaxAge) > 0 {
+		conditions["max_age"] = spec.MaxAge
+	}
+	if spec.MaxDocs > 0 {
+		conditions["max_docs"] = spec.MaxDocs
+	}
+	if len(spec.MaxPrimaryShardSize) > 0 {
+		conditions["max_primary_shard_size"] = spec.MaxPrimaryShardSize
+	}
+	if len(conditions) == 0 {
+		// Without conditions rollover would happen on every reconcile
+		return "", nil
+	}
+	request := rolloverRequest{
+		Conditions: conditions,
+		Settings: Settings{
+			Index:    object.Spec.Settings,
+			Analysis: analysis,
+		},
+	}
+	var err error
+	request.Mappings, err = addManagedBy2Interface(object.Spec.Mappings)
+	if err != nil {
+		return "", fmt.Errorf("can't add managed-by 2 ES index: %w", err)
+	}
+	answer := rolloverResponse{}
+	err = c.perform(ctx, http.MethodPost, indexPath(object.Spec.Name)+"/_rollover", request, &answer)
+	if err != nil {
+		return "", fmt.Errorf("can't rollover ES index %s: %w", object.Spec.Name, err)
+	}
+	if !answer.RolledOver {
+		return "", nil
+	}
+	return answer.NewIndex, nil
+}
+
+// NextRollover would get time when write index of rollover index reaches max_age condition.
+// Zero time is returned if index has no max_age, other conditions can't be predicted.
+func (c *Client) NextRollover(ctx context.Context, object *xov1alpha1.ElasticSearchIndex) (time.Time, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	if object.Spec.Rollover == nil || len(object.Spec.Rollover.MaxAge) == 0 {
+		return time.Time{}, nil
+	}
+	maxAge, err := parseTimeUnits(object.Spec.Rollover.MaxAge)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid max_age of ES index %s: %w", object.Spec.Name, err)
+	}
+	writeIndex, err := c.getWriteIndex(ctx, object.Spec.Name)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("can't get write index of alias %s: %w", object.Spec.Name, err)
+	}
+	settings, _, err := c.getServerIndexSettingsAndMappings(ctx, writeIndex)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("can't get ES index %s: %w", writeIndex, err)
+	}
+	created, ok := getInt64ValueFromSettings(settings, "index.creation_date")
+	if !ok {
+		return time.Time{}, fmt.Errorf("ES index %s has no creation date", writeIndex)
+	}
+	return time.UnixMilli(created).Add(maxAge), nil
+}
+
+// ExpiredRolloverIndices would get generations of rollover index older than retention count,
+// they are deleted by CreateUpdateIndex unless index has backup.
+// Write index and indices not managed by operator are never expired.
+func (c *Client) ExpiredRolloverIndices(ctx context.Context, object *xov1alpha1.ElasticSearchIndex) ([]string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	if object.Spec.Rollover == nil || object.Spec.Rollover.Retention <= 0 {
+		return nil, nil
+	}
+	writeIndex, err := c.getWriteIndex(ctx, object.Spec.Name)
+	if err != nil {
+		return nil, fmt.Errorf("can't get write index of alias %s: %w", object.Spec.Name, err)
+	}
+	return c.expiredRolloverIndices(ctx, object.Spec.Name, writeIndex, object.Spec.Rollover.Retention)
+}
+
+// pruneRolloverIndices would delete generations of alias older than retention count
+func (c *Client) pruneRolloverIndices(ctx context.Context, alias, writeIndex string, retention int64) ([]string, error) {
+	if retention <= 0 {
+		return nil, nil
+	}
+	expired, err := c.expiredRolloverIndices(ctx, alias, writeIndex, retention)
+	if err != nil {
+		return nil, err
+	}
+	pruned := []string{}
+	for _, index := range expired {
+		err = c.perform(ctx, http.MethodDelete, indexPath(index), nil, nil)
+		if err != nil && !isNotFound(err) {
+			return pruned, fmt.Errorf("can't delete old ES index %s: %w", index, err)
+		}
+		pruned = append(pruned, index)
+	}
+	return pruned, nil
+}
+
+// expiredRolloverIndices would get managed generations of alias older than retention count except write index
+func (c *Client) expiredRolloverIndices(ctx context.Context, alias, writeIndex string, retention int64) ([]string, error) {
+	generations, err := c.rolloverGenerations(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+	expired := []string{}
+	for i, index := range generations {
+		if int64(i) < retention || index == writeIndex {
+			continue
+		}
+		expired = append(expired, index)
+	}
+	return expired, nil
+}
+
+// rolloverGenerations would get generations of alias managed by operator, newest generation first
+func (c *Client) rolloverGenerations(ctx context.Context, alias string) ([]string, error) {
+	mappings := map[string]indexGetResponse{}
+	err := c.perform(ctx, http.MethodGet, indexPath(alias+"-*")+"/_mapping", nil, &mappings)
+	if err != nil {
+		return nil, fmt.Errorf("can't get ES index generations of %s: %w", alias, err)
+	}
+	type generation struct {
+		index      string
+		generation int
+	}
+	generations := []generation{}
+	for index := range mappings {
+		gen, ok := rolloverGeneration(alias, index)
+		if ok {
+			generations = append(generations, generation{index: index, generation: gen})
+		}
+	}
+	// Newest generations first
+	sort.Slice(generations, func(i, j int) bool {
+		return generations[i].generation > generations[j].generation
+	})
+	indices := []string{}
+	for _, gen := range generations {
+		if !isManagedByESOperator(mappings[gen.index].Mappings) {
+			continue
+		}
+		indices = append(indices, gen.index)
+	}
+	return indices, nil
+}
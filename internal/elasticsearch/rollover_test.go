@@ -0,0 +1,272 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+const (
+	// Used by Doer to mock ES answer on get index request of current write index
+	RolloverIndexGETanswer = `{"logs-000002":{"aliases":{"logs":{"is_write_index":true}},"mappings":{"_meta":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"},"properties":{"message":{"type":"text"}}},"settings":{"index":{"number_of_shards":"1","number_of_replicas":"1","provided_name":"logs-000002"}}}}`
+	// Used by Doer to mock ES answer on get mapping request of all generations
+	RolloverMappingGETanswer = `{
+		"logs-000001":{"mappings":{"_meta":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}},
+		"logs-000002":{"mappings":{"_meta":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}},
+		"logs-000003":{"mappings":{"_meta":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}},
+		"logs-old":{"mappings":{}},
+		"logs-000000":{"mappings":{}}
+	}`
+	// Settings of new index generation as operator sends them
+	RolloverSettingsBody = `{"index":{"number_of_shards":1,"shard":{},"number_of_replicas":1,"search":{"idle":{}},"blocks":{},"analyze":{},"highlight":{},"routing":{"allocation":{},"rebalance":{}}}}`
+)
+
+type TestRollover struct {
+	Index *xov1alpha1.ElasticSearchIndex
+	R2R   []Responce2Req
+	Err   error
+	Msg   string
+}
+
+func newTestRolloverIndex(rollover xov1alpha1.ESIndexRollover) *xov1alpha1.ElasticSearchIndex {
+	return &xov1alpha1.ElasticSearchIndex{
+		Spec: xov1alpha1.ElasticSearchIndexSpec{
+			Name: "logs",
+			Settings: xov1alpha1.ESIndexSettings{
+				NumOfShards:   1,
+				NumOfReplicas: 1,
+			},
+			Mappings: `{"properties":{"message":{"type":"text"}}}`,
+			Rollover: &rollover,
+		},
+	}
+}
+
+func TestCreateUpdateRolloverIndex(t *testing.T) {
+	rolloverBody := `{
+		"conditions":{"max_age":"7d","max_docs":1000},
+		"settings":` + RolloverSettingsBody + `,
+		"mappings":{"_meta":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"},"properties":{"message":{"type":"text"}}}
+	}`
+	tests := []TestRollover{
+		{
+			// Bootstrap of first generation with write alias
+			Index: newTestRolloverIndex(xov1alpha1.ESIndexRollover{MaxAge: "7d"}),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_alias/logs",
+					ResponceCode: 404,
+					Responce:     `{"error":"alias [logs] missing","status":404}`,
+				},
+				{
+					RequestURI:   "/logs-000001",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true,"shards_acknowledged":true,"index":"logs-000001"}`,
+					RequestBody: `{
+						"settings":` + RolloverSettingsBody + `,
+						"mappings":{"_meta":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"},"properties":{"message":{"type":"text"}}},
+						"aliases":{"logs":{"is_write_index":true}}
+					}`,
+				},
+			},
+			Msg: "successfully created ES index logs-000001 with write alias logs",
+		},
+		{
+			// Conditions are not met
+			Index: newTestRolloverIndex(xov1alpha1.ESIndexRollover{MaxAge: "7d", MaxDocs: 1000}),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_alias/logs",
+					ResponceCode: 200,
+					Responce:     `{"logs-000001":{"aliases":{"logs":{"is_write_index":false}}},"logs-000002":{"aliases":{"logs":{"is_write_index":true}}}}`,
+				},
+				{
+					RequestURI:   "/logs-000002",
+					ResponceCode: 200,
+					Responce:     RolloverIndexGETanswer,
+				},
+				{
+					RequestURI:   "/logs/_rollover",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":false,"shards_acknowledged":false,"old_index":"logs-000002","new_index":"logs-000003","rolled_over":false,"dry_run":false,"conditions":{"[max_age: 7d]":false,"[max_docs: 1000]":false}}`,
+					RequestBody:  rolloverBody,
+				},
+			},
+			Msg: "no changes on index named logs-000002",
+		},
+		{
+			// Write index is rolled over and generations over retention are deleted
+			Index: newTestRolloverIndex(xov1alpha1.ESIndexRollover{MaxAge: "7d", MaxDocs: 1000, Retention: 2}),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_alias/logs",
+					ResponceCode: 200,
+					Responce:     `{"logs-000001":{"aliases":{"logs":{}}},"logs-000002":{"aliases":{"logs":{"is_write_index":true}}}}`,
+				},
+				{
+					RequestURI:   "/logs-000002",
+					ResponceCode: 200,
+					Responce:     RolloverIndexGETanswer,
+				},
+				{
+					RequestURI:   "/logs/_rollover",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true,"shards_acknowledged":true,"old_index":"logs-000002","new_index":"logs-000003","rolled_over":true,"dry_run":false,"conditions":{"[max_age: 7d]":true,"[max_docs: 1000]":false}}`,
+					RequestBody:  rolloverBody,
+				},
+				{
+					RequestURI:   "/logs-%2A/_mapping",
+					ResponceCode: 200,
+					Responce:     RolloverMappingGETanswer,
+				},
+				{
+					RequestURI:   "/logs-000001",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+				},
+			},
+			Msg: "successfully rolled over ES index logs from logs-000002 to logs-000003, deleted old ES indices logs-000001",
+		},
+		{
+			// Generation which is not managed by operator is kept
+			Index: newTestRolloverIndex(xov1alpha1.ESIndexRollover{Retention: 1}),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_alias/logs",
+					ResponceCode: 200,
+					Responce:     `{"logs-000002":{"aliases":{"logs":{}}}}`,
+				},
+				{
+					RequestURI:   "/logs-000002",
+					ResponceCode: 200,
+					Responce:     RolloverIndexGETanswer,
+				},
+				{
+					RequestURI:   "/logs-%2A/_mapping",
+					ResponceCode: 200,
+					Responce:     `{"logs-000001":{"mappings":{}},"logs-000002":{"mappings":{"_meta":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}}}`,
+				},
+			},
+			Msg: "no changes on index named logs-000002",
+		},
+		{
+			Index: newTestRolloverIndex(xov1alpha1.ESIndexRollover{MaxAge: "7d"}),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_alias/logs",
+					ResponceCode: 200,
+					Responce:     `{"logs":{"aliases":{"logs":{"is_write_index":true}}}}`,
+				},
+			},
+			Err: fmt.Errorf("write index logs of alias logs is not generation of logs-NNNNNN"),
+		},
+		{
+			Index: newTestRolloverIndex(xov1alpha1.ESIndexRollover{MaxAge: "7d"}),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_alias/logs",
+					ResponceCode: 200,
+					Responce:     `{"logs-000001":{"aliases":{"logs":{}}},"logs-000002":{"aliases":{"logs":{}}}}`,
+				},
+			},
+			Err: fmt.Errorf("can't get write index of alias logs: alias logs has no write index"),
+		},
+	}
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		for _, test := range tests {
+			for _, r2r := range test.R2R {
+				testDoer.R2rChan <- r2r
+			}
+			msg, err := client.CreateUpdateIndex(context.Background(), test.Index, nil)
+			if test.Err != nil {
+				assert.EqualError(t, err, fmt.Sprintf("%s", test.Err))
+				continue
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.Msg, msg)
+		}
+	})
+}
+
+func TestRolloverGeneration(t *testing.T) {
+	assert.Equal(t, "logs-000012", rolloverIndexName("logs", 12))
+	generation, ok := rolloverGeneration("logs", "logs-000012")
+	assert.True(t, ok)
+	assert.Equal(t, 12, generation)
+	_, ok = rolloverGeneration("logs", "logs-old")
+	assert.False(t, ok)
+	_, ok = rolloverGeneration("logs.v1", "logsxv1-000001")
+	assert.False(t, ok)
+	// Regexp is compiled once per prefix
+	assert.Same(t, rolloverGenerationRegexp("logs"), rolloverGenerationRegexp("logs"))
+}
+
+func TestNextRollover(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_alias/logs",
+			ResponceCode: 200,
+			Responce:     `{"logs-000002":{"aliases":{"logs":{"is_write_index":true}}},"logs-000001":{"aliases":{"logs":{}}}}`,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/logs-000002",
+			ResponceCode: 200,
+			Responce:     `{"logs-000002":{"settings":{"index":{"creation_date":"1700000000000","provided_name":"logs-000002"}}}}`,
+		}
+		next, err := client.NextRollover(context.Background(),
+			newTestRolloverIndex(xov1alpha1.ESIndexRollover{MaxAge: "2h", MaxDocs: 1000}))
+		assert.NoError(t, err)
+		assert.Equal(t, time.UnixMilli(1700000000000).Add(2*time.Hour), next)
+		// Only max_age can be predicted
+		next, err = client.NextRollover(context.Background(),
+			newTestRolloverIndex(xov1alpha1.ESIndexRollover{MaxDocs: 1000}))
+		assert.NoError(t, err)
+		assert.True(t, next.IsZero())
+		_, err = client.NextRollover(context.Background(),
+			newTestRolloverIndex(xov1alpha1.ESIndexRollover{MaxAge: "2w"}))
+		assert.EqualError(t, err, "invalid max_age of ES index logs: unknown time unit of 2w")
+	})
+}
+
+func TestExpiredRolloverIndices(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_alias/logs",
+			ResponceCode: 200,
+			Responce:     `{"logs-000003":{"aliases":{"logs":{"is_write_index":true}}},"logs-000002":{"aliases":{"logs":{}}}}`,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/logs-%2A/_mapping",
+			ResponceCode: 200,
+			Responce:     RolloverMappingGETanswer,
+		}
+		expired, err := client.ExpiredRolloverIndices(context.Background(),
+			newTestRolloverIndex(xov1alpha1.ESIndexRollover{MaxAge: "7d", Retention: 1}))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"logs-000002", "logs-000001"}, expired)
+		// All generations are kept without retention
+		expired, err = client.ExpiredRolloverIndices(context.Background(),
+			newTestRolloverIndex(xov1alpha1.ESIndexRollover{MaxAge: "7d"}))
+		assert.NoError(t, err)
+		assert.Empty(t, expired)
+	})
+}
+
+func TestManagedRolloverIndices(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/logs-%2A/_mapping",
+			ResponceCode: 200,
+			Responce:     RolloverMappingGETanswer,
+		}
+		indices, err := client.ManagedIndices(context.Background(),
+			newTestRolloverIndex(xov1alpha1.ESIndexRollover{MaxAge: "7d"}))
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"logs-000003", "logs-000002", "logs-000001"}, indices)
+	})
+}
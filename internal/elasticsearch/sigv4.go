@@ -0,0 +1,152 @@
+package elasticsearch
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+)
+
+const (
+	// AWSServiceOpenSearch is SigV4 service name of Amazon OpenSearch Service domains
+	AWSServiceOpenSearch = "es"
+	// AWSServiceOpenSearchServerless is SigV4 service name of Amazon OpenSearch Serverless collections
+	AWSServiceOpenSearchServerless = "aoss"
+	// AuthModeNone is auth mode without request signing
+	AuthModeNone = "none"
+	// AuthModeAWSSigV4 is auth mode which signs requests with AWS SigV4
+	AuthModeAWSSigV4 = "aws-sigv4"
+
+	// amzContentSHA256Header is required by Amazon OpenSearch Serverless
+	amzContentSHA256Header = "X-Amz-Content-Sha256"
+)
+
+// awsSigV4 is configuration of AWS SigV4 request signing
+type awsSigV4 struct {
+	region      string
+	service     string
+	credentials aws.CredentialsProvider
+}
+
+// AWSSigV4 is option function to sign all ES requests with AWS SigV4 for region and service (es or aoss).
+// Credentials are taken from standard AWS chain (env, IRSA web identity, shared config, instance profile)
+// unless they are set with AWSCredentials. Empty region is taken from AWS chain as well.
+func AWSSigV4(region, service string) Option {
+	return func(c *Client) error {
+		if service != AWSServiceOpenSearch && service != AWSServiceOpenSearchServerless {
+			return fmt.Errorf("AWS service must be %s or %s, got '%s'",
+				AWSServiceOpenSearch, AWSServiceOpenSearchServerless, service)
+		}
+		if c.sigV4 == nil {
+			c.sigV4 = &awsSigV4{}
+		}
+		c.sigV4.region = region
+		c.sigV4.service = service
+		return nil
+	}
+}
+
+// AWSCredentials is option function to set AWS credentials used for SigV4 signing - mainly for mocking
+func AWSCredentials(credentials aws.CredentialsProvider) Option {
+	return func(c *Client) error {
+		if credentials == nil {
+			return fmt.Errorf("AWS credentials must not be nil")
+		}
+		if c.sigV4 == nil {
+			c.sigV4 = &awsSigV4{}
+		}
+		c.sigV4.credentials = credentials
+		return nil
+	}
+}
+
+// newTransport would make HTTP transport for ES requests, requests are signed if AWS SigV4 is configured.
+// Nil transport means http.DefaultTransport.
+func (c *Client) newTransport(ctx context.Context) (http.RoundTripper, error) {
+	if c.sigV4 == nil {
+		return nil, nil
+	}
+	return newSigV4Transport(ctx, c.sigV4, http.DefaultTransport)
+}
+
+// sigV4Transport is http.RoundTripper which signs requests with AWS SigV4
+type sigV4Transport struct {
+	next        http.RoundTripper
+	signer      *v4.Signer
+	credentials aws.CredentialsProvider
+	region      string
+	service     string
+}
+
+func newSigV4Transport(ctx context.Context, cfg *awsSigV4, next http.RoundTripper) (*sigV4Transport, error) {
+	credentials := cfg.credentials
+	region := cfg.region
+	if credentials == nil || len(region) == 0 {
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+		if err != nil {
+			return nil, fmt.Errorf("can't load AWS config: %w", err)
+		}
+		if credentials == nil {
+			credentials = awsCfg.Credentials
+		}
+		region = awsCfg.Region
+	}
+	if len(region) == 0 {
+		return nil, fmt.Errorf("AWS region is not set")
+	}
+	if credentials == nil {
+		return nil, fmt.Errorf("AWS credentials are not found")
+	}
+	// Credentials are cached and refreshed before they expire
+	if _, ok := credentials.(*aws.CredentialsCache); !ok {
+		credentials = aws.NewCredentialsCache(credentials)
+	}
+	return &sigV4Transport{
+		next:        next,
+		signer:      v4.NewSigner(),
+		credentials: credentials,
+		region:      region,
+		service:     cfg.service,
+	}, nil
+}
+
+// RoundTrip would sign request and pass it to next transport
+func (t *sigV4Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTripper must not modify original request
+	signed := req.Clone(req.Context())
+	payload := []byte{}
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		payload, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("can't read request body: %w", err)
+		}
+		signed.Body = io.NopCloser(bytes.NewReader(payload))
+		signed.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(payload)), nil
+		}
+		signed.ContentLength = int64(len(payload))
+	}
+	hash := sha256.Sum256(payload)
+	payloadHash := hex.EncodeToString(hash[:])
+	signed.Header.Set(amzContentSHA256Header, payloadHash)
+
+	credentials, err := t.credentials.Retrieve(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("can't retrieve AWS credentials: %w", err)
+	}
+	err = t.signer.SignHTTP(req.Context(), credentials, signed, payloadHash, t.service, t.region, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("can't sign request with AWS SigV4: %w", err)
+	}
+	return t.next.RoundTrip(signed)
+}
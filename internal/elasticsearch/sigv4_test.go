@@ -0,0 +1,262 @@
+package elasticsearch
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testAWSAccessKey    = "AKIDEXAMPLE"
+	testAWSSecretKey    = "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"
+	testAWSSessionToken = "session-token"
+	testAWSRegion       = "eu-west-1"
+)
+
+// SigV4Verifier is local stand-in of AWS endpoint, it checks SigV4 signature of every request
+// and answers with 403 if signature is invalid
+type SigV4Verifier struct {
+	SecretKey string
+	Region    string
+	Service   string
+	// Answers are keyed by "METHOD path"
+	Answers map[string]string
+
+	mu       sync.Mutex
+	verified []string
+}
+
+func (v *SigV4Verifier) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = v.verify(r, body)
+	}
+	if err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = fmt.Fprintf(w, `{"message":%q}`, err.Error())
+		return
+	}
+	key := r.Method + " " + r.URL.Path
+	v.mu.Lock()
+	v.verified = append(v.verified, key)
+	v.mu.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Elastic-Product", "Elasticsearch")
+	_, _ = io.WriteString(w, v.Answers[key])
+}
+
+// Verified would return requests with valid signature
+func (v *SigV4Verifier) Verified() []string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]string{}, v.verified...)
+}
+
+// verify is independent implementation of SigV4 signature check,
+// see https://docs.aws.amazon.com/IAM/latest/UserGuide/create-signed-request.html
+func (v *SigV4Verifier) verify(r *http.Request, body []byte) error {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 ") {
+		return fmt.Errorf("request is not signed")
+	}
+	fields := map[string]string{}
+	for _, field := range strings.Split(strings.TrimPrefix(auth, "AWS4-HMAC-SHA256 "), ",") {
+		kv := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(kv) == 2 {
+			fields[kv[0]] = kv[1]
+		}
+	}
+	credential := strings.SplitN(fields["Credential"], "/", 2)
+	if len(credential) != 2 {
+		return fmt.Errorf("invalid credential '%s'", fields["Credential"])
+	}
+	scope := credential[1]
+	amzDate := r.Header.Get("X-Amz-Date")
+	if len(amzDate) < 8 {
+		return fmt.Errorf("invalid X-Amz-Date '%s'", amzDate)
+	}
+	expectedScope := fmt.Sprintf("%s/%s/%s/aws4_request", amzDate[:8], v.Region, v.Service)
+	if scope != expectedScope {
+		return fmt.Errorf("invalid scope '%s', expected '%s'", scope, expectedScope)
+	}
+	bodyHash := sha256.Sum256(body)
+	payloadHash := hex.EncodeToString(bodyHash[:])
+	if r.Header.Get("X-Amz-Content-Sha256") != payloadHash {
+		return fmt.Errorf("payload hash doesn't match body")
+	}
+
+	signedHeaders := strings.Split(fields["SignedHeaders"], ";")
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaders {
+		var value string
+		switch name {
+		case "host":
+			value = r.Host
+		case "content-length":
+			value = strconv.FormatInt(r.ContentLength, 10)
+		default:
+			value = strings.Join(r.Header.Values(name), ",")
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	query := r.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	params := []string{}
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			params = append(params, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		uriEncode(r.URL.EscapedPath(), false),
+		strings.Join(params, "&"),
+		canonicalHeaders.String(),
+		fields["SignedHeaders"],
+		payloadHash,
+	}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hex.EncodeToString(requestHash[:]),
+	}, "\n")
+	key := []byte("AWS4" + v.SecretKey)
+	for _, part := range []string{amzDate[:8], v.Region, v.Service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+	if signature != fields["Signature"] {
+		return fmt.Errorf("the request signature we calculated does not match the signature you provided")
+	}
+	return nil
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// uriEncode would encode everything except unreserved characters, slash is kept in paths
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func newSigV4TestClient(t *testing.T, verifier *SigV4Verifier, secretKey string) (*Client, error) {
+	srv := httptest.NewServer(verifier)
+	t.Cleanup(srv.Close)
+	return New(
+		URL(srv.URL),
+		Timeout(time.Second),
+		AWSSigV4(testAWSRegion, verifier.Service),
+		AWSCredentials(credentials.NewStaticCredentialsProvider(testAWSAccessKey, secretKey, testAWSSessionToken)),
+	)
+}
+
+func TestSigV4Signing(t *testing.T) {
+	tests := []struct {
+		name    string
+		service string
+		root    string
+		v8      bool
+	}{
+		{name: "opensearch_service", service: AWSServiceOpenSearch, root: OpenSearchRootGETanswer},
+		{name: "opensearch_serverless", service: AWSServiceOpenSearchServerless, root: OpenSearchRootGETanswer},
+		{name: "elasticsearch_7", service: AWSServiceOpenSearch, root: ElasticsearchRootGETanswer},
+		{name: "elasticsearch_8", service: AWSServiceOpenSearch,
+			root: strings.Replace(ElasticsearchRootGETanswer, `"number":"7.17.9"`, `"number":"8.11.1"`, 1), v8: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			verifier := &SigV4Verifier{
+				SecretKey: testAWSSecretKey,
+				Region:    testAWSRegion,
+				Service:   test.service,
+				Answers: map[string]string{
+					"GET /":                     test.root,
+					"PUT /test_index/_settings": `{"acknowledged":true}`,
+					"GET /test_index/_search":   `{}`,
+				},
+			}
+			client, err := newSigV4TestClient(t, verifier, testAWSSecretKey)
+			if !assert.NoError(t, err) {
+				return
+			}
+			ctx := context.Background()
+			// Body is part of signature
+			err = client.perform(ctx, http.MethodPut, "/test_index/_settings",
+				map[string]interface{}{"index": map[string]interface{}{"number_of_replicas": 2}}, nil)
+			assert.NoError(t, err)
+			err = client.perform(ctx, http.MethodGet, "/test_index/_search?q="+url.QueryEscape("name:some value"), nil, nil)
+			assert.NoError(t, err)
+			exists, err := client.IndexExists(ctx, "test_index")
+			assert.NoError(t, err)
+			assert.True(t, exists)
+			assert.Contains(t, verifier.Verified(), "PUT /test_index/_settings")
+			_, isV8 := client.driver.(*v8Driver)
+			assert.Equal(t, test.v8, isV8)
+		})
+	}
+}
+
+func TestSigV4InvalidSignature(t *testing.T) {
+	verifier := &SigV4Verifier{
+		SecretKey: testAWSSecretKey,
+		Region:    testAWSRegion,
+		Service:   AWSServiceOpenSearch,
+		Answers: map[string]string{
+			"GET /": OpenSearchRootGETanswer,
+		},
+	}
+	client, err := newSigV4TestClient(t, verifier, "wrong-secret")
+	assert.NoError(t, err)
+	_, err = client.Flavor(context.Background())
+	assert.Error(t, err)
+	assert.Empty(t, verifier.Verified())
+}
+
+func TestSigV4Options(t *testing.T) {
+	_, err := New(URL("http://localhost:9200"), AWSSigV4(testAWSRegion, "s3"))
+	assert.EqualError(t, err, "can't make new ES Client: AWS service must be es or aoss, got 's3'")
+	_, err = New(URL("http://localhost:9200"), AWSCredentials(nil))
+	assert.EqualError(t, err, "can't make new ES Client: AWS credentials must not be nil")
+	_, err = New(URL("http://localhost:9200"),
+		AWSCredentials(credentials.NewStaticCredentialsProvider(testAWSAccessKey, testAWSSecretKey, "")))
+	assert.EqualError(t, err, "can't make new ES Client: AWS credentials are set, but AWS SigV4 signing is not enabled")
+}
@@ -0,0 +1,203 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/consts"
+)
+
+const (
+	// SnapshotStateInProgress is state of snapshot which is being taken
+	SnapshotStateInProgress = "IN_PROGRESS"
+	// SnapshotStateSuccess is state of snapshot which finished without failures
+	SnapshotStateSuccess = "SUCCESS"
+	// SnapshotStatePartial is state of snapshot where some shards couldn't be stored
+	SnapshotStatePartial = "PARTIAL"
+)
+
+// Snapshot is state of ES snapshot
+type Snapshot struct {
+	Name         string
+	State        string
+	Indices      []string
+	StartTime    time.Time
+	EndTime      time.Time
+	ShardsTotal  int64
+	ShardsDone   int64
+	ShardsFailed int64
+	Failures     []string
+}
+
+// snapshotRequest is body of ES create snapshot API
+type snapshotRequest struct {
+	Indices            []string               `json:"indices,omitempty"`
+	IgnoreUnavailable  bool                   `json:"ignore_unavailable,omitempty"`
+	IncludeGlobalState *bool                  `json:"include_global_state,omitempty"`
+	Partial            bool                   `json:"partial,omitempty"`
+	Metadata           map[string]interface{} `json:"metadata"`
+}
+
+// snapshotInfo is snapshot as ES get snapshot API returns it
+type snapshotInfo struct {
+	Snapshot          string                 `json:"snapshot"`
+	Indices           []string               `json:"indices"`
+	State             string                 `json:"state"`
+	StartTimeInMillis int64                  `json:"start_time_in_millis"`
+	EndTimeInMillis   int64                  `json:"end_time_in_millis"`
+	Metadata          map[string]interface{} `json:"metadata"`
+	Failures          []struct {
+		Index   string `json:"index"`
+		ShardID int    `json:"shard_id"`
+		Reason  string `json:"reason"`
+	} `json:"failures"`
+	Shards struct {
+		Total      int64 `json:"total"`
+		Failed     int64 `json:"failed"`
+		Successful int64 `json:"successful"`
+	} `json:"shards"`
+}
+
+// snapshotStatusResponse is answer of ES snapshot status API, it has progress of running snapshot
+type snapshotStatusResponse struct {
+	Snapshots []struct {
+		ShardsStats struct {
+			Done   int64 `json:"done"`
+			Failed int64 `json:"failed"`
+			Total  int64 `json:"total"`
+		} `json:"shards_stats"`
+	} `json:"snapshots"`
+}
+
+func snapshotPath(repository, name string) string {
+	return fmt.Sprintf("%s/%s", snapshotRepositoryPath(repository), url.PathEscape(name))
+}
+
+// CreateSnapshot would start snapshot without waiting for its completion, use GetSnapshot to track it.
+// Snapshot which is already started by operator is not an error.
+func (c *Client) CreateSnapshot(ctx context.Context, object *xov1alpha1.ElasticSearchSnapshot) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	path := snapshotPath(object.Spec.Repository, object.Spec.Name)
+	servSnapshot, err := c.getSnapshotInfo(ctx, object.Spec.Repository, object.Spec.Name)
+	if err != nil && !errors.Is(err, errObjectNotFound) {
+		return "", fmt.Errorf("can't get snapshot: %w", err)
+	}
+	if servSnapshot != nil {
+		managedBy, _ := getStringValueFromSettings(servSnapshot.Metadata, consts.ESManagedByField)
+		if managedBy != consts.ESManagedByValue {
+			return "", fmt.Errorf("snapshot '%s' is not managed by this operator", object.Spec.Name)
+		}
+		return fmt.Sprintf("snapshot %s is already started", object.Spec.Name), nil
+	}
+	request := snapshotRequest{
+		Indices:            object.Spec.Indices,
+		IgnoreUnavailable:  object.Spec.IgnoreUnavailable,
+		IncludeGlobalState: object.Spec.IncludeGlobalState,
+		Partial:            object.Spec.Partial,
+		Metadata: map[string]interface{}{
+			consts.ESManagedByField: consts.ESManagedByValue,
+		},
+	}
+	answer := struct {
+		Accepted bool `json:"accepted"`
+	}{}
+	err = c.perform(ctx, http.MethodPut, path+"?wait_for_completion=false", request, &answer)
+	if err != nil {
+		return "", fmt.Errorf("can't create snapshot: %w", err)
+	}
+	if !answer.Accepted {
+		return "", fmt.Errorf("snapshot %s is not accepted", object.Spec.Name)
+	}
+	return fmt.Sprintf("successfully started snapshot %s", object.Spec.Name), nil
+}
+
+// GetSnapshot would get state of snapshot, progress of running snapshot is taken from snapshot status API
+func (c *Client) GetSnapshot(ctx context.Context, repository, name string) (*Snapshot, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	info, err := c.getSnapshotInfo(ctx, repository, name)
+	if err != nil {
+		return nil, fmt.Errorf("can't get snapshot %s: %w", name, err)
+	}
+	snapshot := &Snapshot{
+		Name:         info.Snapshot,
+		State:        info.State,
+		Indices:      info.Indices,
+		ShardsTotal:  info.Shards.Total,
+		ShardsDone:   info.Shards.Successful,
+		ShardsFailed: info.Shards.Failed,
+	}
+	if info.StartTimeInMillis > 0 {
+		snapshot.StartTime = time.UnixMilli(info.StartTimeInMillis).UTC()
+	}
+	if info.EndTimeInMillis > 0 {
+		snapshot.EndTime = time.UnixMilli(info.EndTimeInMillis).UTC()
+	}
+	for _, failure := range info.Failures {
+		snapshot.Failures = append(snapshot.Failures,
+			fmt.Sprintf("%s[%d]: %s", failure.Index, failure.ShardID, failure.Reason))
+	}
+	if snapshot.State != SnapshotStateInProgress {
+		return snapshot, nil
+	}
+	// Shard counts of running snapshot are only in snapshot status API
+	status := snapshotStatusResponse{}
+	err = c.perform(ctx, http.MethodGet, snapshotPath(repository, name)+"/_status", nil, &status)
+	if err != nil {
+		return nil, fmt.Errorf("can't get status of snapshot %s: %w", name, err)
+	}
+	if len(status.Snapshots) > 0 {
+		snapshot.ShardsTotal = status.Snapshots[0].ShardsStats.Total
+		snapshot.ShardsDone = status.Snapshots[0].ShardsStats.Done
+		snapshot.ShardsFailed = status.Snapshots[0].ShardsStats.Failed
+	}
+	return snapshot, nil
+}
+
+// DeleteSnapshot would delete snapshot from repository,
+// snapshot which doesn't exist or is not taken by operator is not deleted
+func (c *Client) DeleteSnapshot(ctx context.Context, repository, name string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	servSnapshot, err := c.getSnapshotInfo(ctx, repository, name)
+	if errors.Is(err, errObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("can't get snapshot %s: %w", name, err)
+	}
+	managedBy, _ := getStringValueFromSettings(servSnapshot.Metadata, consts.ESManagedByField)
+	if managedBy != consts.ESManagedByValue {
+		return fmt.Errorf("snapshot '%s' is %w", name, ErrNotManaged)
+	}
+	err = c.perform(ctx, http.MethodDelete, snapshotPath(repository, name), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("can't delete snapshot %s: %w", name, err)
+	}
+	return nil
+}
+
+func (c *Client) getSnapshotInfo(ctx context.Context, repository, name string) (*snapshotInfo, error) {
+	answer := struct {
+		Snapshots []snapshotInfo `json:"snapshots"`
+	}{}
+	err := c.perform(ctx, http.MethodGet, snapshotPath(repository, name), nil, &answer)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errObjectNotFound
+		}
+		return nil, err
+	}
+	for i := range answer.Snapshots {
+		if answer.Snapshots[i].Snapshot == name {
+			return &answer.Snapshots[i], nil
+		}
+	}
+	return nil, errObjectNotFound
+}
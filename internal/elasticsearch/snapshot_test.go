@@ -0,0 +1,170 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+const (
+	// Used by Doer to mock ES answer on get snapshot request for snapshot started by operator
+	SnapshotGETanswer = `{"snapshots":[{"snapshot":"orders-before-migration","indices":["orders-2024"],
+		"state":"SUCCESS","start_time_in_millis":1575595800000,"end_time_in_millis":1575595860000,
+		"metadata":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"},"failures":[],
+		"shards":{"total":3,"failed":0,"successful":3}}]}`
+	// Used by Doer to mock ES answer on get snapshot request for snapshot which is being taken
+	SnapshotInProgressGETanswer = `{"snapshots":[{"snapshot":"orders-before-migration","indices":["orders-2024"],
+		"state":"IN_PROGRESS","start_time_in_millis":1575595800000,"end_time_in_millis":0,
+		"metadata":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"},"failures":[],
+		"shards":{"total":0,"failed":0,"successful":0}}]}`
+	// Used by Doer to mock ES answer on snapshot which doesn't exist
+	SnapshotNotFoundAnswer = `{"error":{"type":"snapshot_missing_exception","reason":"[backups:orders-before-migration] is missing"},"status":404}`
+)
+
+func newTestSnapshot() *xov1alpha1.ElasticSearchSnapshot {
+	includeGlobalState := false
+	return &xov1alpha1.ElasticSearchSnapshot{
+		Spec: xov1alpha1.ElasticSearchSnapshotSpec{
+			Name:               "orders-before-migration",
+			Repository:         "backups",
+			Indices:            []string{"orders-*"},
+			IncludeGlobalState: &includeGlobalState,
+		},
+	}
+}
+
+func TestCreateSnapshot(t *testing.T) {
+	tests := []struct {
+		R2R []Responce2Req
+		Err error
+		Msg string
+	}{
+		{
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_snapshot/backups/orders-before-migration",
+					ResponceCode: 404,
+					Responce:     SnapshotNotFoundAnswer,
+				},
+				{
+					RequestURI:   "/_snapshot/backups/orders-before-migration?wait_for_completion=false",
+					ResponceCode: 200,
+					Responce:     `{"accepted":true}`,
+					RequestBody:  `{"indices":["orders-*"],"include_global_state":false,"metadata":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}`,
+				},
+			},
+			Msg: "successfully started snapshot orders-before-migration",
+		},
+		{
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_snapshot/backups/orders-before-migration",
+					ResponceCode: 200,
+					Responce:     SnapshotInProgressGETanswer,
+				},
+			},
+			Msg: "snapshot orders-before-migration is already started",
+		},
+		{
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_snapshot/backups/orders-before-migration",
+					ResponceCode: 200,
+					Responce:     `{"snapshots":[{"snapshot":"orders-before-migration","state":"SUCCESS"}]}`,
+				},
+			},
+			Err: fmt.Errorf("snapshot 'orders-before-migration' is not managed by this operator"),
+		},
+	}
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		for _, test := range tests {
+			for _, r2r := range test.R2R {
+				testDoer.R2rChan <- r2r
+			}
+			msg, err := client.CreateSnapshot(context.Background(), newTestSnapshot())
+			if test.Err != nil {
+				assert.EqualError(t, err, fmt.Sprintf("%s", test.Err))
+				continue
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.Msg, msg)
+		}
+	})
+}
+
+func TestGetSnapshot(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups/orders-before-migration",
+			ResponceCode: 200,
+			Responce:     SnapshotGETanswer,
+		}
+		snapshot, err := client.GetSnapshot(context.Background(), "backups", "orders-before-migration")
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, &Snapshot{
+			Name:        "orders-before-migration",
+			State:       SnapshotStateSuccess,
+			Indices:     []string{"orders-2024"},
+			StartTime:   time.UnixMilli(1575595800000).UTC(),
+			EndTime:     time.UnixMilli(1575595860000).UTC(),
+			ShardsTotal: 3,
+			ShardsDone:  3,
+		}, snapshot)
+		// Progress of running snapshot comes from status API
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups/orders-before-migration",
+			ResponceCode: 200,
+			Responce:     SnapshotInProgressGETanswer,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups/orders-before-migration/_status",
+			ResponceCode: 200,
+			Responce:     `{"snapshots":[{"snapshot":"orders-before-migration","state":"STARTED","shards_stats":{"initializing":0,"started":2,"finalizing":0,"done":1,"failed":0,"total":3}}]}`,
+		}
+		snapshot, err = client.GetSnapshot(context.Background(), "backups", "orders-before-migration")
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, SnapshotStateInProgress, snapshot.State)
+		assert.Equal(t, int64(3), snapshot.ShardsTotal)
+		assert.Equal(t, int64(1), snapshot.ShardsDone)
+		assert.True(t, snapshot.EndTime.IsZero())
+	})
+}
+
+func TestDeleteSnapshot(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups/orders-before-migration",
+			ResponceCode: 404,
+			Responce:     SnapshotNotFoundAnswer,
+		}
+		assert.NoError(t, client.DeleteSnapshot(context.Background(), "backups", "orders-before-migration"))
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups/orders-before-migration",
+			ResponceCode: 200,
+			Responce:     SnapshotGETanswer,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups/orders-before-migration",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true}`,
+		}
+		assert.NoError(t, client.DeleteSnapshot(context.Background(), "backups", "orders-before-migration"))
+		// Snapshot taken outside of operator is kept
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups/orders-before-migration",
+			ResponceCode: 200,
+			Responce: `{"snapshots":[{"snapshot":"orders-before-migration","indices":["orders-2024"],` +
+				`"state":"SUCCESS","failures":[],"shards":{"total":3,"failed":0,"successful":3}}]}`,
+		}
+		assert.ErrorIs(t, client.DeleteSnapshot(context.Background(), "backups", "orders-before-migration"), ErrNotManaged)
+	})
+}
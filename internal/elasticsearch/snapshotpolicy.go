@@ -0,0 +1,214 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/consts"
+)
+
+// SnapshotPolicy is configuration struct for ES SLM policy creation
+type SnapshotPolicy struct {
+	Schedule   string                                `json:"schedule"`
+	Name       string                                `json:"name"`
+	Repository string                                `json:"repository"`
+	Config     SnapshotPolicyConfig                  `json:"config"`
+	Retention  *xov1alpha1.ESSnapshotPolicyRetention `json:"retention,omitempty"`
+}
+
+// SnapshotPolicyConfig extends xov1alpha1.ESSnapshotPolicyConfig with metadata which is attached to snapshots
+type SnapshotPolicyConfig struct {
+	xov1alpha1.ESSnapshotPolicyConfig
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+// SnapshotPolicyState is state of SLM policy executions
+type SnapshotPolicyState struct {
+	LastSuccess         time.Time
+	LastSuccessSnapshot string
+	LastFailure         time.Time
+	LastFailureSnapshot string
+	LastFailureDetails  string
+	NextExecution       time.Time
+}
+
+// snapshotPolicyExecution is last success or failure of SLM policy
+type snapshotPolicyExecution struct {
+	SnapshotName string `json:"snapshot_name"`
+	Time         int64  `json:"time"`
+	Details      string `json:"details"`
+}
+
+// snapshotPolicyResponse is answer of ES get SLM policy API for one policy
+type snapshotPolicyResponse struct {
+	Policy              map[string]interface{}   `json:"policy"`
+	LastSuccess         *snapshotPolicyExecution `json:"last_success"`
+	LastFailure         *snapshotPolicyExecution `json:"last_failure"`
+	NextExecutionMillis int64                    `json:"next_execution_millis"`
+}
+
+func snapshotPolicyPath(name string) string {
+	return fmt.Sprintf("/_slm/policy/%s", url.PathEscape(name))
+}
+
+// SnapshotPolicyExists would check if SLM policy exists
+func (c *Client) SnapshotPolicyExists(ctx context.Context, name string) (bool, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "SLM policy", FlavorElasticsearch)
+	if err != nil {
+		return false, err
+	}
+	_, err = c.getServerSnapshotPolicy(ctx, name)
+	if errors.Is(err, errObjectNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("can't check if SLM policy exists: %w", err)
+	}
+	return true, nil
+}
+
+// CreateUpdateSnapshotPolicy would update SLM policy if it exists or create if not
+func (c *Client) CreateUpdateSnapshotPolicy(ctx context.Context, object *xov1alpha1.ElasticSearchSnapshotPolicy) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "SLM policy", FlavorElasticsearch)
+	if err != nil {
+		return "", err
+	}
+	policy := newSnapshotPolicy(object)
+	retMsg := "successfully created SLM policy %s"
+	servPolicy, err := c.getServerSnapshotPolicy(ctx, object.Spec.Name)
+	if err != nil && !errors.Is(err, errObjectNotFound) {
+		return "", fmt.Errorf("can't get SLM policy: %w", err)
+	}
+	if servPolicy != nil {
+		managedBy, _ := getStringValueFromSettings(servPolicy.Policy, "config.metadata."+consts.ESManagedByField)
+		if managedBy != consts.ESManagedByValue {
+			return "", fmt.Errorf("SLM policy '%s' is not managed by this operator", object.Spec.Name)
+		}
+		desired, err := toInterface(policy)
+		if err != nil {
+			return "", err
+		}
+		if isSubset(desired, servPolicy.Policy) {
+			return fmt.Sprintf("no changes on SLM policy named %s", object.Spec.Name), nil
+		}
+		retMsg = "successfully updated SLM policy %s"
+	}
+	putPolicy := acknowledgedResponse{}
+	err = c.perform(ctx, http.MethodPut, snapshotPolicyPath(object.Spec.Name), policy, &putPolicy)
+	if err != nil {
+		return "", fmt.Errorf("can't put SLM policy: %w", err)
+	}
+	if !putPolicy.Acknowledged {
+		return "", fmt.Errorf("can't acknowledge SLM policy %s update", object.Spec.Name)
+	}
+	return fmt.Sprintf(retMsg, object.Spec.Name), nil
+}
+
+// GetSnapshotPolicyState would get last success and failure of SLM policy and time of its next execution
+func (c *Client) GetSnapshotPolicyState(ctx context.Context, name string) (*SnapshotPolicyState, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	servPolicy, err := c.getServerSnapshotPolicy(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("can't get SLM policy %s: %w", name, err)
+	}
+	state := &SnapshotPolicyState{}
+	if servPolicy.LastSuccess != nil {
+		state.LastSuccess = time.UnixMilli(servPolicy.LastSuccess.Time).UTC()
+		state.LastSuccessSnapshot = servPolicy.LastSuccess.SnapshotName
+	}
+	if servPolicy.LastFailure != nil {
+		state.LastFailure = time.UnixMilli(servPolicy.LastFailure.Time).UTC()
+		state.LastFailureSnapshot = servPolicy.LastFailure.SnapshotName
+		state.LastFailureDetails = servPolicy.LastFailure.Details
+	}
+	if servPolicy.NextExecutionMillis > 0 {
+		state.NextExecution = time.UnixMilli(servPolicy.NextExecutionMillis).UTC()
+	}
+	return state, nil
+}
+
+// ExecuteSnapshotPolicy would take snapshot with SLM policy immediately, it returns name of snapshot
+func (c *Client) ExecuteSnapshotPolicy(ctx context.Context, name string) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	answer := struct {
+		SnapshotName string `json:"snapshot_name"`
+	}{}
+	err := c.perform(ctx, http.MethodPost, snapshotPolicyPath(name)+"/_execute", nil, &answer)
+	if err != nil {
+		return "", fmt.Errorf("can't execute SLM policy %s: %w", name, err)
+	}
+	return answer.SnapshotName, nil
+}
+
+// DeleteSnapshotPolicy would delete SLM policy, policy which doesn't exist or is not managed by operator is not deleted
+func (c *Client) DeleteSnapshotPolicy(ctx context.Context, name string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "SLM policy", FlavorElasticsearch)
+	if err != nil {
+		return err
+	}
+	servPolicy, err := c.getServerSnapshotPolicy(ctx, name)
+	if errors.Is(err, errObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("can't get SLM policy %s: %w", name, err)
+	}
+	managedBy, _ := getStringValueFromSettings(servPolicy.Policy, "config.metadata."+consts.ESManagedByField)
+	if managedBy != consts.ESManagedByValue {
+		return fmt.Errorf("SLM policy '%s' is %w", name, ErrNotManaged)
+	}
+	err = c.perform(ctx, http.MethodDelete, snapshotPolicyPath(name), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("can't delete SLM policy %s: %w", name, err)
+	}
+	return nil
+}
+
+func (c *Client) getServerSnapshotPolicy(ctx context.Context, name string) (*snapshotPolicyResponse, error) {
+	policies := map[string]snapshotPolicyResponse{}
+	err := c.perform(ctx, http.MethodGet, snapshotPolicyPath(name), nil, &policies)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errObjectNotFound
+		}
+		return nil, err
+	}
+	policy, ok := policies[name]
+	if !ok {
+		return nil, errObjectNotFound
+	}
+	return &policy, nil
+}
+
+// newSnapshotPolicy would make SLM policy ES understands from K8S object
+func newSnapshotPolicy(object *xov1alpha1.ElasticSearchSnapshotPolicy) *SnapshotPolicy {
+	policy := &SnapshotPolicy{
+		Schedule:   object.Spec.Schedule,
+		Name:       object.Spec.SnapshotName,
+		Repository: object.Spec.Repository,
+		Config: SnapshotPolicyConfig{
+			// Metadata marks policy and its snapshots as managed by operator
+			Metadata: map[string]interface{}{
+				consts.ESManagedByField: consts.ESManagedByValue,
+			},
+		},
+		Retention: object.Spec.Retention,
+	}
+	if object.Spec.Config != nil {
+		policy.Config.ESSnapshotPolicyConfig = *object.Spec.Config
+	}
+	return policy
+}
@@ -0,0 +1,214 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+const (
+	// Used by Doer to mock ES answer on get SLM policy request
+	SnapshotPolicyGETanswer = `{"nightly-snapshots":{"version":1,"modified_date_millis":1575567000000,
+		"policy":{"name":"<nightly-snap-{now/d}>","schedule":"0 30 1 * * ?","repository":"backups",
+			"config":{"indices":["orders-*"],"include_global_state":false,"metadata":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}},
+			"retention":{"expire_after":"30d","min_count":5,"max_count":50}},
+		"last_success":{"snapshot_name":"nightly-snap-2019.12.06-mffd4dwxq5ggrajrmdhbsq","start_time":1575595800000,"time":1575595860000},
+		"last_failure":{"snapshot_name":"nightly-snap-2019.12.05-2tfmqiyhrdqi1m7rpbsnla","time":1575509460000,"details":"{\"type\":\"snapshot_exception\"}"},
+		"next_execution_millis":1575682200000,
+		"stats":{"policy":"nightly-snapshots","snapshots_taken":1,"snapshots_failed":1}}}`
+	// Used by Doer to mock ES answer on get SLM policy request for policy created outside of operator
+	SnapshotPolicyNotManagedGETanswer = `{"nightly-snapshots":{"version":1,"policy":{"name":"<nightly-snap-{now/d}>","schedule":"0 30 1 * * ?","repository":"backups","config":{}}}}`
+)
+
+type TestSnapshotPolicy struct {
+	Policy *xov1alpha1.ElasticSearchSnapshotPolicy
+	R2R    []Responce2Req
+	Err    error
+	Msg    string
+}
+
+func newTestSnapshotPolicy(schedule string) *xov1alpha1.ElasticSearchSnapshotPolicy {
+	includeGlobalState := false
+	return &xov1alpha1.ElasticSearchSnapshotPolicy{
+		Spec: xov1alpha1.ElasticSearchSnapshotPolicySpec{
+			Name:         "nightly-snapshots",
+			Schedule:     schedule,
+			SnapshotName: "<nightly-snap-{now/d}>",
+			Repository:   "backups",
+			Config: &xov1alpha1.ESSnapshotPolicyConfig{
+				Indices:            []string{"orders-*"},
+				IncludeGlobalState: &includeGlobalState,
+			},
+			Retention: &xov1alpha1.ESSnapshotPolicyRetention{
+				ExpireAfter: "30d",
+				MinCount:    5,
+				MaxCount:    50,
+			},
+		},
+	}
+}
+
+func TestCreateUpdateSnapshotPolicy(t *testing.T) {
+	policyBody := `{"name":"<nightly-snap-{now/d}>","schedule":"0 0 2 * * ?","repository":"backups",
+		"config":{"indices":["orders-*"],"include_global_state":false,"metadata":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}},
+		"retention":{"expire_after":"30d","min_count":5,"max_count":50}}`
+	tests := []TestSnapshotPolicy{
+		{
+			Policy: newTestSnapshotPolicy("0 0 2 * * ?"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_slm/policy/nightly-snapshots",
+					ResponceCode: 404,
+					Responce:     `{"error":{"type":"resource_not_found_exception","reason":"snapshot lifecycle policy or policies [nightly-snapshots] not found"},"status":404}`,
+				},
+				{
+					RequestURI:   "/_slm/policy/nightly-snapshots",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+					RequestBody:  policyBody,
+				},
+			},
+			Msg: "successfully created SLM policy nightly-snapshots",
+		},
+		{
+			Policy: newTestSnapshotPolicy("0 30 1 * * ?"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_slm/policy/nightly-snapshots",
+					ResponceCode: 200,
+					Responce:     SnapshotPolicyGETanswer,
+				},
+			},
+			Msg: "no changes on SLM policy named nightly-snapshots",
+		},
+		{
+			Policy: newTestSnapshotPolicy("0 0 2 * * ?"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_slm/policy/nightly-snapshots",
+					ResponceCode: 200,
+					Responce:     SnapshotPolicyGETanswer,
+				},
+				{
+					RequestURI:   "/_slm/policy/nightly-snapshots",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+					RequestBody:  policyBody,
+				},
+			},
+			Msg: "successfully updated SLM policy nightly-snapshots",
+		},
+		{
+			Policy: newTestSnapshotPolicy("0 0 2 * * ?"),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_slm/policy/nightly-snapshots",
+					ResponceCode: 200,
+					Responce:     SnapshotPolicyNotManagedGETanswer,
+				},
+			},
+			Err: fmt.Errorf("SLM policy 'nightly-snapshots' is not managed by this operator"),
+		},
+	}
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		for _, test := range tests {
+			for _, r2r := range test.R2R {
+				testDoer.R2rChan <- r2r
+			}
+			msg, err := client.CreateUpdateSnapshotPolicy(context.Background(), test.Policy)
+			if test.Err != nil {
+				assert.EqualError(t, err, fmt.Sprintf("%s", test.Err))
+				continue
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.Msg, msg)
+		}
+	})
+}
+
+func TestSnapshotPolicyOpenSearch(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		client.flavor = FlavorOpenSearch
+		_, err := client.CreateUpdateSnapshotPolicy(context.Background(), newTestSnapshotPolicy("0 0 2 * * ?"))
+		assert.EqualError(t, err, "SLM policy is not supported by opensearch cluster")
+	})
+}
+
+func TestGetSnapshotPolicyState(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_slm/policy/nightly-snapshots",
+			ResponceCode: 200,
+			Responce:     SnapshotPolicyGETanswer,
+		}
+		state, err := client.GetSnapshotPolicyState(context.Background(), "nightly-snapshots")
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.Equal(t, &SnapshotPolicyState{
+			LastSuccess:         time.UnixMilli(1575595860000).UTC(),
+			LastSuccessSnapshot: "nightly-snap-2019.12.06-mffd4dwxq5ggrajrmdhbsq",
+			LastFailure:         time.UnixMilli(1575509460000).UTC(),
+			LastFailureSnapshot: "nightly-snap-2019.12.05-2tfmqiyhrdqi1m7rpbsnla",
+			LastFailureDetails:  `{"type":"snapshot_exception"}`,
+			NextExecution:       time.UnixMilli(1575682200000).UTC(),
+		}, state)
+		// Policy which never run has only next execution
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_slm/policy/nightly-snapshots",
+			ResponceCode: 200,
+			Responce:     `{"nightly-snapshots":{"policy":{},"next_execution_millis":1575682200000}}`,
+		}
+		state, err = client.GetSnapshotPolicyState(context.Background(), "nightly-snapshots")
+		assert.NoError(t, err)
+		assert.True(t, state.LastSuccess.IsZero())
+		assert.True(t, state.LastFailure.IsZero())
+	})
+}
+
+func TestExecuteSnapshotPolicy(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_slm/policy/nightly-snapshots/_execute",
+			ResponceCode: 200,
+			Responce:     `{"snapshot_name":"nightly-snap-2019.04.24-tmtnyjtrsxkhbrrdcgg18a"}`,
+		}
+		snapshot, err := client.ExecuteSnapshotPolicy(context.Background(), "nightly-snapshots")
+		assert.NoError(t, err)
+		assert.Equal(t, "nightly-snap-2019.04.24-tmtnyjtrsxkhbrrdcgg18a", snapshot)
+	})
+}
+
+func TestDeleteSnapshotPolicy(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_slm/policy/nightly-snapshots",
+			ResponceCode: 404,
+			Responce:     `{"error":{"type":"resource_not_found_exception","reason":"snapshot lifecycle policy or policies [nightly-snapshots] not found"},"status":404}`,
+		}
+		assert.NoError(t, client.DeleteSnapshotPolicy(context.Background(), "nightly-snapshots"))
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_slm/policy/nightly-snapshots",
+			ResponceCode: 200,
+			Responce:     SnapshotPolicyGETanswer,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_slm/policy/nightly-snapshots",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true}`,
+		}
+		assert.NoError(t, client.DeleteSnapshotPolicy(context.Background(), "nightly-snapshots"))
+		// Policy created outside of operator is kept
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_slm/policy/nightly-snapshots",
+			ResponceCode: 200,
+			Responce:     SnapshotPolicyNotManagedGETanswer,
+		}
+		assert.ErrorIs(t, client.DeleteSnapshotPolicy(context.Background(), "nightly-snapshots"), ErrNotManaged)
+	})
+}
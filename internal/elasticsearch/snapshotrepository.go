@@ -0,0 +1,146 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/consts"
+)
+
+// SnapshotRepository is configuration struct for ES snapshot repository
+type SnapshotRepository struct {
+	Type     string            `json:"type"`
+	Settings map[string]string `json:"settings"`
+}
+
+// snapshotRepositoryVerifyResponse is answer of ES verify snapshot repository API
+type snapshotRepositoryVerifyResponse struct {
+	Nodes map[string]struct {
+		Name string `json:"name"`
+	} `json:"nodes"`
+}
+
+func snapshotRepositoryPath(name string) string {
+	return fmt.Sprintf("/_snapshot/%s", url.PathEscape(name))
+}
+
+// SnapshotRepositoryExists would check if snapshot repository exists
+func (c *Client) SnapshotRepositoryExists(ctx context.Context, name string) (bool, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	_, err := c.getSnapshotRepository(ctx, name)
+	if errors.Is(err, errObjectNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("can't check if snapshot repository exists: %w", err)
+	}
+	return true, nil
+}
+
+// CreateUpdateSnapshotRepository would register snapshot repository or update it if it differs from spec.
+// Settings taken from Secrets are passed in secretSettings, they override settings from spec.
+// Repository is not verified on registration, use VerifySnapshotRepository for that.
+func (c *Client) CreateUpdateSnapshotRepository(ctx context.Context, object *xov1alpha1.ElasticSearchSnapshotRepository,
+	secretSettings map[string]string) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	name := object.Spec.Name
+	repository := SnapshotRepository{
+		Type:     string(object.Spec.Type),
+		Settings: make(map[string]string, len(object.Spec.Settings)+len(secretSettings)+1),
+	}
+	for key, value := range object.Spec.Settings {
+		repository.Settings[key] = value
+	}
+	for key, value := range secretSettings {
+		repository.Settings[key] = value
+	}
+	// Repository has no metadata, unknown setting marks it as managed by operator
+	repository.Settings[consts.ESManagedByField] = consts.ESManagedByValue
+	servRepository, err := c.getSnapshotRepository(ctx, name)
+	if err != nil && !errors.Is(err, errObjectNotFound) {
+		return "", fmt.Errorf("can't get snapshot repository: %w", err)
+	}
+	retMsg := "successfully created snapshot repository %s"
+	if servRepository != nil {
+		if reflect.DeepEqual(*servRepository, repository) {
+			return fmt.Sprintf("no changes on snapshot repository named %s", name), nil
+		}
+		retMsg = "successfully updated snapshot repository %s"
+	}
+	putRepository := acknowledgedResponse{}
+	err = c.perform(ctx, http.MethodPut, snapshotRepositoryPath(name)+"?verify=false", repository, &putRepository)
+	if err != nil {
+		return "", fmt.Errorf("can't put snapshot repository: %w", err)
+	}
+	if !putRepository.Acknowledged {
+		return "", fmt.Errorf("can't acknowledge snapshot repository %s update", name)
+	}
+	return fmt.Sprintf(retMsg, name), nil
+}
+
+// VerifySnapshotRepository would check that repository is functional on all master and data nodes,
+// it returns sorted names of nodes which verified repository
+func (c *Client) VerifySnapshotRepository(ctx context.Context, name string) ([]string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	answer := snapshotRepositoryVerifyResponse{}
+	err := c.perform(ctx, http.MethodPost, snapshotRepositoryPath(name)+"/_verify", nil, &answer)
+	if err != nil {
+		return nil, fmt.Errorf("can't verify snapshot repository %s: %w", name, err)
+	}
+	nodes := make([]string, 0, len(answer.Nodes))
+	for _, node := range answer.Nodes {
+		nodes = append(nodes, node.Name)
+	}
+	sort.Strings(nodes)
+	return nodes, nil
+}
+
+// DeleteSnapshotRepository would unregister snapshot repository, snapshots in it are kept.
+// Repository which doesn't exist or is not managed by operator is not deleted.
+func (c *Client) DeleteSnapshotRepository(ctx context.Context, name string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	servRepository, err := c.getSnapshotRepository(ctx, name)
+	if errors.Is(err, errObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("can't get snapshot repository %s: %w", name, err)
+	}
+	if servRepository.Settings[consts.ESManagedByField] != consts.ESManagedByValue {
+		return fmt.Errorf("snapshot repository '%s' is %w", name, ErrNotManaged)
+	}
+	err = c.perform(ctx, http.MethodDelete, snapshotRepositoryPath(name), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("can't delete snapshot repository %s: %w", name, err)
+	}
+	return nil
+}
+
+func (c *Client) getSnapshotRepository(ctx context.Context, name string) (*SnapshotRepository, error) {
+	repositories := map[string]SnapshotRepository{}
+	err := c.perform(ctx, http.MethodGet, snapshotRepositoryPath(name), nil, &repositories)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errObjectNotFound
+		}
+		return nil, err
+	}
+	repository, ok := repositories[name]
+	if !ok {
+		return nil, errObjectNotFound
+	}
+	if repository.Settings == nil {
+		repository.Settings = map[string]string{}
+	}
+	return &repository, nil
+}
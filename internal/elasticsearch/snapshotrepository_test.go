@@ -0,0 +1,193 @@
+package elasticsearch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+const (
+	// Used by Doer to mock ES answer on get snapshot repository request
+	SnapshotRepositoryGETanswer = `{"backups":{"type":"fs","settings":{"compress":"true","location":"/mnt/backups",` +
+		`"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}}`
+)
+
+type TestSnapshotRepository struct {
+	Repository     *xov1alpha1.ElasticSearchSnapshotRepository
+	SecretSettings map[string]string
+	R2R            []Responce2Req
+	Err            error
+	Msg            string
+}
+
+func newTestSnapshotRepository(repoType xov1alpha1.ESSnapshotRepositoryType,
+	settings map[string]string) *xov1alpha1.ElasticSearchSnapshotRepository {
+	return &xov1alpha1.ElasticSearchSnapshotRepository{
+		Spec: xov1alpha1.ElasticSearchSnapshotRepositorySpec{
+			Name:     "backups",
+			Type:     repoType,
+			Settings: settings,
+		},
+	}
+}
+
+func TestCreateUpdateSnapshotRepository(t *testing.T) {
+	fsSettings := map[string]string{"location": "/mnt/backups", "compress": "true"}
+	tests := []TestSnapshotRepository{
+		{
+			Repository: newTestSnapshotRepository(xov1alpha1.SnapshotRepositoryFS, fsSettings),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_snapshot/backups",
+					ResponceCode: 404,
+					Responce:     `{"error":{"type":"repository_missing_exception","reason":"[backups] missing"},"status":404}`,
+				},
+				{
+					RequestURI:   "/_snapshot/backups?verify=false",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+					RequestBody: `{"type":"fs","settings":{"location":"/mnt/backups","compress":"true",` +
+						`"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}`,
+				},
+			},
+			Msg: "successfully created snapshot repository backups",
+		},
+		{
+			Repository: newTestSnapshotRepository(xov1alpha1.SnapshotRepositoryFS, fsSettings),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_snapshot/backups",
+					ResponceCode: 200,
+					Responce:     SnapshotRepositoryGETanswer,
+				},
+			},
+			Msg: "no changes on snapshot repository named backups",
+		},
+		{
+			// Settings from Secrets override settings from spec
+			Repository: newTestSnapshotRepository(xov1alpha1.SnapshotRepositoryS3,
+				map[string]string{"bucket": "backups", "access_key": "placeholder"}),
+			SecretSettings: map[string]string{"access_key": "AKIDEXAMPLE", "secret_key": "secret"},
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_snapshot/backups",
+					ResponceCode: 200,
+					Responce:     SnapshotRepositoryGETanswer,
+				},
+				{
+					RequestURI:   "/_snapshot/backups?verify=false",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+					RequestBody: `{"type":"s3","settings":{"bucket":"backups","access_key":"AKIDEXAMPLE","secret_key":"secret",` +
+						`"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}`,
+				},
+			},
+			Msg: "successfully updated snapshot repository backups",
+		},
+		{
+			Repository: newTestSnapshotRepository(xov1alpha1.SnapshotRepositoryFS, fsSettings),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_snapshot/backups",
+					ResponceCode: 404,
+					Responce:     `{"error":{"type":"repository_missing_exception","reason":"[backups] missing"},"status":404}`,
+				},
+				{
+					RequestURI:   "/_snapshot/backups?verify=false",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":false}`,
+				},
+			},
+			Err: fmt.Errorf("can't acknowledge snapshot repository backups update"),
+		},
+	}
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		for _, test := range tests {
+			for _, r2r := range test.R2R {
+				testDoer.R2rChan <- r2r
+			}
+			msg, err := client.CreateUpdateSnapshotRepository(context.Background(), test.Repository, test.SecretSettings)
+			if test.Err != nil {
+				assert.EqualError(t, err, fmt.Sprintf("%s", test.Err))
+				continue
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.Msg, msg)
+		}
+	})
+}
+
+func TestSnapshotRepositoryExists(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups",
+			ResponceCode: 200,
+			Responce:     SnapshotRepositoryGETanswer,
+		}
+		exists, err := client.SnapshotRepositoryExists(context.Background(), "backups")
+		assert.NoError(t, err)
+		assert.True(t, exists)
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups",
+			ResponceCode: 404,
+			Responce:     `{"error":{"type":"repository_missing_exception","reason":"[backups] missing"},"status":404}`,
+		}
+		exists, err = client.SnapshotRepositoryExists(context.Background(), "backups")
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+}
+
+func TestVerifySnapshotRepository(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups/_verify",
+			ResponceCode: 200,
+			Responce:     `{"nodes":{"b3vqtONkSJudnHqFyUaHYw":{"name":"node-2"},"Yl5Bs2bKRsOkrYUzFAOhUw":{"name":"node-1"}}}`,
+		}
+		nodes, err := client.VerifySnapshotRepository(context.Background(), "backups")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"node-1", "node-2"}, nodes)
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups/_verify",
+			ResponceCode: 500,
+			Responce:     `{"error":{"type":"repository_verification_exception","reason":"[backups] path  is not accessible on master node"},"status":500}`,
+		}
+		_, err = client.VerifySnapshotRepository(context.Background(), "backups")
+		assert.Error(t, err)
+	})
+}
+
+func TestDeleteSnapshotRepository(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups",
+			ResponceCode: 200,
+			Responce:     SnapshotRepositoryGETanswer,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true}`,
+		}
+		assert.NoError(t, client.DeleteSnapshotRepository(context.Background(), "backups"))
+		// Already deleted repository is fine
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups",
+			ResponceCode: 404,
+			Responce:     `{"error":{"type":"repository_missing_exception","reason":"[backups] missing"},"status":404}`,
+		}
+		assert.NoError(t, client.DeleteSnapshotRepository(context.Background(), "backups"))
+		// Repository registered outside of operator is kept
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_snapshot/backups",
+			ResponceCode: 200,
+			Responce:     `{"backups":{"type":"fs","settings":{"location":"/mnt/backups"}}}`,
+		}
+		assert.ErrorIs(t, client.DeleteSnapshotRepository(context.Background(), "backups"), ErrNotManaged)
+	})
+}
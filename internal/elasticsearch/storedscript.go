@@ -0,0 +1,145 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+// StoredScript is configuration struct for ES stored script
+type StoredScript struct {
+	Lang   string `json:"lang"`
+	Source string `json:"source"`
+}
+
+// storedScriptResponse is answer of ES get stored script API
+type storedScriptResponse struct {
+	ID     string        `json:"_id"`
+	Found  bool          `json:"found"`
+	Script *StoredScript `json:"script"`
+}
+
+// renderTemplateRequest is body of ES render search template API
+type renderTemplateRequest struct {
+	Source string      `json:"source"`
+	Params interface{} `json:"params,omitempty"`
+}
+
+func storedScriptPath(id string) string {
+	return fmt.Sprintf("/_scripts/%s", url.PathEscape(id))
+}
+
+// StoredScriptExists would check if stored script exists
+func (c *Client) StoredScriptExists(ctx context.Context, id string) (bool, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	_, err := c.getStoredScript(ctx, id)
+	if errors.Is(err, errObjectNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("can't check if stored script exists: %w", err)
+	}
+	return true, nil
+}
+
+// CreateUpdateStoredScript would update stored script if it exists or create if not.
+// Stored scripts have no metadata, so script is only updated if status of object records it was created by operator.
+func (c *Client) CreateUpdateStoredScript(ctx context.Context, object *xov1alpha1.ElasticSearchStoredScript) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	script := newStoredScript(object)
+	if object.Spec.Validate {
+		err := c.renderTemplate(ctx, object)
+		if err != nil {
+			return "", err
+		}
+	}
+	retMsg := "successfully created stored script %s"
+	servScript, err := c.getStoredScript(ctx, object.Spec.ID)
+	if err != nil && !errors.Is(err, errObjectNotFound) {
+		return "", fmt.Errorf("can't get stored script: %w", err)
+	}
+	if servScript != nil {
+		if *servScript == *script {
+			return fmt.Sprintf("no changes on stored script named %s", object.Spec.ID), nil
+		}
+		if object.Status.ID != object.Spec.ID {
+			return "", fmt.Errorf("stored script '%s' is not managed by this operator", object.Spec.ID)
+		}
+		retMsg = "successfully updated stored script %s"
+	}
+	request := map[string]interface{}{
+		"script": script,
+	}
+	err = c.perform(ctx, http.MethodPut, storedScriptPath(object.Spec.ID), request, nil)
+	if err != nil {
+		return "", fmt.Errorf("can't put stored script: %w", err)
+	}
+	return fmt.Sprintf(retMsg, object.Spec.ID), nil
+}
+
+// DeleteStoredScript would delete stored script, script which doesn't exist is not an error
+func (c *Client) DeleteStoredScript(ctx context.Context, id string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.perform(ctx, http.MethodDelete, storedScriptPath(id), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("can't delete stored script %s: %w", id, err)
+	}
+	return nil
+}
+
+func (c *Client) getStoredScript(ctx context.Context, id string) (*StoredScript, error) {
+	answer := storedScriptResponse{}
+	err := c.perform(ctx, http.MethodGet, storedScriptPath(id), nil, &answer)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errObjectNotFound
+		}
+		return nil, err
+	}
+	if !answer.Found || answer.Script == nil {
+		return nil, errObjectNotFound
+	}
+	return answer.Script, nil
+}
+
+// renderTemplate would render search template with sample params, so broken template is not stored
+func (c *Client) renderTemplate(ctx context.Context, object *xov1alpha1.ElasticSearchStoredScript) error {
+	if object.Spec.Lang != "mustache" {
+		return fmt.Errorf("only mustache search templates can be validated, stored script %s has lang %s",
+			object.Spec.ID, object.Spec.Lang)
+	}
+	request := renderTemplateRequest{
+		Source: object.Spec.Source,
+	}
+	if len(object.Spec.Params) > 0 {
+		err := json.Unmarshal([]byte(object.Spec.Params), &request.Params)
+		if err != nil {
+			return fmt.Errorf("can't unmarhsal Params string: %w", err)
+		}
+	}
+	err := c.perform(ctx, http.MethodPost, "/_render/template", request, nil)
+	if err != nil {
+		return fmt.Errorf("search template %s failed to render: %w", object.Spec.ID, err)
+	}
+	return nil
+}
+
+// newStoredScript would make stored script ES understands from K8S object
+func newStoredScript(object *xov1alpha1.ElasticSearchStoredScript) *StoredScript {
+	script := &StoredScript{
+		Lang:   object.Spec.Lang,
+		Source: object.Spec.Source,
+	}
+	if len(script.Lang) == 0 {
+		script.Lang = "painless"
+	}
+	return script
+}
@@ -0,0 +1,144 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+const (
+	// Used by Doer to mock ES answer on get stored script request
+	StoredScriptGETanswer = `{"_id":"orders-search","found":true,"script":{"lang":"mustache",
+		"source":"{\"query\":{\"match\":{\"customer\":\"{{customer}}\"}}}","options":{"content_type":"application/json; charset=UTF-8"}}}`
+	// Used by Doer to mock ES answer on stored script which doesn't exist
+	StoredScriptNotFoundAnswer = `{"_id":"orders-search","found":false}`
+)
+
+func newTestStoredScript(source string) *xov1alpha1.ElasticSearchStoredScript {
+	return &xov1alpha1.ElasticSearchStoredScript{
+		Spec: xov1alpha1.ElasticSearchStoredScriptSpec{
+			ID:     "orders-search",
+			Lang:   "mustache",
+			Source: source,
+		},
+	}
+}
+
+func TestCreateUpdateStoredScript(t *testing.T) {
+	source := `{"query":{"match":{"customer":"{{customer}}"}}}`
+	newSource := `{"query":{"term":{"customer":"{{customer}}"}}}`
+	managed := newTestStoredScript(newSource)
+	managed.Status.ID = "orders-search"
+	validated := newTestStoredScript(source)
+	validated.Spec.Validate = true
+	validated.Spec.Params = `{"customer":"acme"}`
+	tests := []requestTest[*xov1alpha1.ElasticSearchStoredScript, string]{
+		{
+			Object: newTestStoredScript(source),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_scripts/orders-search",
+					ResponceCode: 404,
+					Responce:     StoredScriptNotFoundAnswer,
+				},
+				{
+					RequestURI:   "/_scripts/orders-search",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+					RequestBody:  `{"script":{"lang":"mustache","source":"{\"query\":{\"match\":{\"customer\":\"{{customer}}\"}}}"}}`,
+				},
+			},
+			Want: "successfully created stored script orders-search",
+		},
+		{
+			Object: newTestStoredScript(source),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_scripts/orders-search",
+					ResponceCode: 200,
+					Responce:     StoredScriptGETanswer,
+				},
+			},
+			Want: "no changes on stored script named orders-search",
+		},
+		{
+			// Script created outside of operator is not changed
+			Object: newTestStoredScript(newSource),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_scripts/orders-search",
+					ResponceCode: 200,
+					Responce:     StoredScriptGETanswer,
+				},
+			},
+			Err: "stored script 'orders-search' is not managed by this operator",
+		},
+		{
+			Object: managed,
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_scripts/orders-search",
+					ResponceCode: 200,
+					Responce:     StoredScriptGETanswer,
+				},
+				{
+					RequestURI:   "/_scripts/orders-search",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+					RequestBody:  `{"script":{"lang":"mustache","source":"{\"query\":{\"term\":{\"customer\":\"{{customer}}\"}}}"}}`,
+				},
+			},
+			Want: "successfully updated stored script orders-search",
+		},
+		{
+			Object: validated,
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_render/template",
+					ResponceCode: 200,
+					Responce:     `{"template_output":{"query":{"match":{"customer":"acme"}}}}`,
+					RequestBody:  `{"source":"{\"query\":{\"match\":{\"customer\":\"{{customer}}\"}}}","params":{"customer":"acme"}}`,
+				},
+				{
+					RequestURI:   "/_scripts/orders-search",
+					ResponceCode: 200,
+					Responce:     StoredScriptGETanswer,
+				},
+			},
+			Want: "no changes on stored script named orders-search",
+		},
+		{
+			// Template which fails to render is not stored
+			Object: validated,
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_render/template",
+					ResponceCode: 400,
+					Responce:     `{"error":{"type":"general_script_exception","reason":"Failed to compile inline script"},"status":400}`,
+				},
+			},
+			Err: "search template orders-search failed to render",
+		},
+	}
+	runRequestTests(t, tests, (*Client).CreateUpdateStoredScript)
+}
+
+func TestDeleteStoredScript(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_scripts/orders-search",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true}`,
+		}
+		assert.NoError(t, client.DeleteStoredScript(context.Background(), "orders-search"))
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_scripts/orders-search",
+			ResponceCode: 404,
+			Responce:     StoredScriptNotFoundAnswer,
+		}
+		assert.NoError(t, client.DeleteStoredScript(context.Background(), "orders-search"))
+	})
+}
@@ -0,0 +1,308 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+// Synonyms are synonym rules of index synonym filters, keyed by filter name
+type Synonyms map[string][]string
+
+// synonymsSet is body and answer of ES synonyms API
+type synonymsSet struct {
+	SynonymsSet []synonymRule `json:"synonyms_set"`
+}
+
+// synonymRule is synonym rule of synonyms set
+type synonymRule struct {
+	ID       string `json:"id,omitempty"`
+	Synonyms string `json:"synonyms"`
+}
+
+// synonymsSetMaxSize is maximum number of rules ES returns for synonyms set
+const synonymsSetMaxSize = 10000
+
+// SynonymsSetName would return name of synonyms set of index synonym filter
+func SynonymsSetName(index, filter string) string {
+	return fmt.Sprintf("%s-%s", index, filter)
+}
+
+func synonymsSetPath(name string) string {
+	return fmt.Sprintf("/_synonyms/%s", url.PathEscape(name))
+}
+
+// SynonymsSetsSupported would check if synonyms are stored in synonyms sets, ES synonyms API is available
+// since Elasticsearch 8.10. Otherwise synonyms are stored in index settings.
+func (c *Client) SynonymsSetsSupported(ctx context.Context) (bool, error) {
+	flavor, err := c.Flavor(ctx)
+	if err != nil {
+		return false, err
+	}
+	if flavor != FlavorElasticsearch {
+		return false, nil
+	}
+	version, err := c.Version(ctx)
+	if err != nil {
+		return false, err
+	}
+	major, minor := parseVersion(version)
+	return major > 8 || (major == 8 && minor >= 10), nil
+}
+
+// putSynonymsSets would create or update synonyms sets of index synonym filters, it returns filters which were updated.
+// ES reloads search analyzers which use synonyms set once it is updated.
+func (c *Client) putSynonymsSets(ctx context.Context, object *xov1alpha1.ElasticSearchIndex, synonyms Synonyms) ([]string, error) {
+	updated := []string{}
+	for _, filter := range object.Spec.Synonyms {
+		rules, ok := synonyms[filter.Filter]
+		if !ok {
+			return nil, fmt.Errorf("no synonyms for synonym filter %s", filter.Filter)
+		}
+		name := SynonymsSetName(object.Spec.Name, filter.Filter)
+		servSet := synonymsSet{}
+		err := c.perform(ctx, http.MethodGet, fmt.Sprintf("%s?size=%d", synonymsSetPath(name), synonymsSetMaxSize), nil, &servSet)
+		if err != nil && !isNotFound(err) {
+			return nil, fmt.Errorf("can't get synonyms set %s: %w", name, err)
+		}
+		if err == nil && sameSynonyms(servSet, rules) {
+			continue
+		}
+		request := synonymsSet{
+			SynonymsSet: make([]synonymRule, 0, len(rules)),
+		}
+		for _, rule := range rules {
+			request.SynonymsSet = append(request.SynonymsSet, synonymRule{Synonyms: rule})
+		}
+		err = c.perform(ctx, http.MethodPut, synonymsSetPath(name), request, nil)
+		if err != nil {
+			return nil, fmt.Errorf("can't put synonyms set %s: %w", name, err)
+		}
+		updated = append(updated, filter.Filter)
+	}
+	return updated, nil
+}
+
+// DeleteSynonymsSets would delete synonyms sets of index synonym filters, sets which don't exist are skipped.
+// Sets can only be deleted once no index uses them.
+func (c *Client) DeleteSynonymsSets(ctx context.Context, object *xov1alpha1.ElasticSearchIndex) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	if len(object.Spec.Synonyms) == 0 {
+		return nil
+	}
+	useSets, err := c.SynonymsSetsSupported(ctx)
+	if err != nil || !useSets {
+		return err
+	}
+	for _, filter := range object.Spec.Synonyms {
+		name := SynonymsSetName(object.Spec.Name, filter.Filter)
+		err = c.perform(ctx, http.MethodDelete, synonymsSetPath(name), nil, nil)
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf("can't delete synonyms set %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// sameSynonyms would check if synonyms set has same rules, order of rules doesn't matter
+func sameSynonyms(set synonymsSet, rules []string) bool {
+	servRules := make([]string, 0, len(set.SynonymsSet))
+	for _, rule := range set.SynonymsSet {
+		servRules = append(servRules, rule.Synonyms)
+	}
+	desired := slices.Clone(rules)
+	slices.Sort(servRules)
+	slices.Sort(desired)
+	return slices.Equal(servRules, desired)
+}
+
+// indexAnalysis would make analysis settings of index from spec with synonym filters added.
+// Synonym filter refers to synonyms set if ES synonyms API is used or has synonym rules inline otherwise.
+func indexAnalysis(object *xov1alpha1.ElasticSearchIndex, synonyms Synonyms, useSets bool) (map[string]interface{}, error) {
+	if len(object.Spec.Analysis) == 0 && len(object.Spec.Synonyms) == 0 {
+		return nil, nil
+	}
+	analysis := map[string]interface{}{}
+	if len(object.Spec.Analysis) > 0 {
+		err := json.Unmarshal([]byte(object.Spec.Analysis), &analysis)
+		if err != nil {
+			return nil, fmt.Errorf("can't unmarhsal Analysis string: %w", err)
+		}
+	}
+	if len(object.Spec.Synonyms) == 0 {
+		return analysis, nil
+	}
+	filters, ok := analysis["filter"].(map[string]interface{})
+	if !ok {
+		filters = map[string]interface{}{}
+		analysis["filter"] = filters
+	}
+	for _, filter := range object.Spec.Synonyms {
+		rules, ok := synonyms[filter.Filter]
+		if !ok {
+			return nil, fmt.Errorf("no synonyms for synonym filter %s", filter.Filter)
+		}
+		definition := map[string]interface{}{
+			"type": filter.Type,
+		}
+		if len(filter.Type) == 0 {
+			definition["type"] = "synonym_graph"
+		}
+		if filter.Updateable {
+			definition["updateable"] = true
+		}
+		if useSets {
+			definition["synonyms_set"] = SynonymsSetName(object.Spec.Name, filter.Filter)
+		} else {
+			definition["synonyms"] = rules
+		}
+		filters[filter.Filter] = definition
+	}
+	return analysis, nil
+}
+
+// changedInlineSynonyms would return synonym filters of analysis which synonym rules differ from index settings
+func changedInlineSynonyms(object *xov1alpha1.ElasticSearchIndex, analysis map[string]interface{},
+	servSettings map[string]interface{}) map[string]interface{} {
+	changed := map[string]interface{}{}
+	filters, _ := analysis["filter"].(map[string]interface{})
+	servFilters, _ := getValueFromSettings(servSettings, "index.analysis.filter")
+	servFiltersMap, _ := servFilters.(map[string]interface{})
+	for _, filter := range object.Spec.Synonyms {
+		definition, _ := filters[filter.Filter].(map[string]interface{})
+		rules, ok := definition["synonyms"].([]string)
+		if !ok {
+			// Filter refers to synonyms set
+			continue
+		}
+		servFilter, _ := servFiltersMap[filter.Filter].(map[string]interface{})
+		servRules, _ := servFilter["synonyms"].([]interface{})
+		if len(servRules) == len(rules) {
+			same := true
+			for i := range rules {
+				if fmt.Sprint(servRules[i]) != rules[i] {
+					same = false
+					break
+				}
+			}
+			if same {
+				continue
+			}
+		}
+		changed[filter.Filter] = definition
+	}
+	return changed
+}
+
+// InlineSynonymsChange is change of inline synonyms of existing index, index must be closed for it
+type InlineSynonymsChange struct {
+	// Index synonyms are changed on, it is current write index in rollover mode
+	Index string
+	// Filters with close_index set, index is closed to update them
+	Closed []string
+	// Filters without close_index set, they are not updated
+	Pending []string
+}
+
+// InlineSynonymsChanges would get change of inline synonyms CreateUpdateIndex is going to make, nil is returned
+// if there is none. Index which uses synonyms sets or doesn't exist yet is never closed.
+func (c *Client) InlineSynonymsChanges(ctx context.Context, object *xov1alpha1.ElasticSearchIndex,
+	synonyms Synonyms) (*InlineSynonymsChange, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	if len(object.Spec.Synonyms) == 0 {
+		return nil, nil
+	}
+	useSets, err := c.SynonymsSetsSupported(ctx)
+	if err != nil || useSets {
+		return nil, err
+	}
+	analysis, err := indexAnalysis(object, synonyms, false)
+	if err != nil {
+		return nil, err
+	}
+	index := object.Spec.Name
+	if object.Spec.Rollover != nil {
+		index, err = c.getWriteIndex(ctx, object.Spec.Name)
+		if errors.Is(err, errObjectNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("can't get write index of alias %s: %w", object.Spec.Name, err)
+		}
+	}
+	servSettings, _, err := c.getServerIndexSettingsAndMappings(ctx, index)
+	if errors.Is(err, errObjectNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't get ES index %s: %w", index, err)
+	}
+	changed := changedInlineSynonyms(object, analysis, servSettings)
+	if len(changed) == 0 {
+		return nil, nil
+	}
+	change := &InlineSynonymsChange{
+		Index: index,
+	}
+	for _, filter := range object.Spec.Synonyms {
+		if _, ok := changed[filter.Filter]; !ok {
+			continue
+		}
+		if filter.CloseIndex {
+			change.Closed = append(change.Closed, filter.Filter)
+		} else {
+			change.Pending = append(change.Pending, filter.Filter)
+		}
+	}
+	return change, nil
+}
+
+// updateInlineSynonyms would close index, update synonym filters in its settings and open it again, as analysis
+// settings can't be changed on open index. Search analyzers of updateable filters are reloaded afterwards.
+func (c *Client) updateInlineSynonyms(ctx context.Context, object *xov1alpha1.ElasticSearchIndex, index string,
+	filters map[string]interface{}) error {
+	err := c.perform(ctx, http.MethodPost, indexPath(index)+"/_close", nil, nil)
+	if err != nil {
+		return fmt.Errorf("can't close ES index %s to update synonyms: %w", index, err)
+	}
+	request := map[string]interface{}{
+		"analysis": map[string]interface{}{
+			"filter": filters,
+		},
+	}
+	err = c.perform(ctx, http.MethodPut, indexPath(index)+"/_settings", request, nil)
+	if err != nil {
+		err = fmt.Errorf("can't update synonyms of ES index %s: %w", index, err)
+	}
+	// Index is opened even if update has failed
+	openErr := c.perform(ctx, http.MethodPost, indexPath(index)+"/_open", nil, nil)
+	if openErr != nil {
+		return errors.Join(err, fmt.Errorf("can't open ES index %s: %w", index, openErr))
+	}
+	if err != nil {
+		return err
+	}
+	// Reload API is Elasticsearch only, reopened index has new analyzers anyway
+	flavor, err := c.Flavor(ctx)
+	if err != nil || flavor != FlavorElasticsearch {
+		return err
+	}
+	for _, filter := range object.Spec.Synonyms {
+		if _, ok := filters[filter.Filter]; ok && filter.Updateable {
+			err = c.perform(ctx, http.MethodPost, indexPath(index)+"/_reload_search_analyzers", nil, nil)
+			if err != nil {
+				return fmt.Errorf("can't reload search analyzers of ES index %s: %w", index, err)
+			}
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,291 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+const (
+	// Used by Doer to mock ES answer on get index request for index with inline synonyms
+	SynonymsIndexGETanswer = `{"products":{"aliases":{},
+		"mappings":{"_meta":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"},"properties":{"name":{"type":"text"}}},
+		"settings":{"index":{"number_of_shards":"1","provided_name":"products","analysis":{
+			"filter":{"product_synonyms":{"type":"synonym_graph","updateable":"true","synonyms":["tv, television"]}},
+			"analyzer":{"product_search":{"tokenizer":"standard","filter":["lowercase","product_synonyms"]}}}}}}}`
+	// Used by Doer to mock ES answer on get index request for index with synonyms set
+	SynonymsSetIndexGETanswer = `{"products":{"aliases":{},
+		"mappings":{"_meta":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"},"properties":{"name":{"type":"text"}}},
+		"settings":{"index":{"number_of_shards":"1","provided_name":"products","analysis":{
+			"filter":{"product_synonyms":{"type":"synonym_graph","updateable":"true","synonyms_set":"products-product_synonyms"}},
+			"analyzer":{"product_search":{"tokenizer":"standard","filter":["lowercase","product_synonyms"]}}}}}}}`
+	// Used by Doer to mock ES answer on get synonyms set request
+	SynonymsSetGETanswer = `{"count":2,"synonyms_set":[{"id":"b2","synonyms":"laptop, notebook"},{"id":"a1","synonyms":"tv, television"}]}`
+)
+
+func newTestSynonymsIndex() *xov1alpha1.ElasticSearchIndex {
+	return &xov1alpha1.ElasticSearchIndex{
+		Spec: xov1alpha1.ElasticSearchIndexSpec{
+			Name:     "products",
+			Mappings: `{"properties":{"name":{"type":"text"}}}`,
+			Analysis: `{"analyzer":{"product_search":{"tokenizer":"standard","filter":["lowercase","product_synonyms"]}}}`,
+			Synonyms: []xov1alpha1.ESIndexSynonyms{
+				{
+					Filter:     "product_synonyms",
+					Type:       "synonym_graph",
+					Updateable: true,
+					CloseIndex: true,
+					ConfigMapRef: corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "product-synonyms"},
+						Key:                  "synonyms.txt",
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestIndexAnalysis(t *testing.T) {
+	index := newTestSynonymsIndex()
+	synonyms := Synonyms{"product_synonyms": {"tv, television"}}
+	analysis, err := indexAnalysis(index, synonyms, false)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"analyzer": map[string]interface{}{
+			"product_search": map[string]interface{}{
+				"tokenizer": "standard",
+				"filter":    []interface{}{"lowercase", "product_synonyms"},
+			},
+		},
+		"filter": map[string]interface{}{
+			"product_synonyms": map[string]interface{}{
+				"type":       "synonym_graph",
+				"updateable": true,
+				"synonyms":   []string{"tv, television"},
+			},
+		},
+	}, analysis)
+
+	analysis, err = indexAnalysis(index, synonyms, true)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"type":         "synonym_graph",
+		"updateable":   true,
+		"synonyms_set": "products-product_synonyms",
+	}, analysis["filter"].(map[string]interface{})["product_synonyms"])
+
+	_, err = indexAnalysis(index, Synonyms{}, false)
+	assert.EqualError(t, err, "no synonyms for synonym filter product_synonyms")
+
+	analysis, err = indexAnalysis(&xov1alpha1.ElasticSearchIndex{}, nil, false)
+	assert.NoError(t, err)
+	assert.Nil(t, analysis)
+}
+
+func TestUpdateInlineSynonyms(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		client.version = "7.17.9"
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/products",
+			ResponceCode: 200,
+			Responce:     SynonymsIndexGETanswer,
+		}
+		msg, err := client.CreateUpdateIndex(context.Background(), newTestSynonymsIndex(),
+			Synonyms{"product_synonyms": {"tv, television"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "no changes on index named products", msg)
+
+		// Index is closed to update synonyms
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/products",
+			ResponceCode: 200,
+			Responce:     SynonymsIndexGETanswer,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/products/_close",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true,"shards_acknowledged":true}`,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/products/_settings",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true}`,
+			RequestBody: `{"analysis":{"filter":{"product_synonyms":{"type":"synonym_graph","updateable":true,
+				"synonyms":["tv, television","laptop, notebook"]}}}}`,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/products/_open",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true,"shards_acknowledged":true}`,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/products/_reload_search_analyzers",
+			ResponceCode: 200,
+			Responce:     `{"_shards":{"total":2,"successful":2,"failed":0},"reload_details":[]}`,
+		}
+		msg, err = client.CreateUpdateIndex(context.Background(), newTestSynonymsIndex(),
+			Synonyms{"product_synonyms": {"tv, television", "laptop, notebook"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "successfully updated ES index products", msg)
+
+		// Index is opened again if update fails
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/products",
+			ResponceCode: 200,
+			Responce:     SynonymsIndexGETanswer,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/products/_close",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true,"shards_acknowledged":true}`,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/products/_settings",
+			ResponceCode: 400,
+			Responce:     `{"error":{"type":"illegal_argument_exception","reason":"failed to build synonyms"},"status":400}`,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/products/_open",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true,"shards_acknowledged":true}`,
+		}
+		_, err = client.CreateUpdateIndex(context.Background(), newTestSynonymsIndex(),
+			Synonyms{"product_synonyms": {"tv => => television"}})
+		assert.ErrorContains(t, err, "can't update synonyms of ES index products")
+	})
+}
+
+func TestInlineSynonymsChanges(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		client.version = "7.17.9"
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/products",
+			ResponceCode: 200,
+			Responce:     SynonymsIndexGETanswer,
+		}
+		change, err := client.InlineSynonymsChanges(context.Background(), newTestSynonymsIndex(),
+			Synonyms{"product_synonyms": {"tv, television"}})
+		assert.NoError(t, err)
+		assert.Nil(t, change)
+
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/products",
+			ResponceCode: 200,
+			Responce:     SynonymsIndexGETanswer,
+		}
+		change, err = client.InlineSynonymsChanges(context.Background(), newTestSynonymsIndex(),
+			Synonyms{"product_synonyms": {"tv, television", "laptop, notebook"}})
+		assert.NoError(t, err)
+		assert.Equal(t, &InlineSynonymsChange{Index: "products", Closed: []string{"product_synonyms"}}, change)
+
+		// Filter which doesn't allow to close index is not updated
+		notClosed := newTestSynonymsIndex()
+		notClosed.Spec.Synonyms[0].CloseIndex = false
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/products",
+			ResponceCode: 200,
+			Responce:     SynonymsIndexGETanswer,
+		}
+		change, err = client.InlineSynonymsChanges(context.Background(), notClosed,
+			Synonyms{"product_synonyms": {"tv, television", "laptop, notebook"}})
+		assert.NoError(t, err)
+		assert.Equal(t, &InlineSynonymsChange{Index: "products", Pending: []string{"product_synonyms"}}, change)
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/products",
+			ResponceCode: 200,
+			Responce:     SynonymsIndexGETanswer,
+		}
+		msg, err := client.CreateUpdateIndex(context.Background(), notClosed,
+			Synonyms{"product_synonyms": {"tv, television", "laptop, notebook"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "no changes on index named products", msg)
+
+		// Index which doesn't exist yet is created with synonyms
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/products",
+			ResponceCode: 404,
+			Responce:     `{"error":{"type":"index_not_found_exception","reason":"no such index [products]"},"status":404}`,
+		}
+		change, err = client.InlineSynonymsChanges(context.Background(), newTestSynonymsIndex(),
+			Synonyms{"product_synonyms": {"tv, television", "laptop, notebook"}})
+		assert.NoError(t, err)
+		assert.Nil(t, change)
+
+		// Synonyms sets are updated without closing index
+		client.version = "8.11.0"
+		change, err = client.InlineSynonymsChanges(context.Background(), newTestSynonymsIndex(),
+			Synonyms{"product_synonyms": {"tv, television", "laptop, notebook"}})
+		assert.NoError(t, err)
+		assert.Nil(t, change)
+	})
+}
+
+func TestSynonymsSets(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		client.version = "8.11.0"
+		// Order of rules doesn't matter
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_synonyms/products-product_synonyms?size=10000",
+			ResponceCode: 200,
+			Responce:     SynonymsSetGETanswer,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/products",
+			ResponceCode: 200,
+			Responce:     SynonymsSetIndexGETanswer,
+		}
+		msg, err := client.CreateUpdateIndex(context.Background(), newTestSynonymsIndex(),
+			Synonyms{"product_synonyms": {"tv, television", "laptop, notebook"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "no changes on index named products", msg)
+
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_synonyms/products-product_synonyms?size=10000",
+			ResponceCode: 200,
+			Responce:     SynonymsSetGETanswer,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_synonyms/products-product_synonyms",
+			ResponceCode: 200,
+			Responce:     `{"result":"updated","reload_analyzers_details":{"_shards":{"total":2,"successful":2,"failed":0}}}`,
+			RequestBody:  `{"synonyms_set":[{"synonyms":"tv, television"}]}`,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/products",
+			ResponceCode: 200,
+			Responce:     SynonymsSetIndexGETanswer,
+		}
+		msg, err = client.CreateUpdateIndex(context.Background(), newTestSynonymsIndex(),
+			Synonyms{"product_synonyms": {"tv, television"}})
+		assert.NoError(t, err)
+		assert.Equal(t, "no changes on index named products, updated synonyms of filters product_synonyms", msg)
+
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_synonyms/products-product_synonyms",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true}`,
+		}
+		assert.NoError(t, client.DeleteSynonymsSets(context.Background(), newTestSynonymsIndex()))
+	})
+}
+
+func TestSynonymsSetsSupported(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		for version, supported := range map[string]bool{"7.17.9": false, "8.9.2": false, "8.10.0": true, "9.0.0": true} {
+			client.version = version
+			ok, err := client.SynonymsSetsSupported(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, supported, ok, version)
+		}
+		client.flavor = FlavorOpenSearch
+		ok, err := client.SynonymsSetsSupported(context.Background())
+		assert.NoError(t, err)
+		assert.False(t, ok)
+		// Nothing to delete without synonyms sets
+		assert.NoError(t, client.DeleteSynonymsSets(context.Background(), newTestSynonymsIndex()))
+	})
+}
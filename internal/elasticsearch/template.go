@@ -5,10 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-
-	"github.com/olivere/elastic/v7"
+	"net/http"
+	"net/url"
 
 	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/consts"
 )
 
 // ESAlias has struct same as xov1alpha1.ESAlias, but replaces Filter with interface
@@ -31,6 +32,47 @@ type Template struct {
 	Version       int64              `json:"version,omitempty"`
 }
 
+// ComposableTemplate is configuration struct for ES composable index template creation
+type ComposableTemplate struct {
+	IndexPatterns []string          `json:"index_patterns"`
+	Template      TemplateBody      `json:"template"`
+	Priority      int64             `json:"priority,omitempty"`
+	Version       int64             `json:"version,omitempty"`
+	DataStream    *DataStreamConfig `json:"data_stream,omitempty"`
+	// Meta is copied to data streams created from template
+	Meta map[string]interface{} `json:"_meta,omitempty"`
+}
+
+// DataStreamConfig enables data stream creation for indices matching composable template
+type DataStreamConfig struct{}
+
+// TemplateBody is part of composable index template applied to new indices
+type TemplateBody struct {
+	Aliases  map[string]ESAlias `json:"aliases,omitempty"`
+	Mappings interface{}        `json:"mappings"`
+	Settings Settings           `json:"settings,omitempty"`
+}
+
+// legacyTemplate is answer of ES get legacy template API for one template
+type legacyTemplate struct {
+	Settings map[string]interface{} `json:"settings"`
+}
+
+// composableTemplates is answer of ES get composable index template API
+type composableTemplates struct {
+	IndexTemplates []struct {
+		Name          string `json:"name"`
+		IndexTemplate struct {
+			IndexPatterns []string `json:"index_patterns"`
+			Priority      int64    `json:"priority"`
+			Template      struct {
+				Settings map[string]interface{} `json:"settings"`
+			} `json:"template"`
+			DataStream *DataStreamConfig `json:"data_stream"`
+		} `json:"index_template"`
+	} `json:"index_templates"`
+}
+
 func (c *Client) createESAlias(original map[string]xov1alpha1.ESAlias) (map[string]ESAlias, error) {
 	newAliases := make(map[string]ESAlias, len(original))
 	for key, value := range original {
@@ -53,21 +95,58 @@ func (c *Client) createESAlias(original map[string]xov1alpha1.ESAlias) (map[stri
 	return newAliases, nil
 }
 
-func (c *Client) TemplateExists(name string) (bool, error) {
-	ctx := context.Background()
-	exists, err := c.es.IndexTemplateExists(name).Do(ctx) // nolint
+// TemplateExists would check if template exists
+func (c *Client) TemplateExists(ctx context.Context, tmpl *xov1alpha1.ElasticSearchTemplate) (bool, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	composable, err := c.isComposable(ctx, tmpl)
+	if err != nil {
+		return false, err
+	}
+	exists, err := c.exists(ctx, templatePath(tmpl.Spec.Name, composable))
 	if err != nil {
 		return false, fmt.Errorf("can't check if template exists: %w", err)
 	}
 	return exists, nil
 }
 
+// isComposable would check if template must be managed with composable index template API.
+// OpenSearch clusters and data stream templates only get composable templates.
+func (c *Client) isComposable(ctx context.Context, tmpl *xov1alpha1.ElasticSearchTemplate) (bool, error) {
+	if tmpl.Spec.Composable || tmpl.Spec.DataStream {
+		return true, nil
+	}
+	flavor, err := c.Flavor(ctx)
+	if err != nil {
+		return false, err
+	}
+	return flavor == FlavorOpenSearch, nil
+}
+
+// templatePath would return path of legacy or composable template
+func templatePath(name string, composable bool) string {
+	if composable {
+		return fmt.Sprintf("/_index_template/%s", url.PathEscape(name))
+	}
+	return fmt.Sprintf("/_template/%s", url.PathEscape(name))
+}
+
 // CreateUpdateTemplate is going to update ES template with template user provides or create a new one
 // nolint
-func (c *Client) CreateUpdateTemplate(modified *xov1alpha1.ElasticSearchTemplate) (string, error) {
+func (c *Client) CreateUpdateTemplate(ctx context.Context, modified *xov1alpha1.ElasticSearchTemplate) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
 	retMsg := "successfully created ES template %s"
+	err := c.checkSettingsFlavor(ctx, &modified.Spec.Settings)
+	if err != nil {
+		return "", err
+	}
+	composable, err := c.isComposable(ctx, modified)
+	if err != nil {
+		return "", err
+	}
 	// Check if template exists
-	servSettings, err := c.getServerTemplateSettings(modified.Spec.Name)
+	servSettings, err := c.getServerTemplateSettings(ctx, modified.Spec.Name, composable)
 	if err != nil && !errors.Is(err, errObjectNotFound) {
 		// Error is not NotFound - report back
 		return "", fmt.Errorf("can't get template settings: %w", err)
@@ -106,16 +185,37 @@ func (c *Client) CreateUpdateTemplate(modified *xov1alpha1.ElasticSearchTemplate
 		return "", fmt.Errorf("can't add managed-by 2 ES index: %w", err)
 	}
 	// Update template
-	err = c.createOrUpdateTemplate(modified.Spec.Name, modIndex)
+	var body interface{} = modIndex
+	if composable {
+		composableTmpl := &ComposableTemplate{
+			IndexPatterns: modIndex.IndexPatterns,
+			Template: TemplateBody{
+				Aliases:  modIndex.Aliases,
+				Mappings: modIndex.Mappings,
+				Settings: modIndex.Settings,
+			},
+			Priority: modified.Spec.Priority,
+			Version:  modIndex.Version,
+		}
+		if modified.Spec.DataStream {
+			composableTmpl.DataStream = &DataStreamConfig{}
+			// Marks data streams created from template as managed by operator
+			composableTmpl.Meta = map[string]interface{}{
+				consts.ESManagedByField: consts.ESManagedByValue,
+			}
+		}
+		body = composableTmpl
+	}
+	err = c.createOrUpdateTemplate(ctx, templatePath(modified.Spec.Name, composable), body)
 	if err != nil {
 		return "", err
 	}
 	return fmt.Sprintf(retMsg, modified.Spec.Name), nil
 }
 
-func (c *Client) createOrUpdateTemplate(name string, settings interface{}) error {
-	ctx := context.Background()
-	createTemplate, err := c.es.IndexPutTemplate(name).BodyJson(settings).Do(ctx) // nolint
+func (c *Client) createOrUpdateTemplate(ctx context.Context, path string, settings interface{}) error {
+	createTemplate := acknowledgedResponse{}
+	err := c.perform(ctx, http.MethodPut, path, settings, &createTemplate)
 	if err != nil {
 		// Handle error
 		return fmt.Errorf("can't create or update ES template: %w", err)
@@ -127,14 +227,16 @@ func (c *Client) createOrUpdateTemplate(name string, settings interface{}) error
 	return nil
 }
 
-func (c *Client) getServerTemplateSettings(tmplName string) (map[string]interface{}, error) {
-	settings, err := c.es.IndexGetTemplate(tmplName).Do(context.Background()) // nolint
+func (c *Client) getServerTemplateSettings(ctx context.Context, tmplName string,
+	composable bool) (map[string]interface{}, error) {
+	if composable {
+		return c.getServerComposableTemplateSettings(ctx, tmplName)
+	}
+	settings := map[string]legacyTemplate{}
+	err := c.perform(ctx, http.MethodGet, templatePath(tmplName, false), nil, &settings)
 	if err != nil {
-		if newErr, ok := err.(*elastic.Error); ok {
-			// Elastic error, we could check status
-			if newErr.Status == 404 {
-				return nil, errObjectNotFound
-			}
+		if isNotFound(err) {
+			return nil, errObjectNotFound
 		}
 		return nil, err
 	}
@@ -145,9 +247,35 @@ func (c *Client) getServerTemplateSettings(tmplName string) (map[string]interfac
 	return tmplSettings.Settings, nil
 }
 
+func (c *Client) getServerComposableTemplateSettings(ctx context.Context,
+	tmplName string) (map[string]interface{}, error) {
+	templates := composableTemplates{}
+	err := c.perform(ctx, http.MethodGet, templatePath(tmplName, true), nil, &templates)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errObjectNotFound
+		}
+		return nil, err
+	}
+	for _, tmpl := range templates.IndexTemplates {
+		if tmpl.Name == tmplName {
+			return tmpl.IndexTemplate.Template.Settings, nil
+		}
+	}
+	return nil, fmt.Errorf("no settings")
+}
+
 // DeleteTemplate would delete ES template
-func (c *Client) DeleteTemplate(name string) error {
-	delTemplate, err := c.es.IndexDeleteTemplate(name).Do(context.Background()) // nolint
+func (c *Client) DeleteTemplate(ctx context.Context, tmpl *xov1alpha1.ElasticSearchTemplate) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	name := tmpl.Spec.Name
+	composable, err := c.isComposable(ctx, tmpl)
+	if err != nil {
+		return err
+	}
+	delTemplate := acknowledgedResponse{}
+	err = c.perform(ctx, http.MethodDelete, templatePath(name, composable), nil, &delTemplate)
 	if err != nil {
 		return fmt.Errorf("can't delete template %s: %w", name, err)
 	}
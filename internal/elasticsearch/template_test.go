@@ -1,6 +1,7 @@
 package elasticsearch
 
 import (
+	"context"
 	"fmt"
 	"sort"
 	"testing"
@@ -23,11 +24,6 @@ type TestUpdateTempl struct {
 }
 
 func TestCreateUpdateTemplate(t *testing.T) {
-	var testDoer *TestDoer
-	client := Client{}
-	client.esURL = "http://localhost:80"
-	client.es, testDoer = setupCreateTestClient(t)
-	defer testDoer.Close()
 	tests := []TestUpdateTempl{
 		{
 			Templ: &xov1alpha1.ElasticSearchTemplate{
@@ -203,31 +199,28 @@ func TestCreateUpdateTemplate(t *testing.T) {
 			Err: fmt.Errorf("can't acknowledge ES template creation/update"),
 		},
 	}
-	for _, test := range tests {
-		r2rKeys := make([]int, 0, len(test.R2R))
-		for key := range test.R2R {
-			r2rKeys = append(r2rKeys, key)
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		for _, test := range tests {
+			r2rKeys := make([]int, 0, len(test.R2R))
+			for key := range test.R2R {
+				r2rKeys = append(r2rKeys, key)
+			}
+			sort.Ints(r2rKeys)
+			for _, value := range r2rKeys {
+				testDoer.R2rChan <- test.R2R[value]
+			}
+			msg, err := client.CreateUpdateTemplate(context.Background(), test.Templ)
+			if test.Err != nil {
+				assert.EqualError(t, err, fmt.Sprintf("%s", test.Err))
+				continue
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.Msg, msg)
 		}
-		sort.Ints(r2rKeys)
-		for _, value := range r2rKeys {
-			testDoer.R2rChan <- test.R2R[value]
-		}
-		msg, err := client.CreateUpdateTemplate(test.Templ)
-		if test.Err != nil {
-			assert.EqualError(t, err, fmt.Sprintf("%s", test.Err))
-			continue
-		}
-		assert.NoError(t, err)
-		assert.Equal(t, test.Msg, msg)
-	}
+	})
 }
 
 func TestDeleteTemplate(t *testing.T) {
-	var testDoer *TestDoer
-	client := Client{}
-	client.esURL = "http://localhost:80"
-	client.es, testDoer = setupCreateTestClient(t)
-	defer testDoer.Close()
 	tests := []TestDelete{
 		{
 			IndexName: "some_templ",
@@ -256,13 +249,19 @@ func TestDeleteTemplate(t *testing.T) {
 			Err: fmt.Errorf("can't acknowledge ES template deletion"),
 		},
 	}
-	for _, test := range tests {
-		testDoer.R2rChan <- test.R2R
-		err := client.DeleteTemplate(test.IndexName)
-		if test.Err != nil {
-			assert.EqualError(t, err, fmt.Sprintf("%s", test.Err))
-			continue
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		for _, test := range tests {
+			testDoer.R2rChan <- test.R2R
+			err := client.DeleteTemplate(context.Background(), &xov1alpha1.ElasticSearchTemplate{
+				Spec: xov1alpha1.ElasticSearchTemplateSpec{
+					Name: test.IndexName,
+				},
+			})
+			if test.Err != nil {
+				assert.EqualError(t, err, fmt.Sprintf("%s", test.Err))
+				continue
+			}
+			assert.NoError(t, err)
 		}
-		assert.NoError(t, err)
-	}
+	})
 }
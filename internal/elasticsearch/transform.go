@@ -0,0 +1,325 @@
+package elasticsearch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/consts"
+)
+
+const (
+	// TransformStateStopped is state of transform which is not running
+	TransformStateStopped = "stopped"
+	// TransformStateStopping is state of transform which is being stopped
+	TransformStateStopping = "stopping"
+	// TransformStateFailed is state of transform which stopped because of error
+	TransformStateFailed = "failed"
+)
+
+// Transform is configuration struct for ES transform
+type Transform struct {
+	Description string                 `json:"description,omitempty"`
+	Source      TransformSource        `json:"source"`
+	Dest        TransformDest          `json:"dest"`
+	Frequency   string                 `json:"frequency,omitempty"`
+	Sync        *TransformSync         `json:"sync,omitempty"`
+	Pivot       interface{}            `json:"pivot,omitempty"`
+	Latest      interface{}            `json:"latest,omitempty"`
+	Metadata    map[string]interface{} `json:"_meta,omitempty"`
+}
+
+// TransformSource is source of transform
+type TransformSource struct {
+	Index []string    `json:"index"`
+	Query interface{} `json:"query,omitempty"`
+}
+
+// TransformDest is destination of transform
+type TransformDest struct {
+	Index    string `json:"index"`
+	Pipeline string `json:"pipeline,omitempty"`
+}
+
+// TransformSync is time based synchronisation of continuous transform
+type TransformSync struct {
+	Time struct {
+		Field string `json:"field"`
+		Delay string `json:"delay,omitempty"`
+	} `json:"time"`
+}
+
+// TransformStats is state and progress of ES transform
+type TransformStats struct {
+	State            string
+	Reason           string
+	Health           string
+	HealthIssues     []string
+	Checkpoint       int64
+	CheckpointTime   time.Time
+	OperationsBehind int64
+}
+
+// transformResponse is answer of ES get transform API
+type transformResponse struct {
+	Transforms []Transform `json:"transforms"`
+}
+
+// transformStatsResponse is answer of ES get transform stats API
+type transformStatsResponse struct {
+	Transforms []struct {
+		State  string `json:"state"`
+		Reason string `json:"reason"`
+		Health *struct {
+			Status string `json:"status"`
+			Issues []struct {
+				Issue   string `json:"issue"`
+				Details string `json:"details"`
+			} `json:"issues"`
+		} `json:"health"`
+		Checkpointing struct {
+			Last struct {
+				Checkpoint      int64 `json:"checkpoint"`
+				TimestampMillis int64 `json:"timestamp_millis"`
+			} `json:"last"`
+			OperationsBehind int64 `json:"operations_behind"`
+		} `json:"checkpointing"`
+	} `json:"transforms"`
+}
+
+func transformPath(id string) string {
+	return fmt.Sprintf("/_transform/%s", url.PathEscape(id))
+}
+
+// TransformExists would check if transform exists
+func (c *Client) TransformExists(ctx context.Context, id string) (bool, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "transform", FlavorElasticsearch)
+	if err != nil {
+		return false, err
+	}
+	_, err = c.getTransform(ctx, id)
+	if errors.Is(err, errObjectNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("can't check if transform exists: %w", err)
+	}
+	return true, nil
+}
+
+// CreateUpdateTransform would update transform if it exists or create if not.
+// Pivot and latest of transform can't be updated, so transform is deleted and created again when they change.
+func (c *Client) CreateUpdateTransform(ctx context.Context, object *xov1alpha1.ElasticSearchTransform) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "transform", FlavorElasticsearch)
+	if err != nil {
+		return "", err
+	}
+	transform, err := newTransform(object)
+	if err != nil {
+		return "", err
+	}
+	servTransform, err := c.getTransform(ctx, object.Spec.ID)
+	if err != nil && !errors.Is(err, errObjectNotFound) {
+		return "", fmt.Errorf("can't get transform: %w", err)
+	}
+	if servTransform == nil {
+		err = c.perform(ctx, http.MethodPut, transformPath(object.Spec.ID), transform, nil)
+		if err != nil {
+			return "", fmt.Errorf("can't put transform: %w", err)
+		}
+		return fmt.Sprintf("successfully created transform %s", object.Spec.ID), nil
+	}
+	managedBy, _ := getStringValueFromSettings(servTransform.Metadata, consts.ESManagedByField)
+	if managedBy != consts.ESManagedByValue {
+		return "", fmt.Errorf("transform '%s' is not managed by this operator", object.Spec.ID)
+	}
+	normalizeTransform(servTransform, transform)
+	if reflect.DeepEqual(*servTransform, *transform) {
+		return fmt.Sprintf("no changes on transform named %s", object.Spec.ID), nil
+	}
+	if !reflect.DeepEqual(servTransform.Pivot, transform.Pivot) || !reflect.DeepEqual(servTransform.Latest, transform.Latest) {
+		// Force stops transform before it is deleted
+		err = c.perform(ctx, http.MethodDelete, transformPath(object.Spec.ID)+"?force=true", nil, nil)
+		if err != nil {
+			return "", fmt.Errorf("can't delete transform %s to recreate it: %w", object.Spec.ID, err)
+		}
+		err = c.perform(ctx, http.MethodPut, transformPath(object.Spec.ID), transform, nil)
+		if err != nil {
+			return "", fmt.Errorf("can't put transform: %w", err)
+		}
+		return fmt.Sprintf("successfully recreated transform %s", object.Spec.ID), nil
+	}
+	update := *transform
+	update.Pivot = nil
+	update.Latest = nil
+	err = c.perform(ctx, http.MethodPost, transformPath(object.Spec.ID)+"/_update", update, nil)
+	if err != nil {
+		return "", fmt.Errorf("can't update transform: %w", err)
+	}
+	return fmt.Sprintf("successfully updated transform %s", object.Spec.ID), nil
+}
+
+// GetTransformStats would get state, health and last checkpoint of transform
+func (c *Client) GetTransformStats(ctx context.Context, id string) (*TransformStats, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	answer := transformStatsResponse{}
+	err := c.perform(ctx, http.MethodGet, transformPath(id)+"/_stats", nil, &answer)
+	if err != nil {
+		return nil, fmt.Errorf("can't get stats of transform %s: %w", id, err)
+	}
+	if len(answer.Transforms) == 0 {
+		return nil, fmt.Errorf("can't get stats of transform %s: %w", id, errObjectNotFound)
+	}
+	info := answer.Transforms[0]
+	stats := &TransformStats{
+		State:            info.State,
+		Reason:           info.Reason,
+		Checkpoint:       info.Checkpointing.Last.Checkpoint,
+		OperationsBehind: info.Checkpointing.OperationsBehind,
+	}
+	if info.Checkpointing.Last.TimestampMillis > 0 {
+		stats.CheckpointTime = time.UnixMilli(info.Checkpointing.Last.TimestampMillis).UTC()
+	}
+	if info.Health != nil {
+		stats.Health = info.Health.Status
+		for _, issue := range info.Health.Issues {
+			stats.HealthIssues = append(stats.HealthIssues, fmt.Sprintf("%s: %s", issue.Issue, issue.Details))
+		}
+	}
+	return stats, nil
+}
+
+// StartTransform would start transform
+func (c *Client) StartTransform(ctx context.Context, id string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.perform(ctx, http.MethodPost, transformPath(id)+"/_start", nil, nil)
+	if err != nil {
+		return fmt.Errorf("can't start transform %s: %w", id, err)
+	}
+	return nil
+}
+
+// StopTransform would stop transform, running checkpoint is not waited for
+func (c *Client) StopTransform(ctx context.Context, id string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.perform(ctx, http.MethodPost, transformPath(id)+"/_stop", nil, nil)
+	if err != nil {
+		return fmt.Errorf("can't stop transform %s: %w", id, err)
+	}
+	return nil
+}
+
+// DeleteTransform would stop and delete transform, transform which doesn't exist or is not managed by operator is not deleted.
+// Destination index of transform is kept.
+func (c *Client) DeleteTransform(ctx context.Context, id string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "transform", FlavorElasticsearch)
+	if err != nil {
+		return err
+	}
+	servTransform, err := c.getTransform(ctx, id)
+	if errors.Is(err, errObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("can't get transform %s: %w", id, err)
+	}
+	managedBy, _ := getStringValueFromSettings(servTransform.Metadata, consts.ESManagedByField)
+	if managedBy != consts.ESManagedByValue {
+		return fmt.Errorf("transform '%s' is %w", id, ErrNotManaged)
+	}
+	err = c.perform(ctx, http.MethodDelete, transformPath(id)+"?force=true", nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("can't delete transform %s: %w", id, err)
+	}
+	return nil
+}
+
+func (c *Client) getTransform(ctx context.Context, id string) (*Transform, error) {
+	answer := transformResponse{}
+	err := c.perform(ctx, http.MethodGet, transformPath(id), nil, &answer)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errObjectNotFound
+		}
+		return nil, err
+	}
+	if len(answer.Transforms) == 0 {
+		return nil, errObjectNotFound
+	}
+	return &answer.Transforms[0], nil
+}
+
+// normalizeTransform would drop defaults ES cluster adds to transform, so they don't count as changes
+func normalizeTransform(servTransform, transform *Transform) {
+	if transform.Source.Query == nil {
+		servTransform.Source.Query = nil
+	}
+	if len(transform.Frequency) == 0 {
+		servTransform.Frequency = ""
+	}
+	if transform.Sync != nil && servTransform.Sync != nil && len(transform.Sync.Time.Delay) == 0 {
+		servTransform.Sync.Time.Delay = ""
+	}
+	// Metadata is only compared for managed-by
+	servTransform.Metadata = transform.Metadata
+}
+
+// newTransform would make transform ES understands from K8S object
+func newTransform(object *xov1alpha1.ElasticSearchTransform) (*Transform, error) {
+	if (len(object.Spec.Pivot) == 0) == (len(object.Spec.Latest) == 0) {
+		return nil, fmt.Errorf("either pivot or latest must be set for transform %s", object.Spec.ID)
+	}
+	transform := &Transform{
+		Description: object.Spec.Description,
+		Source: TransformSource{
+			Index: object.Spec.Source.Index,
+		},
+		Dest: TransformDest{
+			Index:    object.Spec.Dest.Index,
+			Pipeline: object.Spec.Dest.Pipeline,
+		},
+		Frequency: object.Spec.Frequency,
+		// Metadata marks transform as managed by operator
+		Metadata: map[string]interface{}{
+			consts.ESManagedByField: consts.ESManagedByValue,
+		},
+	}
+	if object.Spec.Sync != nil {
+		transform.Sync = &TransformSync{}
+		transform.Sync.Time.Field = object.Spec.Sync.Time.Field
+		transform.Sync.Time.Delay = object.Spec.Sync.Time.Delay
+	}
+	for _, def := range []struct {
+		name   string
+		source string
+		target *interface{}
+	}{
+		{"Query", object.Spec.Source.Query, &transform.Source.Query},
+		{"Pivot", object.Spec.Pivot, &transform.Pivot},
+		{"Latest", object.Spec.Latest, &transform.Latest},
+	} {
+		if len(def.source) == 0 {
+			continue
+		}
+		err := json.Unmarshal([]byte(def.source), def.target)
+		if err != nil {
+			return nil, fmt.Errorf("can't unmarhsal %s string: %w", def.name, err)
+		}
+	}
+	return transform, nil
+}
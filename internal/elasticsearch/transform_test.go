@@ -0,0 +1,227 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+const (
+	// Used by Doer to mock ES answer on get transform request
+	TransformGETanswer = `{"count":1,"transforms":[{"id":"orders-by-customer","version":"7.17.0","create_time":1700000000000,
+		"source":{"index":["orders-*"],"query":{"match_all":{}}},"dest":{"index":"orders-by-customer"},"frequency":"1m",
+		"sync":{"time":{"field":"order_date","delay":"60s"}},
+		"pivot":{"group_by":{"customer":{"terms":{"field":"customer_id"}}},"aggregations":{"total":{"sum":{"field":"price"}}}},
+		"settings":{},"_meta":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}]}`
+	// Used by Doer to mock ES answer on transform which doesn't exist
+	TransformNotFoundAnswer = `{"error":{"type":"resource_not_found_exception","reason":"Transform with id [orders-by-customer] could not be found"},"status":404}`
+)
+
+func newTestTransform(pivot string) *xov1alpha1.ElasticSearchTransform {
+	return &xov1alpha1.ElasticSearchTransform{
+		Spec: xov1alpha1.ElasticSearchTransformSpec{
+			ID: "orders-by-customer",
+			Source: xov1alpha1.ESTransformSource{
+				Index: []string{"orders-*"},
+			},
+			Dest: xov1alpha1.ESTransformDest{
+				Index: "orders-by-customer",
+			},
+			Pivot: pivot,
+			Sync: &xov1alpha1.ESTransformSync{
+				Time: xov1alpha1.ESTransformSyncTime{Field: "order_date"},
+			},
+		},
+	}
+}
+
+func TestCreateUpdateTransform(t *testing.T) {
+	pivot := `{"group_by":{"customer":{"terms":{"field":"customer_id"}}},"aggregations":{"total":{"sum":{"field":"price"}}}}`
+	newPivot := `{"group_by":{"customer":{"terms":{"field":"customer_id"}}},"aggregations":{"total":{"avg":{"field":"price"}}}}`
+	frequent := newTestTransform(pivot)
+	frequent.Spec.Frequency = "5m"
+	both := newTestTransform(pivot)
+	both.Spec.Latest = `{"unique_key":["customer_id"],"sort":"order_date"}`
+	tests := []requestTest[*xov1alpha1.ElasticSearchTransform, string]{
+		{
+			Object: newTestTransform(pivot),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_transform/orders-by-customer",
+					ResponceCode: 404,
+					Responce:     TransformNotFoundAnswer,
+				},
+				{
+					RequestURI:   "/_transform/orders-by-customer",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+					RequestBody: `{"source":{"index":["orders-*"]},"dest":{"index":"orders-by-customer"},` +
+						`"sync":{"time":{"field":"order_date"}},"pivot":` + pivot + `,` +
+						`"_meta":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}`,
+				},
+			},
+			Want: "successfully created transform orders-by-customer",
+		},
+		{
+			// Defaults set by ES cluster are not changes
+			Object: newTestTransform(pivot),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_transform/orders-by-customer",
+					ResponceCode: 200,
+					Responce:     TransformGETanswer,
+				},
+			},
+			Want: "no changes on transform named orders-by-customer",
+		},
+		{
+			Object: frequent,
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_transform/orders-by-customer",
+					ResponceCode: 200,
+					Responce:     TransformGETanswer,
+				},
+				{
+					RequestURI:   "/_transform/orders-by-customer/_update",
+					ResponceCode: 200,
+					Responce:     `{"id":"orders-by-customer"}`,
+					RequestBody: `{"source":{"index":["orders-*"]},"dest":{"index":"orders-by-customer"},"frequency":"5m",` +
+						`"sync":{"time":{"field":"order_date"}},"_meta":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}`,
+				},
+			},
+			Want: "successfully updated transform orders-by-customer",
+		},
+		{
+			// Pivot can't be updated, transform is recreated
+			Object: newTestTransform(newPivot),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_transform/orders-by-customer",
+					ResponceCode: 200,
+					Responce:     TransformGETanswer,
+				},
+				{
+					RequestURI:   "/_transform/orders-by-customer?force=true",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+				},
+				{
+					RequestURI:   "/_transform/orders-by-customer",
+					ResponceCode: 200,
+					Responce:     `{"acknowledged":true}`,
+					RequestBody: `{"source":{"index":["orders-*"]},"dest":{"index":"orders-by-customer"},` +
+						`"sync":{"time":{"field":"order_date"}},"pivot":` + newPivot + `,` +
+						`"_meta":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}`,
+				},
+			},
+			Want: "successfully recreated transform orders-by-customer",
+		},
+		{
+			// Transform created outside of operator is not changed
+			Object: newTestTransform(newPivot),
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_transform/orders-by-customer",
+					ResponceCode: 200,
+					Responce: `{"count":1,"transforms":[{"id":"orders-by-customer","source":{"index":["orders-*"]},` +
+						`"dest":{"index":"orders-by-customer"},"pivot":` + pivot + `}]}`,
+				},
+			},
+			Err: "transform 'orders-by-customer' is not managed by this operator",
+		},
+		{
+			Object: both,
+			Err:    "either pivot or latest must be set for transform orders-by-customer",
+		},
+	}
+	runRequestTests(t, tests, (*Client).CreateUpdateTransform)
+}
+
+func TestGetTransformStats(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_transform/orders-by-customer/_stats",
+			ResponceCode: 200,
+			Responce: `{"count":1,"transforms":[{"id":"orders-by-customer","state":"started",
+				"health":{"status":"yellow","issues":[{"issue":"Transform task is automatically retrying its search",
+				"details":"no such index [orders-2024]","count":2}]},
+				"checkpointing":{"last":{"checkpoint":12,"timestamp_millis":1700000000000},"operations_behind":42}}]}`,
+		}
+		stats, err := client.GetTransformStats(context.Background(), "orders-by-customer")
+		assert.NoError(t, err)
+		assert.Equal(t, &TransformStats{
+			State:            "started",
+			Health:           "yellow",
+			HealthIssues:     []string{"Transform task is automatically retrying its search: no such index [orders-2024]"},
+			Checkpoint:       12,
+			CheckpointTime:   time.UnixMilli(1700000000000).UTC(),
+			OperationsBehind: 42,
+		}, stats)
+
+		// 7.x clusters don't report health
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_transform/orders-by-customer/_stats",
+			ResponceCode: 200,
+			Responce: `{"count":1,"transforms":[{"id":"orders-by-customer","state":"failed",
+				"reason":"task encountered irrecoverable failure","checkpointing":{"last":{"checkpoint":0}}}]}`,
+		}
+		stats, err = client.GetTransformStats(context.Background(), "orders-by-customer")
+		assert.NoError(t, err)
+		assert.Equal(t, &TransformStats{
+			State:  "failed",
+			Reason: "task encountered irrecoverable failure",
+		}, stats)
+	})
+}
+
+func TestStartStopTransform(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_transform/orders-by-customer/_start",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true}`,
+		}
+		assert.NoError(t, client.StartTransform(context.Background(), "orders-by-customer"))
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_transform/orders-by-customer/_stop",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true}`,
+		}
+		assert.NoError(t, client.StopTransform(context.Background(), "orders-by-customer"))
+	})
+}
+
+func TestDeleteTransform(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_transform/orders-by-customer",
+			ResponceCode: 404,
+			Responce:     TransformNotFoundAnswer,
+		}
+		assert.NoError(t, client.DeleteTransform(context.Background(), "orders-by-customer"))
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_transform/orders-by-customer",
+			ResponceCode: 200,
+			Responce:     TransformGETanswer,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_transform/orders-by-customer?force=true",
+			ResponceCode: 200,
+			Responce:     `{"acknowledged":true}`,
+		}
+		assert.NoError(t, client.DeleteTransform(context.Background(), "orders-by-customer"))
+	})
+}
+
+func TestTransformOpenSearch(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		client.flavor = FlavorOpenSearch
+		_, err := client.CreateUpdateTransform(context.Background(), newTestTransform(`{"group_by":{}}`))
+		assert.EqualError(t, err, "transform is not supported by opensearch cluster")
+	})
+}
@@ -0,0 +1,146 @@
+package elasticsearch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+	"github.com/90poe/elasticsearch-objects-operator/internal/consts"
+)
+
+// User is configuration struct for ES native realm user
+type User struct {
+	Password string                 `json:"password,omitempty"`
+	Roles    []string               `json:"roles"`
+	FullName string                 `json:"full_name,omitempty"`
+	Email    string                 `json:"email,omitempty"`
+	Enabled  bool                   `json:"enabled"`
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+}
+
+func userPath(name string) string {
+	return fmt.Sprintf("/_security/user/%s", url.PathEscape(name))
+}
+
+// UserExists would check if user exists
+func (c *Client) UserExists(ctx context.Context, name string) (bool, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "security user", FlavorElasticsearch)
+	if err != nil {
+		return false, err
+	}
+	_, err = c.getUser(ctx, name)
+	if errors.Is(err, errObjectNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("can't check if user exists: %w", err)
+	}
+	return true, nil
+}
+
+// CreateUpdateUser would update user if it exists or create if not. Password is set only if it is not empty,
+// it is required to create user.
+func (c *Client) CreateUpdateUser(ctx context.Context, object *xov1alpha1.ElasticSearchUser, password string) (string, error) {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "security user", FlavorElasticsearch)
+	if err != nil {
+		return "", err
+	}
+	user := newUser(object)
+	retMsg := "successfully created user %s"
+	servUser, err := c.getUser(ctx, object.Spec.Username)
+	if err != nil && !errors.Is(err, errObjectNotFound) {
+		return "", fmt.Errorf("can't get user: %w", err)
+	}
+	if servUser == nil && len(password) == 0 {
+		return "", fmt.Errorf("password is required to create user %s", object.Spec.Username)
+	}
+	if servUser != nil {
+		managedBy, _ := getStringValueFromSettings(servUser.Metadata, consts.ESManagedByField)
+		if managedBy != consts.ESManagedByValue {
+			return "", fmt.Errorf("user '%s' is not managed by this operator", object.Spec.Username)
+		}
+		// Metadata is only compared for managed-by
+		servUser.Metadata = user.Metadata
+		if len(password) == 0 && reflect.DeepEqual(*servUser, *user) {
+			return fmt.Sprintf("no changes on user named %s", object.Spec.Username), nil
+		}
+		retMsg = "successfully updated user %s"
+	}
+	user.Password = password
+	err = c.perform(ctx, http.MethodPut, userPath(object.Spec.Username), user, nil)
+	if err != nil {
+		return "", fmt.Errorf("can't put user: %w", err)
+	}
+	return fmt.Sprintf(retMsg, object.Spec.Username), nil
+}
+
+// DeleteUser would delete user, user which doesn't exist or is not managed by operator is not deleted
+func (c *Client) DeleteUser(ctx context.Context, name string) error {
+	ctx, cancel := c.withTimeout(ctx)
+	defer cancel()
+	err := c.requireFlavor(ctx, "security user", FlavorElasticsearch)
+	if err != nil {
+		return err
+	}
+	servUser, err := c.getUser(ctx, name)
+	if errors.Is(err, errObjectNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("can't get user %s: %w", name, err)
+	}
+	managedBy, _ := getStringValueFromSettings(servUser.Metadata, consts.ESManagedByField)
+	if managedBy != consts.ESManagedByValue {
+		return fmt.Errorf("user '%s' is %w", name, ErrNotManaged)
+	}
+	err = c.perform(ctx, http.MethodDelete, userPath(name), nil, nil)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("can't delete user %s: %w", name, err)
+	}
+	return nil
+}
+
+func (c *Client) getUser(ctx context.Context, name string) (*User, error) {
+	users := map[string]User{}
+	err := c.perform(ctx, http.MethodGet, userPath(name), nil, &users)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errObjectNotFound
+		}
+		return nil, err
+	}
+	user, ok := users[name]
+	if !ok {
+		return nil, errObjectNotFound
+	}
+	if user.Roles == nil {
+		user.Roles = []string{}
+	}
+	return &user, nil
+}
+
+// newUser would make user ES understands from K8S object, password is not set
+func newUser(object *xov1alpha1.ElasticSearchUser) *User {
+	user := &User{
+		Roles:    object.Spec.Roles,
+		FullName: object.Spec.FullName,
+		Email:    object.Spec.Email,
+		Enabled:  object.Spec.Enabled == nil || *object.Spec.Enabled,
+		// Metadata marks user as managed by operator
+		Metadata: map[string]interface{}{
+			consts.ESManagedByField: consts.ESManagedByValue,
+		},
+	}
+	if user.Roles == nil {
+		user.Roles = []string{}
+	}
+	return user
+}
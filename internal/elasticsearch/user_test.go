@@ -0,0 +1,153 @@
+package elasticsearch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	xov1alpha1 "github.com/90poe/elasticsearch-objects-operator/api/v1alpha1"
+)
+
+const (
+	// Used by Doer to mock ES answer on get user request
+	UserGETanswer = `{"orders-service":{"username":"orders-service","roles":["orders-reader"],"full_name":"Orders service","email":null,
+		"metadata":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"},"enabled":true}}`
+	// Used by Doer to mock ES answer on get user request for user created outside of operator
+	UserNotManagedGETanswer = `{"orders-service":{"username":"orders-service","roles":["superuser"],"full_name":null,"email":null,"metadata":{},"enabled":true}}`
+)
+
+func newTestUser(roles ...string) *xov1alpha1.ElasticSearchUser {
+	return &xov1alpha1.ElasticSearchUser{
+		Spec: xov1alpha1.ElasticSearchUserSpec{
+			Username: "orders-service",
+			FullName: "Orders service",
+			Roles:    roles,
+		},
+	}
+}
+
+// userUpdate is user object with password it is updated with
+type userUpdate struct {
+	User     *xov1alpha1.ElasticSearchUser
+	Password string
+}
+
+func TestCreateUpdateUser(t *testing.T) {
+	tests := []requestTest[userUpdate, string]{
+		{
+			Object: userUpdate{User: newTestUser("orders-reader"), Password: "secret-password"},
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_security/user/orders-service",
+					ResponceCode: 404,
+					Responce:     `{}`,
+				},
+				{
+					RequestURI:   "/_security/user/orders-service",
+					ResponceCode: 200,
+					Responce:     `{"created":true}`,
+					RequestBody: `{"password":"secret-password","roles":["orders-reader"],"full_name":"Orders service","enabled":true,
+						"metadata":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}`,
+				},
+			},
+			Want: "successfully created user orders-service",
+		},
+		{
+			Object: userUpdate{User: newTestUser("orders-reader")},
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_security/user/orders-service",
+					ResponceCode: 404,
+					Responce:     `{}`,
+				},
+			},
+			Err: "password is required to create user orders-service",
+		},
+		{
+			Object: userUpdate{User: newTestUser("orders-reader")},
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_security/user/orders-service",
+					ResponceCode: 200,
+					Responce:     UserGETanswer,
+				},
+			},
+			Want: "no changes on user named orders-service",
+		},
+		{
+			// Roles are changed, password is kept
+			Object: userUpdate{User: newTestUser("orders-reader", "orders-writer")},
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_security/user/orders-service",
+					ResponceCode: 200,
+					Responce:     UserGETanswer,
+				},
+				{
+					RequestURI:   "/_security/user/orders-service",
+					ResponceCode: 200,
+					Responce:     `{"created":false}`,
+					RequestBody: `{"roles":["orders-reader","orders-writer"],"full_name":"Orders service","enabled":true,
+						"metadata":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}`,
+				},
+			},
+			Want: "successfully updated user orders-service",
+		},
+		{
+			// Password is rotated
+			Object: userUpdate{User: newTestUser("orders-reader"), Password: "new-password"},
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_security/user/orders-service",
+					ResponceCode: 200,
+					Responce:     UserGETanswer,
+				},
+				{
+					RequestURI:   "/_security/user/orders-service",
+					ResponceCode: 200,
+					Responce:     `{"created":false}`,
+					RequestBody: `{"password":"new-password","roles":["orders-reader"],"full_name":"Orders service","enabled":true,
+						"metadata":{"managed-by":"elasticsearch-objects-operator.xo.90poe.io"}}`,
+				},
+			},
+			Want: "successfully updated user orders-service",
+		},
+		{
+			Object: userUpdate{User: newTestUser("orders-reader"), Password: "new-password"},
+			R2R: []Responce2Req{
+				{
+					RequestURI:   "/_security/user/orders-service",
+					ResponceCode: 200,
+					Responce:     UserNotManagedGETanswer,
+				},
+			},
+			Err: "user 'orders-service' is not managed by this operator",
+		},
+	}
+	runRequestTests(t, tests, func(client *Client, ctx context.Context, update userUpdate) (string, error) {
+		return client.CreateUpdateUser(ctx, update.User, update.Password)
+	})
+}
+
+func TestDeleteUser(t *testing.T) {
+	forEachDriver(t, func(t *testing.T, client *Client, testDoer *TestDoer) {
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_security/user/orders-service",
+			ResponceCode: 200,
+			Responce:     UserNotManagedGETanswer,
+		}
+		assert.ErrorIs(t, client.DeleteUser(context.Background(), "orders-service"), ErrNotManaged)
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_security/user/orders-service",
+			ResponceCode: 200,
+			Responce:     UserGETanswer,
+		}
+		testDoer.R2rChan <- Responce2Req{
+			RequestURI:   "/_security/user/orders-service",
+			ResponceCode: 200,
+			Responce:     `{"found":true}`,
+		}
+		assert.NoError(t, client.DeleteUser(context.Background(), "orders-service"))
+	})
+}
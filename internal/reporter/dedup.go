@@ -0,0 +1,119 @@
+package reporter
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DedupWindow is default interval between summaries of repeated messages about the same object
+	DedupWindow = time.Hour
+	// FailureTTL is how long we remember failing object which is not reported anymore
+	FailureTTL = 24 * time.Hour
+)
+
+// failure holds state of repeated failing messages about one object sent to one destination
+type failure struct {
+	msg        Message
+	count      int
+	suppressed int
+	lastSent   time.Time
+	lastSeen   time.Time
+}
+
+// deduplicator would suppress identical messages about the same object within window,
+// summarise suppressed messages and report resolution of failures.
+// It is not thread safe and must only be used from Messenger run loop.
+type deduplicator struct {
+	window   time.Duration
+	failures map[string]map[string]*failure
+}
+
+func newDeduplicator(window time.Duration) *deduplicator {
+	return &deduplicator{
+		window:   window,
+		failures: map[string]map[string]*failure{},
+	}
+}
+
+// filter would return messages which must be sent instead of msg
+func (d *deduplicator) filter(msg Message, now time.Time) []Message {
+	if len(msg.object) == 0 {
+		// Messages not related to object are never deduplicated
+		return []Message{msg}
+	}
+	if msg.MsgType() == OKMessage {
+		return d.resolve(msg, now)
+	}
+	destinations, ok := d.failures[msg.object]
+	if !ok {
+		destinations = map[string]*failure{}
+		d.failures[msg.object] = destinations
+	}
+	fail, ok := destinations[msg.destination]
+	if !ok || fail.msg.message != msg.message || fail.msg.msgType != msg.msgType {
+		// New failure or failure has changed - report it right away
+		destinations[msg.destination] = &failure{
+			msg:      msg,
+			count:    1,
+			lastSent: now,
+			lastSeen: now,
+		}
+		return []Message{msg}
+	}
+	fail.count++
+	fail.suppressed++
+	fail.lastSeen = now
+	return nil
+}
+
+// resolve would return "resolved" messages for every destination object failures were reported to
+func (d *deduplicator) resolve(msg Message, now time.Time) []Message {
+	destinations, ok := d.failures[msg.object]
+	if !ok {
+		// Nothing was failing - success is not worth reporting
+		return nil
+	}
+	delete(d.failures, msg.object)
+	ret := make([]Message, 0, len(destinations))
+	for destination, fail := range destinations {
+		ret = append(ret, Message{
+			time:        now,
+			message:     fmt.Sprintf("resolved after %d failures: %s", fail.count, msg.message),
+			msgType:     OKMessage,
+			destination: destination,
+			object:      msg.object,
+		})
+	}
+	return ret
+}
+
+// summaries would return "still failing" messages for failures which were suppressed for longer than window.
+// It also forgets failures which were not seen for FailureTTL.
+func (d *deduplicator) summaries(now time.Time) []Message {
+	ret := []Message{}
+	for object, destinations := range d.failures {
+		for destination, fail := range destinations {
+			if now.Sub(fail.lastSeen) >= FailureTTL {
+				delete(destinations, destination)
+				continue
+			}
+			if fail.suppressed == 0 || now.Sub(fail.lastSent) < d.window {
+				continue
+			}
+			ret = append(ret, Message{
+				time:        now,
+				message:     fmt.Sprintf("still failing (%d times): %s", fail.count, fail.msg.message),
+				msgType:     fail.msg.msgType,
+				destination: destination,
+				object:      object,
+			})
+			fail.suppressed = 0
+			fail.lastSent = now
+		}
+		if len(destinations) == 0 {
+			delete(d.failures, object)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,56 @@
+package reporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeduplicator(t *testing.T) {
+	now := time.Now()
+	dedup := newDeduplicator(time.Hour)
+	failing := Message{message: "can't create index", msgType: ErrorMessage, object: "index/ns/name"}
+
+	// Messages without object are never deduplicated
+	plain := Message{message: "plain", msgType: ErrorMessage}
+	assert.Len(t, dedup.filter(plain, now), 1)
+	assert.Len(t, dedup.filter(plain, now), 1)
+
+	// OK message about object which wasn't failing is dropped
+	assert.Empty(t, dedup.filter(Message{message: "ok", msgType: OKMessage, object: "index/ns/name"}, now))
+
+	// First failure is sent, identical ones are suppressed
+	assert.Len(t, dedup.filter(failing, now), 1)
+	assert.Empty(t, dedup.filter(failing, now.Add(time.Minute)))
+	assert.Empty(t, dedup.filter(failing, now.Add(2*time.Minute)))
+
+	// No summary until window passes
+	assert.Empty(t, dedup.summaries(now.Add(30*time.Minute)))
+	summaries := dedup.summaries(now.Add(61 * time.Minute))
+	assert.Len(t, summaries, 1)
+	assert.Equal(t, "still failing (3 times): can't create index", summaries[0].message)
+	assert.Equal(t, MessageType(ErrorMessage), summaries[0].MsgType())
+	// Summary is not repeated if nothing new was suppressed
+	assert.Empty(t, dedup.summaries(now.Add(3*time.Hour)))
+
+	// Changed failure is reported right away
+	changed := failing
+	changed.message = "can't update index"
+	assert.Len(t, dedup.filter(changed, now.Add(3*time.Hour)), 1)
+
+	// OK message resolves failure
+	resolved := dedup.filter(Message{message: "index updated", msgType: OKMessage, object: "index/ns/name"}, now.Add(4*time.Hour))
+	assert.Len(t, resolved, 1)
+	assert.Equal(t, "resolved after 1 failures: index updated", resolved[0].message)
+	assert.Empty(t, dedup.failures)
+}
+
+func TestDeduplicatorForgetsStaleFailures(t *testing.T) {
+	now := time.Now()
+	dedup := newDeduplicator(time.Hour)
+	failing := Message{message: "can't create index", msgType: ErrorMessage, object: "index/ns/name", destination: "team"}
+	assert.Len(t, dedup.filter(failing, now), 1)
+	assert.Empty(t, dedup.summaries(now.Add(FailureTTL)))
+	assert.Empty(t, dedup.failures)
+}
@@ -15,9 +15,11 @@ const (
 type (
 	MessageType uint8
 	Message     struct {
-		time    time.Time
-		message string
-		msgType MessageType
+		time        time.Time
+		message     string
+		msgType     MessageType
+		destination string
+		object      string
 	}
 )
 
@@ -35,6 +37,28 @@ func (m *Message) MsgType() MessageType {
 	return m.msgType
 }
 
+// Destination would return notifier target of this message, empty means default target
+func (m *Message) Destination() string {
+	return m.destination
+}
+
+// Object would return key of object this message is about, empty means message is not related to object
+func (m *Message) Object() string {
+	return m.object
+}
+
+// String would return human readable name of message type
+func (t MessageType) String() string {
+	switch t {
+	case OKMessage:
+		return "OK"
+	case WarnMessage:
+		return "Warning"
+	default:
+		return "Error"
+	}
+}
+
 func (m *Message) String() string {
 	return fmt.Sprintf("%s: %s", m.time.Format("2006-01-02 15:04:05"), m.message)
 }
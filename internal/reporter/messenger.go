@@ -18,10 +18,13 @@ type Messenger struct {
 	slackChannel string
 	slackClient  Slack
 	slackChan    chan Message
+	queueSize    int
+	dedupWindow  time.Duration
 }
 
 const (
 	FlushInterval   = 30 * time.Second
+	QueueSize       = 100
 	BotName         = "Kafka objects operator"
 	MsgColorOK      = "#00CC00"
 	MsgColorWarning = "#F5EC1E"
@@ -33,7 +36,8 @@ func New(token string, options ...Options) (*Messenger, error) {
 	mess := &Messenger{
 		tickInterval: FlushInterval,
 		httpClient:   &http.Client{},
-		slackChan:    make(chan Message),
+		queueSize:    QueueSize,
+		dedupWindow:  DedupWindow,
 	}
 	var err error
 	for _, option := range options {
@@ -54,50 +58,115 @@ func New(token string, options ...Options) (*Messenger, error) {
 			mess.slackClient = slack.New(token, slack.OptionHTTPClient(mess.httpClient))
 		}
 	}
-	// Run messenger
-	go mess.run()
+	mess.slackChan = make(chan Message, mess.queueSize)
 	return mess, nil
 }
 
-// Send will send message to slack
+// Start will run messenger until context is cancelled, it implements manager.Runnable
+// so Messenger must be added to controller manager. Pending messages are flushed on exit.
+func (m *Messenger) Start(ctx context.Context) error {
+	m.run(ctx)
+	return nil
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable, messenger must run on every replica
+// to flush messages of reconciles which are still in progress when leadership is lost
+func (m *Messenger) NeedLeaderElection() bool {
+	return false
+}
+
+// Send will send message to default slack channel
 func (m *Messenger) Send(msg string, msgType MessageType) {
-	m.slackChan <- Message{
-		time:    time.Now(),
-		message: msg,
-		msgType: msgType,
+	m.SendTo("", "", msg, msgType)
+}
+
+// SendTo will send message about object to given slack channel, empty channel means default one.
+// Identical messages about the same object are deduplicated: repeated ones are summarised once per
+// dedup window and OK message about failing object is reported as resolution. OK messages about
+// objects which were not failing are dropped. Empty object means message is always sent.
+// SendTo never blocks: if queue is full, message is dropped and counted in metrics.
+func (m *Messenger) SendTo(channel, object, msg string, msgType MessageType) {
+	message := Message{
+		time:        time.Now(),
+		message:     msg,
+		msgType:     msgType,
+		destination: strings.Trim(channel, " \t"),
+		object:      object,
+	}
+	select {
+	case m.slackChan <- message:
+	default:
+		notificationsDropped.Inc()
+		log.FromContext(context.Background()).WithValues("reporter", "slack").V(1).Info(
+			fmt.Sprintf("notification queue is full, dropping message: %s", msg))
 	}
 }
 
 // run will send all messages from channel to slack
-// We will close if close channel is closed
+// We will exit and flush pending messages when context is cancelled
 // We also flush every FlushInterval seconds
-func (m *Messenger) run() {
+func (m *Messenger) run(ctx context.Context) {
 	messages := []Message{}
+	dedup := newDeduplicator(m.dedupWindow)
 	ticker := time.NewTicker(m.tickInterval)
-	defer func() {
-		close(m.slackChan)
-		m.flush(messages)
-	}()
+	defer ticker.Stop()
 	for {
 		select {
+		case <-ctx.Done():
+			m.flush(append(messages, m.drain(dedup)...))
+			return
 		case msg := <-m.slackChan:
-			messages = append(messages, msg)
+			messages = append(messages, dedup.filter(msg, time.Now())...)
 			if len(messages) >= 10 {
 				m.flush(messages)
 				messages = []Message{}
 			}
 		case <-ticker.C:
+			messages = append(messages, dedup.summaries(time.Now())...)
 			m.flush(messages)
 			messages = []Message{}
 		}
 	}
 }
 
-// flush will prepare and sort all messages to be sent to slack
+// drain will return messages which are already queued without waiting for new ones
+func (m *Messenger) drain(dedup *deduplicator) []Message {
+	messages := []Message{}
+	for {
+		select {
+		case msg := <-m.slackChan:
+			messages = append(messages, dedup.filter(msg, time.Now())...)
+		default:
+			return messages
+		}
+	}
+}
+
+// flush will group messages per destination and send each group to slack
 func (m *Messenger) flush(messages []Message) {
 	if len(messages) == 0 {
 		return
 	}
+	// divide messages by destination, keeping order of first appearance
+	destinations := []string{}
+	perDestination := map[string][]Message{}
+	for _, msg := range messages {
+		channel := msg.Destination()
+		if len(channel) == 0 {
+			channel = m.slackChannel
+		}
+		if _, ok := perDestination[channel]; !ok {
+			destinations = append(destinations, channel)
+		}
+		perDestination[channel] = append(perDestination[channel], msg)
+	}
+	for _, channel := range destinations {
+		m.flushTo(channel, perDestination[channel])
+	}
+}
+
+// flushTo will prepare and sort messages to be sent to one slack channel
+func (m *Messenger) flushTo(channel string, messages []Message) {
 	// divide messages by type
 	okMessages := []string{}
 	warningMessages := []string{}
@@ -114,18 +183,18 @@ func (m *Messenger) flush(messages []Message) {
 	}
 	// send messages to slack
 	if len(okMessages) != 0 {
-		m.send(strings.Join(okMessages, "\n"), "OK", MsgColorOK)
+		m.send(channel, strings.Join(okMessages, "\n"), MessageType(OKMessage).String(), MsgColorOK)
 	}
 	if len(warningMessages) != 0 {
-		m.send(strings.Join(warningMessages, "\n"), "Warning", MsgColorWarning)
+		m.send(channel, strings.Join(warningMessages, "\n"), MessageType(WarnMessage).String(), MsgColorWarning)
 	}
 	if len(errorMessages) != 0 {
-		m.send(strings.Join(errorMessages, "\n"), "OK", MsgColorError)
+		m.send(channel, strings.Join(errorMessages, "\n"), MessageType(ErrorMessage).String(), MsgColorError)
 	}
 }
 
-// send will send message to slack or to log
-func (m *Messenger) send(msg, title, color string) {
+// send will send message to slack channel or to log
+func (m *Messenger) send(channel, msg, title, color string) {
 	// system logger
 	reqLogger := log.FromContext(context.Background()).WithValues("reporter", "slack")
 	// Check if slack client is initialised
@@ -142,10 +211,13 @@ func (m *Messenger) send(msg, title, color string) {
 		Footer:     BotName,
 		FooterIcon: BotLogo,
 	}
-	_, _, err := m.slackClient.PostMessage(m.slackChannel,
+	_, _, err := m.slackClient.PostMessage(channel,
 		slack.MsgOptionUsername(BotName),
 		slack.MsgOptionAttachments(attachment))
 	if err != nil {
+		notificationsFailed.Inc()
 		reqLogger.V(1).Info(fmt.Sprintf("can't send errors message to Slack: %v", err))
+		return
 	}
+	notificationsSent.Inc()
 }
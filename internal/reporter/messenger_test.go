@@ -1,6 +1,7 @@
 package reporter_test
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -25,6 +26,11 @@ func TestMessenger_Send(t *testing.T) {
 		reporter.TickInterval(5*time.Second),
 	)
 	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = m.Start(ctx)
+	}()
 	mSlack.EXPECT().PostMessage("test-channel", gomock.Any()).Return("", "", nil)
 	m.Send("test message 1", reporter.OKMessage)
 	time.Sleep(7 * time.Second)
@@ -32,3 +38,91 @@ func TestMessenger_Send(t *testing.T) {
 	m.Send("test message 2", reporter.OKMessage)
 	time.Sleep(7 * time.Second)
 }
+
+func TestMessenger_SendTo(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mSlack := mock_slack.NewMockSlack(ctrl)
+	m, err := reporter.New(
+		"",
+		reporter.SlackChannel("test-channel"),
+		reporter.SlackClient(mSlack),
+		reporter.TickInterval(2*time.Second),
+	)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = m.Start(ctx)
+	}()
+	// Each destination gets own batch
+	mSlack.EXPECT().PostMessage("test-channel", gomock.Any()).Return("", "", nil)
+	mSlack.EXPECT().PostMessage("team-channel", gomock.Any()).Return("", "", nil)
+	m.Send("test message 1", reporter.ErrorMessage)
+	m.SendTo("team-channel", "", "test message 2", reporter.ErrorMessage)
+	m.SendTo("team-channel", "", "test message 3", reporter.ErrorMessage)
+	time.Sleep(3 * time.Second)
+}
+
+func TestMessenger_FlushOnStop(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mSlack := mock_slack.NewMockSlack(ctrl)
+	m, err := reporter.New(
+		"",
+		reporter.SlackChannel("test-channel"),
+		reporter.SlackClient(mSlack),
+		reporter.TickInterval(time.Hour),
+	)
+	require.NoError(t, err)
+	// Messages queued before start must be flushed when messenger stops
+	m.Send("test message 1", reporter.ErrorMessage)
+	m.Send("test message 2", reporter.ErrorMessage)
+	mSlack.EXPECT().PostMessage("test-channel", gomock.Any()).Return("", "", nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = m.Start(ctx)
+		close(done)
+	}()
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("messenger didn't stop")
+	}
+}
+
+func TestMessenger_DropWhenQueueIsFull(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mSlack := mock_slack.NewMockSlack(ctrl)
+	m, err := reporter.New(
+		"",
+		reporter.SlackChannel("test-channel"),
+		reporter.SlackClient(mSlack),
+		reporter.QueueLength(1),
+	)
+	require.NoError(t, err)
+	// Messenger is not running, so second message must be dropped without blocking
+	sent := make(chan struct{})
+	go func() {
+		m.Send("test message 1", reporter.ErrorMessage)
+		m.Send("test message 2", reporter.ErrorMessage)
+		close(sent)
+	}()
+	select {
+	case <-sent:
+	case <-time.After(5 * time.Second):
+		t.Fatal("send blocked on full queue")
+	}
+}
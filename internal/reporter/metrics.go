@@ -0,0 +1,29 @@
+package reporter
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// notificationsDropped counts messages dropped because Messenger queue was full
+	notificationsDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "elasticsearch_objects_operator_notifications_dropped_total",
+		Help: "Number of notifications dropped because notification queue was full",
+	})
+	// notificationsFailed counts batches of messages which we failed to post to Slack
+	notificationsFailed = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "elasticsearch_objects_operator_notifications_failed_total",
+		Help: "Number of notification batches which failed to be sent to Slack",
+	})
+	// notificationsSent counts batches of messages posted to Slack
+	notificationsSent = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "elasticsearch_objects_operator_notifications_sent_total",
+		Help: "Number of notification batches successfully sent to Slack",
+	})
+)
+
+func init() {
+	// Register custom metrics with the global prometheus registry
+	metrics.Registry.MustRegister(notificationsDropped, notificationsFailed, notificationsSent)
+}
@@ -48,3 +48,25 @@ func TickInterval(tk time.Duration) Options {
 		return nil
 	}
 }
+
+// DedupInterval will set interval between summaries of repeated messages about the same object
+func DedupInterval(di time.Duration) Options {
+	return func(s *Messenger) error {
+		if di <= 0 {
+			return fmt.Errorf("dedup interval must be positive")
+		}
+		s.dedupWindow = di
+		return nil
+	}
+}
+
+// QueueLength will set how many messages could be queued before new ones are dropped
+func QueueLength(ql int) Options {
+	return func(s *Messenger) error {
+		if ql <= 0 {
+			return fmt.Errorf("queue length must be positive")
+		}
+		s.queueSize = ql
+		return nil
+	}
+}